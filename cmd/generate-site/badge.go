@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+// badgeFile is the shields.io endpoint JSON written to the static site, so
+// a README can show the live overall rate with
+// https://img.shields.io/endpoint?url=<site>/badge.json.
+const badgeFile = "badge.json"
+
+// Overall rate thresholds for the badge color, matching the emoji
+// thresholds of the markdown combination grid.
+const (
+	badgeGoodRate = 95.0
+	badgeFairRate = 70.0
+)
+
+// Badge is a shields.io endpoint badge:
+// https://shields.io/badges/endpoint-badge
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// computeBadge builds the badge for the summary's overall success rate:
+// brightgreen at badgeGoodRate or above, yellow at badgeFairRate or above,
+// red below.
+func computeBadge(summary SummaryData) Badge {
+	color := "red"
+	switch {
+	case summary.OverallRate >= badgeGoodRate:
+		color = "brightgreen"
+	case summary.OverallRate >= badgeFairRate:
+		color = "yellow"
+	}
+
+	return Badge{
+		SchemaVersion: 1,
+		Label:         "QR compat",
+		Message:       fmt.Sprintf("%.1f%%", summary.OverallRate),
+		Color:         color,
+	}
+}
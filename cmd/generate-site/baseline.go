@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// defaultBaselineTolerance is the success rate drop, in percentage points,
+// that -baseline allows before failing.
+const defaultBaselineTolerance = 1.0
+
+// Regression is a success rate that dropped against the baseline by more
+// than the tolerance.
+type Regression struct {
+	Kind     string // "overall", "encoder", or "decoder"
+	Name     string
+	Baseline float64
+	Current  float64
+}
+
+// loadSummary reads a summary.json written by an earlier generate-site run.
+// Files written before the gated rates existed had no expected failures
+// left out, so their published rates stand in for the gated ones.
+func loadSummary(path string) (SummaryData, error) {
+	var summary SummaryData
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return summary, err
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return summary, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var gated struct {
+		GatedOverallRate *float64 `json:"gatedOverallRate"`
+	}
+	if err := json.Unmarshal(data, &gated); err == nil && gated.GatedOverallRate == nil {
+		summary.GatedOverallRate = summary.OverallRate
+		summary.GatedEncoderRates = summary.EncoderRates
+		summary.GatedDecoderRates = summary.DecoderRates
+	}
+	return summary, nil
+}
+
+// compareToBaseline returns every gated rate that dropped by more than
+// tolerance percentage points: the overall rate first, then encoders and
+// decoders by name. Libraries missing from either summary are not compared.
+func compareToBaseline(baseline, current SummaryData, tolerance float64) []Regression {
+	var regressions []Regression
+	if baseline.GatedOverallRate-current.GatedOverallRate > tolerance {
+		regressions = append(regressions, Regression{Kind: "overall", Baseline: baseline.GatedOverallRate, Current: current.GatedOverallRate})
+	}
+
+	compare := func(kind string, before, after map[string]float64) {
+		names := make([]string, 0, len(before))
+		for name := range before {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			rate, ok := after[name]
+			if ok && before[name]-rate > tolerance {
+				regressions = append(regressions, Regression{Kind: kind, Name: name, Baseline: before[name], Current: rate})
+			}
+		}
+	}
+	compare("encoder", baseline.GatedEncoderRates, current.GatedEncoderRates)
+	compare("decoder", baseline.GatedDecoderRates, current.GatedDecoderRates)
+
+	return regressions
+}
+
+// checkBaseline prints the regressions against baseline to w and returns the
+// process exit code: 1 when the overall rate dropped by more than tolerance,
+// else 0. Encoder and decoder drops are listed to explain an overall drop
+// but do not fail the check on their own.
+func checkBaseline(w io.Writer, baseline, current SummaryData, tolerance float64) int {
+	regressions := compareToBaseline(baseline, current, tolerance)
+
+	failed := false
+	for _, r := range regressions {
+		if r.Kind == "overall" {
+			failed = true
+			fmt.Fprintf(w, "Regression: overall rate %.1f%% → %.1f%% (tolerance %.1f points)\n", r.Baseline, r.Current, tolerance)
+			continue
+		}
+		fmt.Fprintf(w, "Regression: %s %s %.1f%% → %.1f%%\n", r.Kind, r.Name, r.Baseline, r.Current)
+	}
+
+	if failed {
+		return 1
+	}
+	fmt.Fprintf(w, "Baseline check passed: overall rate %.1f%% (baseline %.1f%%)\n", current.GatedOverallRate, baseline.GatedOverallRate)
+	return 0
+}
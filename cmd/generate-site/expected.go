@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// expectedKeyFields is the number of fields in an expected-failure key:
+// encoder|decoder|dataSize|pixelSize|contentType|ecLevel.
+const expectedKeyFields = 6
+
+// Expectations is a list of known-broken combinations loaded with
+// -expected-failures. Each key is matched against a result's
+// encoder|decoder|dataSize|pixelSize|contentType|ecLevel; a "*" field or a
+// missing trailing field matches anything, so "skip2/go-qrcode|makiuchi-d/gozxing|*|440"
+// covers one pixel size and "*|liyue201/goqr" covers a decoder.
+type Expectations struct {
+	keys   []string
+	fields [][]string
+}
+
+// ExpectationOutcome is how a run's results compared to the expectations.
+type ExpectationOutcome struct {
+	// Expected counts failures that matched an expectation.
+	Expected int
+
+	// UnexpectedlyFixed lists the keys that matched results, all of which
+	// passed: the known issue is gone and the key can be removed.
+	UnexpectedlyFixed []string
+}
+
+// loadExpectations reads an expected-failures file: a JSON array of keys.
+func loadExpectations(path string) (Expectations, error) {
+	var e Expectations
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return e, err
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return e, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	for i, key := range keys {
+		fields := strings.Split(key, "|")
+		if key == "" || len(fields) > expectedKeyFields {
+			return e, fmt.Errorf("%s: entry %d: invalid key %q: want encoder|decoder|dataSize|pixelSize|contentType|ecLevel", path, i, key)
+		}
+		e.keys = append(e.keys, key)
+		e.fields = append(e.fields, fields)
+	}
+	return e, nil
+}
+
+// matches reports whether the i-th key matches r.
+func (e Expectations) matches(i int, r RawTestResult) bool {
+	values := []string{
+		r.Encoder,
+		r.Decoder,
+		strconv.Itoa(r.DataSize),
+		strconv.Itoa(r.PixelSize),
+		r.ContentType,
+		r.ErrorCorrectionLevel,
+	}
+	for j, field := range e.fields[i] {
+		if field != "*" && field != values[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// apply splits out the failures matching an expectation. It returns the
+// remaining results, which the summary and baseline check gate on, and the
+// outcome. Skips are never failures, so they stay and do not count
+// toward an unexpectedly fixed key.
+func (e Expectations) apply(results []RawTestResult) ([]RawTestResult, ExpectationOutcome) {
+	var outcome ExpectationOutcome
+	matched := make([]int, len(e.keys))
+	failed := make([]int, len(e.keys))
+
+	gated := make([]RawTestResult, 0, len(results))
+	for _, r := range results {
+		expected := false
+		if !r.IsSkipped() {
+			for i := range e.keys {
+				if !e.matches(i, r) {
+					continue
+				}
+				matched[i]++
+				if r.IsFailure() {
+					failed[i]++
+					expected = true
+				}
+			}
+		}
+
+		if expected {
+			outcome.Expected++
+			continue
+		}
+		gated = append(gated, r)
+	}
+
+	for i, key := range e.keys {
+		if matched[i] > 0 && failed[i] == 0 {
+			outcome.UnexpectedlyFixed = append(outcome.UnexpectedlyFixed, key)
+		}
+	}
+	return gated, outcome
+}
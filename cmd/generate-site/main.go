@@ -2,37 +2,22 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+	"github.com/13rac1/qr-library-test/pkg/report"
 )
 
-// RawTestResult matches the JSON structure from pkg/report/json.go
-type RawTestResult struct {
-	Encoder              string  `json:"encoder"`
-	Decoder              string  `json:"decoder"`
-	DataSize             int     `json:"dataSize"`
-	PixelSize            int     `json:"pixelSize"`
-	ContentType          string  `json:"contentType"`
-	ErrorCorrectionLevel string  `json:"errorCorrectionLevel"` // "L", "M", "Q", or "H"
-	Success              bool    `json:"success"`
-	ErrorType            string  `json:"errorType,omitempty"`
-	ErrorMsg             string  `json:"errorMsg,omitempty"`
-	IsCapacityExceeded   bool    `json:"isCapacityExceeded,omitempty"`
-	EncodeTimeMs         float64 `json:"encodeTimeMs"`
-	DecodeTimeMs         float64 `json:"decodeTimeMs"`
-	QRVersion            int     `json:"qrVersion,omitempty"`
-	ModuleCount          int     `json:"moduleCount,omitempty"`
-	ModulePixelSize      float64 `json:"modulePixelSize,omitempty"`
-	IsFractionalModule   bool    `json:"isFractionalModule"`
-}
-
-type RawResults struct {
-	Timestamp string          `json:"timestamp"`
-	Results   []RawTestResult `json:"results"`
-}
+// RawTestResult is the per-test JSON record written by qr-tester.
+type RawTestResult = report.RawTestResult
 
 // Output structures for Hugo
 
@@ -64,6 +49,8 @@ type EncoderStats struct {
 	EffectiveTests    int                         `json:"effectiveTests"`     // TotalTests - CapacitySkips
 	ByDecoder         map[string]DecoderBreakdown `json:"byDecoder"`
 	ByErrorCorrection map[string]ECBreakdown      `json:"byErrorCorrection"`  // Stats per EC level
+	MaskDistribution  map[string]int              `json:"maskDistribution"`   // Encoded images per mask pattern (0-7)
+	AvgQRVersion      float64                     `json:"avgQRVersion"`       // Mean QR version per encoded image, 0 when unknown
 }
 
 type EncoderBreakdown struct {
@@ -96,14 +83,34 @@ type CombinationResult struct {
 	EffectiveTests int     `json:"effectiveTests"`
 	AvgEncodeMs    float64 `json:"avgEncodeMs"`
 	AvgDecodeMs    float64 `json:"avgDecodeMs"`
+
+	// DecodeMsStdDev is the population standard deviation of decode time over
+	// the tests the decoder ran on (Encoded). A high value next to a low
+	// average points at a slow fallback path that only some decodes take.
+	DecodeMsStdDev float64 `json:"decodeMsStdDev"`
+
+	// Encoded counts tests where the encoder produced an image. EncodeRate is
+	// Encoded over EffectiveTests; DecodeRate is Successes over Encoded, so
+	// encoder failures don't count against the decoder.
+	Encoded    int     `json:"encoded"`
+	EncodeRate float64 `json:"encodeRate"`
+	DecodeRate float64 `json:"decodeRate"`
 }
 
 type BestCombination struct {
-	Encoder     string  `json:"encoder"`
-	Decoder     string  `json:"decoder"`
-	SuccessRate float64 `json:"successRate"`
+	Encoder        string  `json:"encoder"`
+	Decoder        string  `json:"decoder"`
+	SuccessRate    float64 `json:"successRate"`
+	EffectiveTests int     `json:"effectiveTests"`
+
+	// MinEffectiveTests is the sample size a pair needed to be considered.
+	MinEffectiveTests int `json:"minEffectiveTests"`
 }
 
+// defaultMinEffectiveTests keeps under-sampled pairs (e.g., 1 test at 100%)
+// from being reported as the best combination.
+const defaultMinEffectiveTests = 10
+
 type CombinationsData struct {
 	Matrix []CombinationResult `json:"matrix"`
 	Best   BestCombination     `json:"best"`
@@ -113,6 +120,22 @@ type FailuresByType struct {
 	Encode       int `json:"encode"`
 	Decode       int `json:"decode"`
 	DataMismatch int `json:"dataMismatch"`
+	Timeout      int `json:"timeout"`
+	Panic        int `json:"panic"`
+
+	// Capacity counts valid capacity rejections; not included in failure rates
+	Capacity int `json:"capacity"`
+
+	// EmptyData counts valid empty-payload rejections; not included in failure rates
+	EmptyData int `json:"emptyData"`
+
+	// Unsupported counts binary tests skipped for text-only decoders; not
+	// included in failure rates
+	Unsupported int `json:"unsupported"`
+
+	// Undersized counts decodes skipped because the pixel size left too few
+	// pixels per module; not included in failure rates
+	Undersized int `json:"undersized"`
 }
 
 type ConditionFailures struct {
@@ -130,8 +153,66 @@ type FailuresData struct {
 	ByErrorCorrection   []ConditionFailures `json:"byErrorCorrection"`
 	FractionalModule    ConditionFailures   `json:"fractionalModule"`
 	IntegerModule       ConditionFailures   `json:"integerModule"`
+
+	// FractionalSignificance tests whether the fractional and integer
+	// failure rates differ by more than sample noise.
+	FractionalSignificance Significance `json:"fractionalSignificance"`
+}
+
+// significanceLevel is the p-value below which a difference in failure
+// rates is reported as significant.
+const significanceLevel = 0.05
+
+// Significance is the result of a two-proportion z-test between two
+// failure rates.
+type Significance struct {
+	Z      float64 `json:"z"`
+	PValue float64 `json:"pValue"`
+
+	// Significant is true when PValue is below significanceLevel.
+	Significant bool `json:"significant"`
+}
+
+// EncoderFailures counts one encoder's failures under a single condition.
+type EncoderFailures struct {
+	Encoder  string  `json:"encoder"`
+	Failures int     `json:"failures"`
+	Total    int     `json:"total"`
+	Rate     float64 `json:"rate"`
+}
+
+// DetailedConditionFailures breaks down one condition's failures by encoder.
+type DetailedConditionFailures struct {
+	Condition string            `json:"condition"`
+	Failures  int               `json:"failures"`
+	Total     int               `json:"total"`
+	Rate      float64           `json:"rate"`
+	ByEncoder []EncoderFailures `json:"byEncoder"`
 }
 
+// FailuresDetailedData cross-tabulates each failure condition with the encoder,
+// showing whether a condition's failures come from one encoder or all of them.
+type FailuresDetailedData struct {
+	ByDataSize        []DetailedConditionFailures `json:"byDataSize"`
+	ByPixelSize       []DetailedConditionFailures `json:"byPixelSize"`
+	ByContentType     []DetailedConditionFailures `json:"byContentType"`
+	ByErrorCorrection []DetailedConditionFailures `json:"byErrorCorrection"`
+}
+
+// VersionCliffs lists the pixel-size boundaries where module size crosses
+// between integer and fractional for one QR version within the tested range.
+type VersionCliffs struct {
+	Version           int                   `json:"version"`
+	ModuleCount       int                   `json:"moduleCount"`
+	IntegerPixelSizes []int                 `json:"integerPixelSizes"`
+	FractionalRanges  []testdata.PixelRange `json:"fractionalRanges"`
+	Tests             int                   `json:"tests"`
+}
+
+// SummaryData holds the headline numbers. CapacitySkips, here and in the
+// per-library stats, counts every result skipped before a decode verdict:
+// capacity and empty-data rejections, unsupported content, and undersized
+// images.
 type SummaryData struct {
 	Timestamp       string          `json:"timestamp"`
 	TotalTests      int             `json:"totalTests"`
@@ -139,11 +220,53 @@ type SummaryData struct {
 	CapacitySkips   int             `json:"capacitySkips"`
 	EffectiveTests  int             `json:"effectiveTests"`
 	OverallRate     float64         `json:"overallRate"`
+	Encoded         int             `json:"encoded"`
+	VersionMismatch int             `json:"versionMismatch"` // Results whose encoder-reported version differs from the detected one
+	EncodeRate      float64         `json:"encodeRate"` // Encoded / EffectiveTests
+	DecodeRate      float64         `json:"decodeRate"` // TotalSuccesses / Encoded
 	BestEncoder     string          `json:"bestEncoder"`
 	BestDecoder     string          `json:"bestDecoder"`
 	BestCombination BestCombination `json:"bestCombination"`
 	EncoderCount    int             `json:"encoderCount"`
 	DecoderCount    int             `json:"decoderCount"`
+
+	// ExcludedFromRate lists decoders left out of OverallRate and BestCombination.
+	ExcludedFromRate []string `json:"excludedFromRate,omitempty"`
+
+	// ExpectedFailures counts failures matching -expected-failures. They stay
+	// in the published counts and OverallRate but are left out of the gated
+	// rates. UnexpectedlyFixed lists the expected-failure keys whose results
+	// all passed.
+	ExpectedFailures  int      `json:"expectedFailures,omitempty"`
+	UnexpectedlyFixed []string `json:"unexpectedlyFixed,omitempty"`
+
+	// EncoderRates and DecoderRates are each library's success rate.
+	EncoderRates map[string]float64 `json:"encoderRates,omitempty"`
+	DecoderRates map[string]float64 `json:"decoderRates,omitempty"`
+
+	// GatedOverallRate, GatedEncoderRates, and GatedDecoderRates leave out
+	// the expected failures, so a later run given this file as -baseline
+	// compares like with like and can name what regressed. Without
+	// -expected-failures they equal the published rates.
+	GatedOverallRate  float64            `json:"gatedOverallRate"`
+	GatedEncoderRates map[string]float64 `json:"gatedEncoderRates,omitempty"`
+	GatedDecoderRates map[string]float64 `json:"gatedDecoderRates,omitempty"`
+
+	// PixelSizeByContentType recommends a pixel size per content type. The
+	// optimum differs by type: alphanumeric data needs a smaller version
+	// than the same number of bytes in byte mode, so its modules land on
+	// different pixel boundaries.
+	PixelSizeByContentType []ContentTypePixelSizes `json:"pixelSizeByContentType,omitempty"`
+}
+
+// ContentTypePixelSizes is the pixel size with the highest and the lowest
+// success rate for one content type, over all pairs and data sizes.
+type ContentTypePixelSizes struct {
+	ContentType    string  `json:"contentType"`
+	BestPixelSize  int     `json:"bestPixelSize"`
+	BestRate       float64 `json:"bestRate"`
+	WorstPixelSize int     `json:"worstPixelSize"`
+	WorstRate      float64 `json:"worstRate"`
 }
 
 type TestConfigData struct {
@@ -159,21 +282,71 @@ type TestConfigData struct {
 }
 
 func main() {
+	minEffectiveTests := flag.Int("min-effective-tests", defaultMinEffectiveTests,
+		"Minimum effective tests for a pair to be chosen as the best combination")
+	excludeArchived := flag.Bool("exclude-archived-from-rate", false,
+		"Leave archived decoders out of the overall and best-combination rates")
+	mergeDirs := flag.String("merge", "",
+		"Comma-separated extra results directories to merge in (newest timestamp wins on overlap)")
+	baselinePath := flag.String("baseline", "",
+		"Previous summary.json; exit 1 if the overall rate dropped by more than -baseline-tolerance")
+	baselineTolerance := flag.Float64("baseline-tolerance", defaultBaselineTolerance,
+		"Allowed drop in success rate against -baseline, in percentage points")
+	expectedPath := flag.String("expected-failures", "",
+		"JSON list of encoder|decoder[|dataSize|pixelSize|contentType|ecLevel] keys (\"*\" matches any) whose failures are known; they are left out of the overall rate")
+	flag.Parse()
+
 	resultsDir := "results"
 	outputDir := "website/data"
 
-	if len(os.Args) > 1 {
-		resultsDir = os.Args[1]
+	if flag.NArg() > 0 {
+		resultsDir = flag.Arg(0)
+	}
+	if flag.NArg() > 1 {
+		outputDir = flag.Arg(1)
+	}
+
+	// Read the baseline first; it may be the summary.json about to be replaced
+	var baseline *SummaryData
+	if *baselinePath != "" {
+		b, err := loadSummary(*baselinePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+			os.Exit(1)
+		}
+		baseline = &b
 	}
-	if len(os.Args) > 2 {
-		outputDir = os.Args[2]
+
+	var expectations *Expectations
+	if *expectedPath != "" {
+		e, err := loadExpectations(*expectedPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading expected failures: %v\n", err)
+			os.Exit(1)
+		}
+		expectations = &e
 	}
 
-	results, err := loadAllResults(resultsDir)
+	dirs := []string{resultsDir}
+	if *mergeDirs != "" {
+		dirs = append(dirs, strings.Split(*mergeDirs, ",")...)
+	}
+
+	merged, conflicts, err := report.MergeResultsConflicts(dirs...)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading results: %v\n", err)
 		os.Exit(1)
 	}
+	for _, c := range conflicts {
+		if c.Changed {
+			fmt.Fprintf(os.Stderr, "Merge: %s differs; kept %s (%s) over %s (%s)\n",
+				c.Key, c.KeptDir, c.KeptTimestamp, c.DroppedDir, c.DroppedTimestamp)
+		}
+	}
+	if len(conflicts) > 0 {
+		fmt.Printf("Merged %d results directories (%d overlapping results, newest kept)\n", len(dirs), len(conflicts))
+	}
+	results := merged.Results
 
 	if len(results) == 0 {
 		fmt.Fprintf(os.Stderr, "No results found in %s\n", resultsDir)
@@ -182,11 +355,25 @@ func main() {
 
 	fmt.Printf("Loaded %d test results\n", len(results))
 
+	excluded := make(map[string]bool)
+	if *excludeArchived {
+		for _, name := range decoders.ArchivedDecoderNames() {
+			excluded[name] = true
+		}
+	}
+
 	encoders := computeEncoderStats(results)
 	decoders := computeDecoderStats(results)
-	combinations := computeCombinations(results)
+	combinations := computeCombinations(results, *minEffectiveTests, excluded)
 	failures := computeFailures(results)
-	summary := computeSummary(results, encoders, decoders, combinations)
+
+	summary, outcome := summarize(results, encoders, decoders, combinations, excluded, expectations)
+	if expectations != nil {
+		fmt.Printf("Expected failures: %d\n", outcome.Expected)
+		for _, key := range outcome.UnexpectedlyFixed {
+			fmt.Fprintf(os.Stderr, "Unexpectedly fixed: %s passes; remove it from %s\n", key, *expectedPath)
+		}
+	}
 
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating output directory: %v\n", err)
@@ -213,11 +400,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	failuresDetailed := computeFailuresDetailed(results)
+	if err := writeJSON(filepath.Join(outputDir, "failures-detailed.json"), failuresDetailed); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing failures-detailed.json: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := writeJSON(filepath.Join(outputDir, "summary.json"), summary); err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing summary.json: %v\n", err)
 		os.Exit(1)
 	}
 
+	cliffs := computeFractionalCliffs(results)
+	if err := writeJSON(filepath.Join(outputDir, "cliffs.json"), cliffs); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing cliffs.json: %v\n", err)
+		os.Exit(1)
+	}
+
 	testConfig := computeTestConfig(results, encoders, decoders)
 	if err := writeJSON(filepath.Join(outputDir, "testconfig.json"), testConfig); err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing testconfig.json: %v\n", err)
@@ -231,62 +430,20 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Generated Hugo data files in %s\n", outputDir)
-	fmt.Printf("Copied raw JSON files to %s/data/raw/\n", staticDir)
-}
-
-func loadAllResults(dir string) ([]RawTestResult, error) {
-	var allResults []RawTestResult
-
-	// Load from encoders directory
-	encodersDir := filepath.Join(dir, "encoders")
-	if err := loadResultsFromDir(encodersDir, &allResults); err != nil {
-		return nil, err
-	}
-
-	// Deduplicate (since we only need one copy of each result)
-	seen := make(map[string]bool)
-	var unique []RawTestResult
-	for _, r := range allResults {
-		key := fmt.Sprintf("%s|%s|%d|%d|%s|%s", r.Encoder, r.Decoder, r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel)
-		if !seen[key] {
-			seen[key] = true
-			unique = append(unique, r)
-		}
-	}
-
-	return unique, nil
-}
-
-func loadResultsFromDir(dir string, results *[]RawTestResult) error {
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
-		return err
+	if err := writeJSON(filepath.Join(staticDir, badgeFile), computeBadge(summary)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", badgeFile, err)
+		os.Exit(1)
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
-			continue
-		}
-
-		path := filepath.Join(dir, entry.Name())
-		data, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("reading %s: %w", path, err)
-		}
+	fmt.Printf("Generated Hugo data files in %s\n", outputDir)
+	fmt.Printf("Copied raw JSON files to %s/data/raw/\n", staticDir)
+	fmt.Printf("Wrote badge to %s/%s\n", staticDir, badgeFile)
 
-		var raw RawResults
-		if err := json.Unmarshal(data, &raw); err != nil {
-			return fmt.Errorf("parsing %s: %w", path, err)
+	if baseline != nil {
+		if code := checkBaseline(os.Stderr, *baseline, summary, *baselineTolerance); code != 0 {
+			os.Exit(code)
 		}
-
-		*results = append(*results, raw.Results...)
 	}
-
-	return nil
 }
 
 func computeEncoderStats(results []RawTestResult) []EncoderStats {
@@ -297,6 +454,10 @@ func computeEncoderStats(results []RawTestResult) []EncoderStats {
 		totalEncMs    float64
 		byDecoder     map[string]*struct{ tests, successes, capacitySkips int }
 		byEC          map[string]*struct{ tests, successes, capacitySkips int; totalMs float64 }
+		masks         map[string]int
+		maskedImages  map[string]bool
+		versionSum    int
+		versionImages map[string]bool
 	}
 
 	agg := make(map[string]*encoderAgg)
@@ -304,8 +465,11 @@ func computeEncoderStats(results []RawTestResult) []EncoderStats {
 	for _, r := range results {
 		if agg[r.Encoder] == nil {
 			agg[r.Encoder] = &encoderAgg{
-				byDecoder: make(map[string]*struct{ tests, successes, capacitySkips int }),
-				byEC:      make(map[string]*struct{ tests, successes, capacitySkips int; totalMs float64 }),
+				byDecoder:     make(map[string]*struct{ tests, successes, capacitySkips int }),
+				byEC:          make(map[string]*struct{ tests, successes, capacitySkips int; totalMs float64 }),
+				masks:         make(map[string]int),
+				maskedImages:  make(map[string]bool),
+				versionImages: make(map[string]bool),
 			}
 		}
 		a := agg[r.Encoder]
@@ -314,10 +478,23 @@ func computeEncoderStats(results []RawTestResult) []EncoderStats {
 		if r.Success {
 			a.successes++
 		}
-		if r.IsCapacityExceeded {
+		if r.IsSkipped() {
 			a.capacitySkips++
 		}
 
+		// Count each encoded image once, not once per decoder
+		imageKey := fmt.Sprintf("%d|%d|%s|%s", r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel)
+		if r.Mask != nil {
+			if !a.maskedImages[imageKey] {
+				a.maskedImages[imageKey] = true
+				a.masks[fmt.Sprintf("%d", *r.Mask)]++
+			}
+		}
+		if r.QRVersion > 0 && !a.versionImages[imageKey] {
+			a.versionImages[imageKey] = true
+			a.versionSum += r.QRVersion
+		}
+
 		if a.byDecoder[r.Decoder] == nil {
 			a.byDecoder[r.Decoder] = &struct{ tests, successes, capacitySkips int }{}
 		}
@@ -325,7 +502,7 @@ func computeEncoderStats(results []RawTestResult) []EncoderStats {
 		if r.Success {
 			a.byDecoder[r.Decoder].successes++
 		}
-		if r.IsCapacityExceeded {
+		if r.IsSkipped() {
 			a.byDecoder[r.Decoder].capacitySkips++
 		}
 
@@ -338,7 +515,7 @@ func computeEncoderStats(results []RawTestResult) []EncoderStats {
 		if r.Success {
 			a.byEC[r.ErrorCorrectionLevel].successes++
 		}
-		if r.IsCapacityExceeded {
+		if r.IsSkipped() {
 			a.byEC[r.ErrorCorrectionLevel].capacitySkips++
 		}
 	}
@@ -393,6 +570,10 @@ func computeEncoderStats(results []RawTestResult) []EncoderStats {
 		if a.totalTests > 0 {
 			avgEnc = a.totalEncMs / float64(a.totalTests)
 		}
+		avgVersion := 0.0
+		if len(a.versionImages) > 0 {
+			avgVersion = float64(a.versionSum) / float64(len(a.versionImages))
+		}
 
 		stats = append(stats, EncoderStats{
 			Name:              name,
@@ -404,6 +585,8 @@ func computeEncoderStats(results []RawTestResult) []EncoderStats {
 			EffectiveTests:    effectiveTests,
 			ByDecoder:         byDec,
 			ByErrorCorrection: byEC,
+			MaskDistribution:  a.masks,
+			AvgQRVersion:      avgVersion,
 		})
 	}
 
@@ -440,7 +623,7 @@ func computeDecoderStats(results []RawTestResult) []DecoderStats {
 		if r.Success {
 			a.successes++
 		}
-		if r.IsCapacityExceeded {
+		if r.IsSkipped() {
 			a.capacitySkips++
 		}
 
@@ -451,7 +634,7 @@ func computeDecoderStats(results []RawTestResult) []DecoderStats {
 		if r.Success {
 			a.byEncoder[r.Encoder].successes++
 		}
-		if r.IsCapacityExceeded {
+		if r.IsSkipped() {
 			a.byEncoder[r.Encoder].capacitySkips++
 		}
 
@@ -464,7 +647,7 @@ func computeDecoderStats(results []RawTestResult) []DecoderStats {
 		if r.Success {
 			a.byEC[r.ErrorCorrectionLevel].successes++
 		}
-		if r.IsCapacityExceeded {
+		if r.IsSkipped() {
 			a.byEC[r.ErrorCorrectionLevel].capacitySkips++
 		}
 	}
@@ -541,13 +724,19 @@ func computeDecoderStats(results []RawTestResult) []DecoderStats {
 	return stats
 }
 
-func computeCombinations(results []RawTestResult) CombinationsData {
+// computeCombinations aggregates results per encoder/decoder pair and picks the
+// best pair among those with at least minEffectiveTests effective tests.
+// Pairs whose decoder is in excluded stay in the matrix but are never best.
+func computeCombinations(results []RawTestResult, minEffectiveTests int, excluded map[string]bool) CombinationsData {
 	type combAgg struct {
 		tests         int
 		successes     int
 		capacitySkips int
+		encoded       int
 		encMs         float64
 		decMs         float64
+		encodedDecMs  float64 // decode time of encoded tests
+		encodedDecSq  float64 // sum of squared decode times of encoded tests
 	}
 
 	agg := make(map[string]*combAgg)
@@ -564,9 +753,14 @@ func computeCombinations(results []RawTestResult) CombinationsData {
 		if r.Success {
 			a.successes++
 		}
-		if r.IsCapacityExceeded {
+		if r.IsSkipped() {
 			a.capacitySkips++
 		}
+		if r.Encoded() {
+			a.encoded++
+			a.encodedDecMs += r.DecodeTimeMs
+			a.encodedDecSq += r.DecodeTimeMs * r.DecodeTimeMs
+		}
 	}
 
 	var matrix []CombinationResult
@@ -596,10 +790,14 @@ func computeCombinations(results []RawTestResult) CombinationsData {
 			EffectiveTests: effectiveTests,
 			AvgEncodeMs:    avgEnc,
 			AvgDecodeMs:    avgDec,
+			DecodeMsStdDev: stdDev(a.encoded, a.encodedDecMs, a.encodedDecSq),
+			Encoded:        a.encoded,
+			EncodeRate:     percentOf(a.encoded, effectiveTests),
+			DecodeRate:     percentOf(a.successes, a.encoded),
 		}
 		matrix = append(matrix, cr)
 
-		if rate > best.SuccessRate {
+		if effectiveTests >= minEffectiveTests && rate > best.SuccessRate && !excluded[cr.Decoder] {
 			best = cr
 		}
 	}
@@ -612,9 +810,11 @@ func computeCombinations(results []RawTestResult) CombinationsData {
 	return CombinationsData{
 		Matrix: matrix,
 		Best: BestCombination{
-			Encoder:     best.Encoder,
-			Decoder:     best.Decoder,
-			SuccessRate: best.SuccessRate,
+			Encoder:           best.Encoder,
+			Decoder:           best.Decoder,
+			SuccessRate:       best.SuccessRate,
+			EffectiveTests:    best.EffectiveTests,
+			MinEffectiveTests: minEffectiveTests,
 		},
 	}
 }
@@ -630,8 +830,18 @@ func computeFailures(results []RawTestResult) FailuresData {
 	var integerFailures, integerTotal int
 
 	for _, r := range results {
-		// Skip capacity exceeded - these are valid rejections, not failures
-		if r.IsCapacityExceeded {
+		// Skips are valid rejections or configuration problems, not failures
+		if r.IsSkipped() {
+			switch r.ErrorType {
+			case "emptyData":
+				byType.EmptyData++
+			case "unsupported":
+				byType.Unsupported++
+			case "undersized":
+				byType.Undersized++
+			default:
+				byType.Capacity++
+			}
 			continue
 		}
 
@@ -643,6 +853,10 @@ func computeFailures(results []RawTestResult) FailuresData {
 				byType.Decode++
 			case "dataMismatch":
 				byType.DataMismatch++
+			case "timeout":
+				byType.Timeout++
+			case "panic":
+				byType.Panic++
 			}
 		}
 
@@ -791,20 +1005,177 @@ func computeFailures(results []RawTestResult) FailuresData {
 			Total:     integerTotal,
 			Rate:      integerRate,
 		},
+		FractionalSignificance: twoProportionZTest(fractionalFailures, fractionalTotal, integerFailures, integerTotal),
+	}
+}
+
+// computeFailuresDetailed breaks down failures per encoder within each data size,
+// pixel size, content type, and error correction level.
+// Capacity-exceeded results are excluded, as in computeFailures.
+func computeFailuresDetailed(results []RawTestResult) FailuresDetailedData {
+	return FailuresDetailedData{
+		ByDataSize: crossTabFailures(results, func(r RawTestResult) string {
+			return fmt.Sprintf("%d bytes", r.DataSize)
+		}),
+		ByPixelSize: crossTabFailures(results, func(r RawTestResult) string {
+			return fmt.Sprintf("%dpx", r.PixelSize)
+		}),
+		ByContentType: crossTabFailures(results, func(r RawTestResult) string {
+			return r.ContentType
+		}),
+		ByErrorCorrection: crossTabFailures(results, func(r RawTestResult) string {
+			return fmt.Sprintf("EC Level %s", r.ErrorCorrectionLevel)
+		}),
+	}
+}
+
+// crossTabFailures groups results by the condition label, then by encoder.
+// Conditions are sorted by failure rate descending, encoders by name.
+func crossTabFailures(results []RawTestResult, condition func(RawTestResult) string) []DetailedConditionFailures {
+	type counts struct{ failures, total int }
+	agg := make(map[string]map[string]*counts)
+
+	for _, r := range results {
+		if r.IsSkipped() {
+			continue
+		}
+
+		label := condition(r)
+		if agg[label] == nil {
+			agg[label] = make(map[string]*counts)
+		}
+		c := agg[label][r.Encoder]
+		if c == nil {
+			c = &counts{}
+			agg[label][r.Encoder] = c
+		}
+		c.total++
+		if !r.Success {
+			c.failures++
+		}
+	}
+
+	var detailed []DetailedConditionFailures
+	for label, byEncoder := range agg {
+		d := DetailedConditionFailures{Condition: label}
+		for enc, c := range byEncoder {
+			d.Failures += c.failures
+			d.Total += c.total
+			d.ByEncoder = append(d.ByEncoder, EncoderFailures{
+				Encoder:  enc,
+				Failures: c.failures,
+				Total:    c.total,
+				Rate:     float64(c.failures) / float64(c.total) * 100,
+			})
+		}
+		d.Rate = float64(d.Failures) / float64(d.Total) * 100
+		sort.Slice(d.ByEncoder, func(i, j int) bool {
+			return d.ByEncoder[i].Encoder < d.ByEncoder[j].Encoder
+		})
+		detailed = append(detailed, d)
+	}
+
+	sort.Slice(detailed, func(i, j int) bool {
+		if detailed[i].Rate != detailed[j].Rate {
+			return detailed[i].Rate > detailed[j].Rate
+		}
+		return detailed[i].Condition < detailed[j].Condition
+	})
+
+	return detailed
+}
+
+// computeFractionalCliffs finds, for each detected QR version, the pixel sizes
+// within the tested range that give integer modules and the ranges between them
+// that give fractional modules.
+func computeFractionalCliffs(results []RawTestResult) []VersionCliffs {
+	minPixel, maxPixel := 0, 0
+	versionTests := make(map[int]int)
+
+	for _, r := range results {
+		if minPixel == 0 || r.PixelSize < minPixel {
+			minPixel = r.PixelSize
+		}
+		if r.PixelSize > maxPixel {
+			maxPixel = r.PixelSize
+		}
+		// Micro QR versions use a different module formula
+		if r.QRVersion > 0 && !r.IsMicroQR {
+			versionTests[r.QRVersion]++
+		}
 	}
+
+	var cliffs []VersionCliffs
+	for version, tests := range versionTests {
+		moduleCount := testdata.CalculateModuleCount(version)
+		cliffs = append(cliffs, VersionCliffs{
+			Version:           version,
+			ModuleCount:       moduleCount,
+			IntegerPixelSizes: testdata.IntegerPixelSizes(moduleCount, testdata.QuietZoneModules, minPixel, maxPixel),
+			FractionalRanges:  testdata.FractionalPixelRanges(moduleCount, testdata.QuietZoneModules, minPixel, maxPixel),
+			Tests:             tests,
+		})
+	}
+
+	sort.Slice(cliffs, func(i, j int) bool {
+		return cliffs[i].Version < cliffs[j].Version
+	})
+
+	return cliffs
 }
 
-func computeSummary(results []RawTestResult, encoders []EncoderStats, decoders []DecoderStats, combinations CombinationsData) SummaryData {
-	total := len(results)
+// summarize computes the published summary from every result and fills in
+// the gated rates from the results left after expectations, if any, are
+// applied. Per-library gated rates are recomputed from those results.
+func summarize(results []RawTestResult, encoders []EncoderStats, decoders []DecoderStats, combinations CombinationsData, excluded map[string]bool, expectations *Expectations) (SummaryData, ExpectationOutcome) {
+	summary := computeSummary(results, encoders, decoders, combinations, excluded)
+	gated := summary
+	var outcome ExpectationOutcome
+	if expectations != nil {
+		var gatedResults []RawTestResult
+		gatedResults, outcome = expectations.apply(results)
+		gated = computeSummary(gatedResults, computeEncoderStats(gatedResults), computeDecoderStats(gatedResults), combinations, excluded)
+	}
+
+	summary.GatedOverallRate = gated.OverallRate
+	summary.GatedEncoderRates = gated.EncoderRates
+	summary.GatedDecoderRates = gated.DecoderRates
+	summary.ExpectedFailures = outcome.Expected
+	summary.UnexpectedlyFixed = outcome.UnexpectedlyFixed
+	return summary, outcome
+}
+
+// computeSummary computes the headline numbers. Results from decoders in
+// excluded are left out of the test counts and OverallRate.
+func computeSummary(results []RawTestResult, encoders []EncoderStats, decoders []DecoderStats, combinations CombinationsData, excluded map[string]bool) SummaryData {
+	total := 0
 	successes := 0
 	capacitySkips := 0
+	encoded := 0
+	versionMismatch := 0
+	excludedSeen := make(map[string]bool)
+	var excludedNames []string
 	for _, r := range results {
+		if excluded[r.Decoder] {
+			if !excludedSeen[r.Decoder] {
+				excludedSeen[r.Decoder] = true
+				excludedNames = append(excludedNames, r.Decoder)
+			}
+			continue
+		}
+		total++
 		if r.Success {
 			successes++
 		}
-		if r.IsCapacityExceeded {
+		if r.IsSkipped() {
 			capacitySkips++
 		}
+		if r.Encoded() {
+			encoded++
+		}
+		if r.VersionMismatch {
+			versionMismatch++
+		}
 	}
 
 	effectiveTests := total - capacitySkips
@@ -823,6 +1194,22 @@ func computeSummary(results []RawTestResult, encoders []EncoderStats, decoders [
 		bestDecoder = decoders[0].Name
 	}
 
+	var byContentType []RawTestResult
+	for _, r := range results {
+		if !excluded[r.Decoder] {
+			byContentType = append(byContentType, r)
+		}
+	}
+
+	encoderRates := make(map[string]float64, len(encoders))
+	for _, e := range encoders {
+		encoderRates[e.Name] = e.SuccessRate
+	}
+	decoderRates := make(map[string]float64, len(decoders))
+	for _, d := range decoders {
+		decoderRates[d.Name] = d.SuccessRate
+	}
+
 	return SummaryData{
 		Timestamp:       time.Now().UTC().Format(time.RFC3339),
 		TotalTests:      total,
@@ -830,11 +1217,120 @@ func computeSummary(results []RawTestResult, encoders []EncoderStats, decoders [
 		CapacitySkips:   capacitySkips,
 		EffectiveTests:  effectiveTests,
 		OverallRate:     rate,
+		Encoded:         encoded,
+		VersionMismatch: versionMismatch,
+		EncodeRate:      percentOf(encoded, effectiveTests),
+		DecodeRate:      percentOf(successes, encoded),
 		BestEncoder:     bestEncoder,
 		BestDecoder:     bestDecoder,
 		BestCombination: combinations.Best,
 		EncoderCount:    len(encoders),
 		DecoderCount:    len(decoders),
+
+		ExcludedFromRate: excludedNames,
+		EncoderRates:     encoderRates,
+		DecoderRates:     decoderRates,
+
+		PixelSizeByContentType: computePixelSizeByContentType(byContentType),
+	}
+}
+
+// computePixelSizeByContentType finds, for each content type, the pixel
+// sizes with the highest and lowest success rate over effective tests
+// (skips left out). Ties go to the smaller pixel size, the cheaper image
+// to print or display. Content types are sorted by name.
+func computePixelSizeByContentType(results []RawTestResult) []ContentTypePixelSizes {
+	type tally struct{ successes, total int }
+	byType := make(map[string]map[int]*tally)
+	for _, r := range results {
+		if r.IsSkipped() {
+			continue
+		}
+		sizes := byType[r.ContentType]
+		if sizes == nil {
+			sizes = make(map[int]*tally)
+			byType[r.ContentType] = sizes
+		}
+		t := sizes[r.PixelSize]
+		if t == nil {
+			t = &tally{}
+			sizes[r.PixelSize] = t
+		}
+		t.total++
+		if r.Success {
+			t.successes++
+		}
+	}
+
+	contentTypes := make([]string, 0, len(byType))
+	for contentType := range byType {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	recommendations := make([]ContentTypePixelSizes, 0, len(contentTypes))
+	for _, contentType := range contentTypes {
+		sizes := make([]int, 0, len(byType[contentType]))
+		for size := range byType[contentType] {
+			sizes = append(sizes, size)
+		}
+		sort.Ints(sizes)
+
+		rec := ContentTypePixelSizes{ContentType: contentType, BestRate: -1, WorstRate: 101}
+		for _, size := range sizes {
+			t := byType[contentType][size]
+			rate := percentOf(t.successes, t.total)
+			if rate > rec.BestRate {
+				rec.BestPixelSize, rec.BestRate = size, rate
+			}
+			if rate < rec.WorstRate {
+				rec.WorstPixelSize, rec.WorstRate = size, rate
+			}
+		}
+		recommendations = append(recommendations, rec)
+	}
+	return recommendations
+}
+
+// percentOf returns n/total as a percentage, or 0 when total is 0.
+func percentOf(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
+
+// stdDev returns the population standard deviation of n values from their
+// sum and sum of squares, or 0 for fewer than two values.
+func stdDev(n int, sum, sumSq float64) float64 {
+	if n < 2 {
+		return 0
+	}
+	mean := sum / float64(n)
+	// Rounding can leave a tiny negative variance for identical values
+	return math.Sqrt(math.Max(0, sumSq/float64(n)-mean*mean))
+}
+
+// twoProportionZTest compares the failure proportions x1/n1 and x2/n2 using
+// the pooled standard error, with a two-sided p-value. With an empty group,
+// or when every test in both groups failed or passed, there is no variance
+// to test against and the result is a p-value of 1.
+func twoProportionZTest(x1, n1, x2, n2 int) Significance {
+	if n1 == 0 || n2 == 0 {
+		return Significance{PValue: 1}
+	}
+	pooled := float64(x1+x2) / float64(n1+n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return Significance{PValue: 1}
+	}
+
+	z := (float64(x1)/float64(n1) - float64(x2)/float64(n2)) / se
+	pValue := math.Erfc(math.Abs(z) / math.Sqrt2)
+	return Significance{
+		Z:           z,
+		PValue:      pValue,
+		Significant: pValue < significanceLevel,
 	}
 }
 
@@ -944,11 +1440,11 @@ func copyRawJSONFiles(resultsDir, staticDir string) error {
 	}
 
 	for _, entry := range encoderFiles {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+		if entry.IsDir() || !report.IsResultFile(entry.Name()) {
 			continue
 		}
 		src := filepath.Join(encodersDir, entry.Name())
-		dst := filepath.Join(rawDataDir, entry.Name())
+		dst := filepath.Join(rawDataDir, strings.TrimSuffix(entry.Name(), ".gz"))
 		if err := copyFile(src, dst); err != nil {
 			return fmt.Errorf("copying %s: %w", entry.Name(), err)
 		}
@@ -962,11 +1458,11 @@ func copyRawJSONFiles(resultsDir, staticDir string) error {
 	}
 
 	for _, entry := range decoderFiles {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+		if entry.IsDir() || !report.IsResultFile(entry.Name()) {
 			continue
 		}
 		src := filepath.Join(decodersDir, entry.Name())
-		dst := filepath.Join(rawDataDir, entry.Name())
+		dst := filepath.Join(rawDataDir, strings.TrimSuffix(entry.Name(), ".gz"))
 		if err := copyFile(src, dst); err != nil {
 			return fmt.Errorf("copying %s: %w", entry.Name(), err)
 		}
@@ -975,8 +1471,10 @@ func copyRawJSONFiles(resultsDir, staticDir string) error {
 	return nil
 }
 
+// copyFile copies a results file, decompressing .json.gz so the site
+// always serves plain .json.
 func copyFile(src, dst string) error {
-	data, err := os.ReadFile(src)
+	data, err := report.ReadResultFile(src)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,458 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeFailuresDetailed_AttributesEncoder(t *testing.T) {
+	results := []RawTestResult{
+		// At 440px only skip2 fails
+		{Encoder: "skip2/go-qrcode", PixelSize: 440, Success: false, ErrorType: "decode"},
+		{Encoder: "skip2/go-qrcode", PixelSize: 440, Success: false, ErrorType: "decode"},
+		{Encoder: "boombuler/barcode", PixelSize: 440, Success: true},
+		{Encoder: "boombuler/barcode", PixelSize: 440, Success: true},
+
+		// At 480px everything passes
+		{Encoder: "skip2/go-qrcode", PixelSize: 480, Success: true},
+		{Encoder: "boombuler/barcode", PixelSize: 480, Success: true},
+
+		// Capacity skips are not failures
+		{Encoder: "boombuler/barcode", PixelSize: 440, Success: false, ErrorType: "capacity", IsCapacityExceeded: true},
+	}
+
+	detailed := computeFailuresDetailed(results)
+
+	if len(detailed.ByPixelSize) != 2 {
+		t.Fatalf("ByPixelSize has %d conditions, want 2", len(detailed.ByPixelSize))
+	}
+
+	// Highest failure rate first
+	worst := detailed.ByPixelSize[0]
+	if worst.Condition != "440px" {
+		t.Fatalf("Worst condition = %q, want %q", worst.Condition, "440px")
+	}
+	if worst.Failures != 2 || worst.Total != 4 {
+		t.Errorf("440px failures = %d/%d, want 2/4", worst.Failures, worst.Total)
+	}
+
+	byEncoder := make(map[string]EncoderFailures)
+	for _, e := range worst.ByEncoder {
+		byEncoder[e.Encoder] = e
+	}
+
+	if got := byEncoder["skip2/go-qrcode"]; got.Failures != 2 || got.Total != 2 || got.Rate != 100 {
+		t.Errorf("skip2 at 440px = %+v, want 2/2 failures at 100%%", got)
+	}
+	if got := byEncoder["boombuler/barcode"]; got.Failures != 0 || got.Total != 2 {
+		t.Errorf("boombuler at 440px = %+v, want 0/2 failures", got)
+	}
+}
+
+func TestComputeFailures_FractionalSignificance(t *testing.T) {
+	// results builds n fractional and n integer tests with the given
+	// failure counts.
+	results := func(n, fractionalFailures, integerFailures int) []RawTestResult {
+		var rs []RawTestResult
+		for i := 0; i < n; i++ {
+			rs = append(rs,
+				RawTestResult{IsFractionalModule: true, Success: i >= fractionalFailures},
+				RawTestResult{Success: i >= integerFailures},
+			)
+		}
+		return rs
+	}
+
+	tests := []struct {
+		name            string
+		results         []RawTestResult
+		wantSignificant bool
+	}{
+		{"separated rates", results(100, 40, 5), true},
+		{"overlapping rates", results(20, 3, 2), false},
+		{"no failures", results(50, 0, 0), false},
+		{"no fractional tests", []RawTestResult{{Success: false}, {Success: true}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig := computeFailures(tt.results).FractionalSignificance
+			if sig.Significant != tt.wantSignificant {
+				t.Errorf("Significant = %v (z=%.2f, p=%.4f), want %v", sig.Significant, sig.Z, sig.PValue, tt.wantSignificant)
+			}
+			if sig.PValue < 0 || sig.PValue > 1 {
+				t.Errorf("PValue = %v, want within [0, 1]", sig.PValue)
+			}
+		})
+	}
+}
+
+func TestComputeCombinations_MinEffectiveTests(t *testing.T) {
+	var results []RawTestResult
+
+	// One lucky test at 100%
+	results = append(results, RawTestResult{Encoder: "lucky", Decoder: "dec", Success: true})
+
+	// 100 tests at 99%
+	for i := 0; i < 100; i++ {
+		results = append(results, RawTestResult{Encoder: "steady", Decoder: "dec", Success: i != 0})
+	}
+
+	combinations := computeCombinations(results, 10, nil)
+
+	best := combinations.Best
+	if best.Encoder != "steady" {
+		t.Errorf("Best encoder = %q, want %q", best.Encoder, "steady")
+	}
+	if best.SuccessRate != 99 {
+		t.Errorf("Best success rate = %.1f, want 99.0", best.SuccessRate)
+	}
+	if best.MinEffectiveTests != 10 {
+		t.Errorf("Best min effective tests = %d, want 10", best.MinEffectiveTests)
+	}
+
+	// Without a threshold the single test wins
+	if got := computeCombinations(results, 0, nil).Best.Encoder; got != "lucky" {
+		t.Errorf("Best encoder with no threshold = %q, want %q", got, "lucky")
+	}
+}
+
+func TestComputeSummary_ExcludeArchived(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc", Decoder: "makiuchi-d/gozxing", Success: true},
+		{Encoder: "enc", Decoder: "makiuchi-d/gozxing", Success: false, ErrorType: "decode"},
+		{Encoder: "enc", Decoder: "liyue201/goqr", Success: true},
+		{Encoder: "enc", Decoder: "liyue201/goqr", Success: true},
+	}
+	excluded := map[string]bool{"liyue201/goqr": true}
+
+	combinations := computeCombinations(results, 0, excluded)
+	if combinations.Best.Decoder != "makiuchi-d/gozxing" {
+		t.Errorf("Best decoder = %q, want archived goqr skipped", combinations.Best.Decoder)
+	}
+	if len(combinations.Matrix) != 2 {
+		t.Errorf("Matrix has %d pairs, want 2", len(combinations.Matrix))
+	}
+
+	summary := computeSummary(results, nil, nil, combinations, excluded)
+	if summary.OverallRate != 50 {
+		t.Errorf("OverallRate = %.1f, want 50.0 with goqr excluded", summary.OverallRate)
+	}
+	if summary.TotalTests != 2 {
+		t.Errorf("TotalTests = %d, want 2", summary.TotalTests)
+	}
+	if len(summary.ExcludedFromRate) != 1 || summary.ExcludedFromRate[0] != "liyue201/goqr" {
+		t.Errorf("ExcludedFromRate = %v, want [liyue201/goqr]", summary.ExcludedFromRate)
+	}
+
+	if got := computeSummary(results, nil, nil, combinations, nil).OverallRate; got != 75 {
+		t.Errorf("OverallRate without exclusion = %.1f, want 75.0", got)
+	}
+}
+
+func TestComputeSummary_PixelSizeByContentType(t *testing.T) {
+	// Alphanumeric reads best at 300px and worst at 440px; binary, at a
+	// larger version, the other way round
+	var results []RawTestResult
+	add := func(contentType string, pixelSize, successes, failures int) {
+		for i := 0; i < successes+failures; i++ {
+			r := RawTestResult{Encoder: "enc", Decoder: "dec", ContentType: contentType, PixelSize: pixelSize, Success: i < successes}
+			if !r.Success {
+				r.ErrorType = "decode"
+			}
+			results = append(results, r)
+		}
+	}
+	add("alphanumeric", 300, 4, 0)
+	add("alphanumeric", 440, 1, 3)
+	add("binary", 300, 0, 4)
+	add("binary", 440, 4, 0)
+	// 400px ties 440px for binary; the smaller size wins
+	add("binary", 400, 4, 0)
+	// Capacity skips and excluded decoders do not count
+	results = append(results,
+		RawTestResult{Encoder: "enc", Decoder: "dec", ContentType: "alphanumeric", PixelSize: 200, ErrorType: "capacity", IsCapacityExceeded: true},
+		RawTestResult{Encoder: "enc", Decoder: "liyue201/goqr", ContentType: "binary", PixelSize: 200, Success: true},
+	)
+
+	summary := computeSummary(results, nil, nil, CombinationsData{}, map[string]bool{"liyue201/goqr": true})
+	want := []ContentTypePixelSizes{
+		{ContentType: "alphanumeric", BestPixelSize: 300, BestRate: 100, WorstPixelSize: 440, WorstRate: 25},
+		{ContentType: "binary", BestPixelSize: 400, BestRate: 100, WorstPixelSize: 300, WorstRate: 0},
+	}
+	if !reflect.DeepEqual(summary.PixelSizeByContentType, want) {
+		t.Errorf("PixelSizeByContentType = %+v, want %+v", summary.PixelSizeByContentType, want)
+	}
+}
+
+func TestComputeCombinations_DecodeRateOverEncoded(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc", Decoder: "dec", Success: true},
+		{Encoder: "enc", Decoder: "dec", Success: false, ErrorType: "dataMismatch"},
+		{Encoder: "enc", Decoder: "dec", Success: false, ErrorType: "encode"},
+		{Encoder: "enc", Decoder: "dec", Success: false, ErrorType: "encode"},
+		{Encoder: "enc", Decoder: "dec", Success: false, ErrorType: "capacity", IsCapacityExceeded: true},
+	}
+
+	combinations := computeCombinations(results, 0, nil)
+	c := combinations.Matrix[0]
+	if c.Encoded != 2 {
+		t.Errorf("Encoded = %d, want 2", c.Encoded)
+	}
+	if c.EncodeRate != 50 {
+		t.Errorf("EncodeRate = %.1f, want 50.0 (2/4)", c.EncodeRate)
+	}
+	if c.DecodeRate != 50 {
+		t.Errorf("DecodeRate = %.1f, want 50.0 (1/2 encoded)", c.DecodeRate)
+	}
+	if c.SuccessRate != 25 {
+		t.Errorf("SuccessRate = %.1f, want 25.0 (1/4)", c.SuccessRate)
+	}
+
+	summary := computeSummary(results, nil, nil, combinations, nil)
+	if summary.Encoded != 2 || summary.EncodeRate != 50 || summary.DecodeRate != 50 {
+		t.Errorf("Summary encoded = %d, encode rate = %.1f, decode rate = %.1f, want 2, 50.0, 50.0",
+			summary.Encoded, summary.EncodeRate, summary.DecodeRate)
+	}
+}
+
+func TestComputeCombinations_DecodeStdDev(t *testing.T) {
+	results := []RawTestResult{
+		// Bimodal: most decodes fast, some hit a slow fallback
+		{Encoder: "enc", Decoder: "bimodal", Success: true, DecodeTimeMs: 1},
+		{Encoder: "enc", Decoder: "bimodal", Success: true, DecodeTimeMs: 1},
+		{Encoder: "enc", Decoder: "bimodal", Success: true, DecodeTimeMs: 1},
+		{Encoder: "enc", Decoder: "bimodal", Success: true, DecodeTimeMs: 41},
+		// Capacity skips never reach the decoder
+		{Encoder: "enc", Decoder: "bimodal", ErrorType: "capacity", IsCapacityExceeded: true},
+		{Encoder: "enc", Decoder: "steady", Success: true, DecodeTimeMs: 5},
+		{Encoder: "enc", Decoder: "steady", Success: true, DecodeTimeMs: 5},
+	}
+
+	byDecoder := make(map[string]CombinationResult)
+	for _, c := range computeCombinations(results, 0, nil).Matrix {
+		byDecoder[c.Decoder] = c
+	}
+
+	// Mean 11, deviations -10, -10, -10, 30: variance (300+900)/4 = 300
+	want := math.Sqrt(300)
+	if got := byDecoder["bimodal"].DecodeMsStdDev; math.Abs(got-want) > 1e-9 {
+		t.Errorf("bimodal DecodeMsStdDev = %.3f, want %.3f", got, want)
+	}
+	if got := byDecoder["steady"].DecodeMsStdDev; got != 0 {
+		t.Errorf("steady DecodeMsStdDev = %.3f, want 0", got)
+	}
+}
+
+func TestCheckBaseline_Regression(t *testing.T) {
+	baseline := SummaryData{
+		GatedOverallRate:  90,
+		GatedEncoderRates: map[string]float64{"enc-a": 95, "enc-b": 85},
+		GatedDecoderRates: map[string]float64{"dec-a": 92, "dec-b": 88},
+	}
+	current := SummaryData{
+		GatedOverallRate:  80,
+		GatedEncoderRates: map[string]float64{"enc-a": 95, "enc-b": 65},
+		GatedDecoderRates: map[string]float64{"dec-a": 91.5, "dec-b": 70},
+	}
+
+	var buf bytes.Buffer
+	if code := checkBaseline(&buf, baseline, current, 1); code != 1 {
+		t.Errorf("checkBaseline() = %d, want 1 for a 10 point drop", code)
+	}
+
+	regressions := compareToBaseline(baseline, current, 1)
+	var got []string
+	for _, r := range regressions {
+		got = append(got, r.Kind+":"+r.Name)
+	}
+	want := []string{"overall:", "encoder:enc-b", "decoder:dec-b"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Regressions = %v, want %v (dec-a within tolerance)", got, want)
+	}
+
+	for _, line := range []string{
+		"Regression: overall rate 90.0% → 80.0% (tolerance 1.0 points)",
+		"Regression: encoder enc-b 85.0% → 65.0%",
+		"Regression: decoder dec-b 88.0% → 70.0%",
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("Output missing %q\n\nOutput:\n%s", line, buf.String())
+		}
+	}
+
+	// Within tolerance passes
+	buf.Reset()
+	if code := checkBaseline(&buf, baseline, current, 15); code != 0 {
+		t.Errorf("checkBaseline() with 15 point tolerance = %d, want 0\n%s", code, buf.String())
+	}
+}
+
+func TestExpectations_Apply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "expected.json")
+	keys := `["skip2/go-qrcode|makiuchi-d/gozxing|*|440", "*|liyue201/goqr", "boombuler/barcode|makiuchi-d/gozxing"]`
+	if err := os.WriteFile(path, []byte(keys), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expectations, err := loadExpectations(path)
+	if err != nil {
+		t.Fatalf("loadExpectations() failed: %v", err)
+	}
+
+	results := []RawTestResult{
+		// Known: skip2 + gozxing fails at 440px
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", DataSize: 100, PixelSize: 440, ErrorType: "decode"},
+		// New breakage at another pixel size stays gated
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", DataSize: 100, PixelSize: 480, ErrorType: "decode"},
+		// Known: anything with goqr
+		{Encoder: "boombuler/barcode", Decoder: "liyue201/goqr", DataSize: 100, PixelSize: 480, ErrorType: "dataMismatch"},
+		{Encoder: "boombuler/barcode", Decoder: "liyue201/goqr", DataSize: 100, PixelSize: 440, Success: true},
+		// Expected to fail, but passes
+		{Encoder: "boombuler/barcode", Decoder: "makiuchi-d/gozxing", DataSize: 100, PixelSize: 440, Success: true},
+	}
+
+	gated, outcome := expectations.apply(results)
+	if outcome.Expected != 2 {
+		t.Errorf("Expected = %d, want 2", outcome.Expected)
+	}
+	if len(gated) != 3 {
+		t.Errorf("Gated results = %d, want 3", len(gated))
+	}
+	for _, r := range gated {
+		if r.PixelSize == 440 && !r.Success {
+			t.Errorf("Known failure %+v was not reclassified", r)
+		}
+	}
+	if len(outcome.UnexpectedlyFixed) != 1 || outcome.UnexpectedlyFixed[0] != "boombuler/barcode|makiuchi-d/gozxing" {
+		t.Errorf("UnexpectedlyFixed = %v, want the boombuler + gozxing key", outcome.UnexpectedlyFixed)
+	}
+
+	// One gated failure out of three: the known failures no longer drag the rate
+	summary := computeSummary(gated, nil, nil, CombinationsData{}, nil)
+	if math.Abs(summary.OverallRate-200.0/3) > 1e-9 {
+		t.Errorf("OverallRate = %.1f, want 66.7", summary.OverallRate)
+	}
+}
+
+func TestCheckBaseline_ExpectedFailuresInBothRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "expected.json")
+	if err := os.WriteFile(path, []byte(`["*|dec/known"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectations, err := loadExpectations(path)
+	if err != nil {
+		t.Fatalf("loadExpectations() failed: %v", err)
+	}
+
+	// 80 passes on dec/good and 20 known failures on dec/known, plus
+	// newFailures of the dec/good results failing
+	run := func(newFailures int) SummaryData {
+		var results []RawTestResult
+		for i := 0; i < 80; i++ {
+			results = append(results, RawTestResult{Encoder: "enc", Decoder: "dec/good", DataSize: i, Success: i >= newFailures, ErrorType: "decode"})
+		}
+		for i := 0; i < 20; i++ {
+			results = append(results, RawTestResult{Encoder: "enc", Decoder: "dec/known", DataSize: i, ErrorType: "decode"})
+		}
+		for i := range results {
+			if results[i].Success {
+				results[i].ErrorType = ""
+			}
+		}
+		summary, _ := summarize(results, computeEncoderStats(results), computeDecoderStats(results), CombinationsData{}, nil, &expectations)
+		return summary
+	}
+
+	baseline := run(0)
+	if baseline.OverallRate != 80 || baseline.GatedOverallRate != 100 {
+		t.Errorf("Baseline rates = %.1f published, %.1f gated; want 80, 100", baseline.OverallRate, baseline.GatedOverallRate)
+	}
+	if _, ok := baseline.GatedDecoderRates["dec/known"]; ok {
+		t.Errorf("GatedDecoderRates = %v, want dec/known left out", baseline.GatedDecoderRates)
+	}
+
+	// Five new failures unrelated to the expectations: gated 100% → 93.75%,
+	// though the published rate of 75% is still within 5 points of the
+	// baseline's published 80%
+	current := run(5)
+	var buf bytes.Buffer
+	if code := checkBaseline(&buf, baseline, current, 5); code != 1 {
+		t.Errorf("checkBaseline() = %d, want 1 for an unrelated new failure\n%s", code, buf.String())
+	}
+	if !strings.Contains(buf.String(), "Regression: encoder enc 100.0% → 93.8%") {
+		t.Errorf("Output missing the gated encoder regression:\n%s", buf.String())
+	}
+
+	// The same run against itself passes
+	buf.Reset()
+	if code := checkBaseline(&buf, baseline, run(0), 5); code != 0 {
+		t.Errorf("checkBaseline() = %d for an unchanged run, want 0\n%s", code, buf.String())
+	}
+}
+
+func TestLoadSummary_WithoutGatedRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	data := `{"overallRate": 91.5, "encoderRates": {"enc": 90}, "decoderRates": {"dec": 93}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := loadSummary(path)
+	if err != nil {
+		t.Fatalf("loadSummary() failed: %v", err)
+	}
+	if summary.GatedOverallRate != 91.5 || summary.GatedEncoderRates["enc"] != 90 || summary.GatedDecoderRates["dec"] != 93 {
+		t.Errorf("Gated rates = %v, %v, %v; want the published rates", summary.GatedOverallRate, summary.GatedEncoderRates, summary.GatedDecoderRates)
+	}
+}
+
+func TestLoadExpectations_InvalidKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "expected.json")
+	if err := os.WriteFile(path, []byte(`["a|b|c|d|e|f|g"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadExpectations(path); err == nil || !strings.Contains(err.Error(), "entry 0") {
+		t.Errorf("loadExpectations() error = %v, want entry 0 rejected", err)
+	}
+}
+
+func TestComputeBadge(t *testing.T) {
+	tests := []struct {
+		rate        float64
+		wantMessage string
+		wantColor   string
+	}{
+		{98.2, "98.2%", "brightgreen"},
+		{95, "95.0%", "brightgreen"},
+		{94.9, "94.9%", "yellow"},
+		{70, "70.0%", "yellow"},
+		{42.5, "42.5%", "red"},
+	}
+
+	for _, tt := range tests {
+		badge := computeBadge(SummaryData{OverallRate: tt.rate})
+		if badge.SchemaVersion != 1 || badge.Label != "QR compat" {
+			t.Errorf("computeBadge(%.1f) = %+v, want schemaVersion 1 labeled %q", tt.rate, badge, "QR compat")
+		}
+		if badge.Message != tt.wantMessage || badge.Color != tt.wantColor {
+			t.Errorf("computeBadge(%.1f) = %q %s, want %q %s", tt.rate, badge.Message, badge.Color, tt.wantMessage, tt.wantColor)
+		}
+	}
+
+	// The endpoint schema field names are fixed by shields.io
+	content, err := json.Marshal(computeBadge(SummaryData{OverallRate: 98.2}))
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	want := `{"schemaVersion":1,"label":"QR compat","message":"98.2%","color":"brightgreen"}`
+	if string(content) != want {
+		t.Errorf("badge JSON = %s, want %s", content, want)
+	}
+}
@@ -0,0 +1,63 @@
+// qr-analyze prints findings from previously saved qr-tester results.
+//
+// It loads the JSON files written by qr-tester, runs the failure pattern,
+// fractional module, and non-monotonic analyses, and prints a markdown
+// summary to stdout. No encoding or decoding is performed.
+//
+// Usage:
+//
+//	qr-analyze [-failures-only] [results-dir]
+//
+// Examples:
+//
+//	# Analyze ./results
+//	qr-analyze
+//
+//	# Analyze a saved run and keep the summary
+//	qr-analyze ./old-results > analysis.md
+//
+//	# Only the failing combinations of a large run
+//	qr-analyze -failures-only ./results
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/13rac1/qr-library-test/pkg/report"
+)
+
+func main() {
+	failuresOnly := flag.Bool("failures-only", false, "Write only the failing combinations, skipping all-passing pairs")
+	flag.Parse()
+
+	resultsDir := "results"
+	if flag.NArg() > 0 {
+		resultsDir = flag.Arg(0)
+	}
+
+	if err := run(resultsDir, *failuresOnly, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run loads results from resultsDir and writes the markdown analysis to w,
+// or only its failing parts when failuresOnly is set.
+func run(resultsDir string, failuresOnly bool, w io.Writer) error {
+	results, err := report.LoadResults(resultsDir)
+	if err != nil {
+		return fmt.Errorf("loading results: %w", err)
+	}
+
+	if len(results) == 0 {
+		return fmt.Errorf("no results found in %s", resultsDir)
+	}
+
+	if failuresOnly {
+		return report.WriteFailuresMarkdown(w, report.Analyze(results))
+	}
+	return report.WriteAnalysisMarkdown(w, report.Analyze(results))
+}
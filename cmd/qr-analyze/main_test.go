@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun_Fixture(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run("testdata/results", false, &buf); err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		// 17 results, 1 capacity skip
+		"- **Total tests:** 17",
+		"- **Effective tests:** 16",
+
+		// Combination grid, color-coded by success rate
+		"| **boombuler/barcode** | 🟢 100.0% | 🟡 75.0% |",
+
+		// skip2 → tuotoo fails at both fractional sizes
+		"**skip2/go-qrcode → tuotoo/qrcode**: 50.0% (2/4 effective tests)",
+
+		// Failure patterns, most failures first
+		"| skip2/go-qrcode | tuotoo/qrcode | 2 | 50.0% | 300, 320 | yes |",
+		"| boombuler/barcode | tuotoo/qrcode | 1 | 25.0% | 300 | yes |",
+
+		// Fractional vs integer
+		"| Fractional | 8 | 3 | 37.5% |",
+		"| Integer | 8 | 0 | 0.0% |",
+
+		// Successful decodes return exactly the input length
+		"| makiuchi-d/gozxing | numeric | 8 | 0 | 0 |",
+
+		// boombuler → tuotoo fails at 300px after passing at 287px
+		"| boombuler/barcode | tuotoo/qrcode | numeric | M | 100 | 287px | 300px |",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+
+	if strings.Index(out, "| skip2/go-qrcode | tuotoo/qrcode | 2 |") > strings.Index(out, "| boombuler/barcode | tuotoo/qrcode | 1 |") {
+		t.Error("failure patterns should be sorted by failure count descending")
+	}
+}
+
+func TestRun_FailuresOnly(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run("testdata/results", true, &buf); err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+	out := buf.String()
+
+	// Only the tuotoo column has failures
+	for _, want := range []string{
+		"## Failing Combinations",
+		"| Encoder \\ Decoder | tuotoo/qrcode |",
+		"| **boombuler/barcode** | 🟡 75.0% |",
+		"| skip2/go-qrcode | tuotoo/qrcode | 2 | 50.0% | 300, 320 | yes |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+
+	for _, unwanted := range []string{"makiuchi-d/gozxing", "## Encoder Version Selection", "## Decoded vs Expected Bytes"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output contains %q, want failing combinations only\n\nOutput:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestRun_EmptyDir(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run(t.TempDir(), false, &buf); err == nil {
+		t.Error("run() with no results should fail")
+	}
+}
@@ -0,0 +1,53 @@
+// qr-serve serves an HTML dashboard for previously saved qr-tester results.
+//
+// It loads the JSON files written by qr-tester once at startup and renders
+// the combination matrix, per-pair details, and failure charts with
+// html/template. No Hugo toolchain is needed.
+//
+// Usage:
+//
+//	qr-serve [-addr host:port] [results-dir]
+//
+// Examples:
+//
+//	# Serve ./results on http://localhost:8080
+//	qr-serve
+//
+//	# Serve a saved run on another port
+//	qr-serve -addr :9000 ./old-results
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/13rac1/qr-library-test/internal/dashboard"
+	"github.com/13rac1/qr-library-test/pkg/report"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "Address to listen on")
+	flag.Parse()
+
+	resultsDir := "results"
+	if flag.NArg() > 0 {
+		resultsDir = flag.Arg(0)
+	}
+
+	results, err := report.LoadResults(resultsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading results: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(os.Stderr, "No results found in %s\n", resultsDir)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Loaded %d test results, serving on http://%s/\n", len(results), *addr)
+	log.Fatal(http.ListenAndServe(*addr, dashboard.NewServer(results)))
+}
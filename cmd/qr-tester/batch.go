@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/matrix"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+	"github.com/13rac1/qr-library-test/pkg/report"
+)
+
+// batchCommand reads newline-delimited payloads from stdin, encodes each
+// with one encoder, decodes it with every decoder, and prints one
+// compatibility line per payload.
+func batchCommand(args []string) error {
+	cfg := config.DefaultConfig()
+
+	fs := flag.NewFlagSet("qr-tester batch", flag.ExitOnError)
+	encoderName := fs.String("encoder", "skip2/go-qrcode", "Encoder to encode every payload with")
+	pixelSize := fs.Int("pixel-size", 400, "Image size in pixels")
+	ecLevel := fs.String("ec", "M", "Error correction level: L, M, Q, or H")
+	fs.DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "Timeout per decoder operation")
+	fs.BoolVar(&cfg.SkipCGO, "skip-cgo", cfg.SkipCGO, "Skip CGO-based encoders and decoders")
+	fs.BoolVar(&cfg.SkipArchived, "skip-archived", cfg.SkipArchived, "Skip archived libraries")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *pixelSize <= 0 {
+		return fmt.Errorf("batch: pixel size must be positive, got %d", *pixelSize)
+	}
+	switch *ecLevel {
+	case "L", "M", "Q", "H":
+	default:
+		return fmt.Errorf("batch: invalid error correction level %q: must be L, M, Q, or H", *ecLevel)
+	}
+
+	var enc encoders.Encoder
+	var names []string
+	for _, e := range encoders.GetAvailableEncoders(cfg) {
+		if e.Name() == *encoderName {
+			enc = e
+		}
+		names = append(names, e.Name())
+	}
+	if enc == nil {
+		return fmt.Errorf("batch: unknown encoder %q: must be one of %s", *encoderName, strings.Join(names, ", "))
+	}
+
+	decs := decoders.GetAvailableDecoders(cfg)
+	if len(decs) == 0 {
+		return fmt.Errorf("no decoders available (check CGO build and skip flags)")
+	}
+
+	return runBatch(os.Stdin, os.Stdout, cfg, enc, decs, *pixelSize, *ecLevel)
+}
+
+// runBatch runs every non-empty line of r as a single test case through
+// the runner and writes a summary line per payload to w:
+//
+//	line 1: 5 bytes, 3/3 decoders: makiuchi-d/gozxing ✓, tuotoo/qrcode ✓, liyue201/goqr ✓
+//
+// A failed decode names its error type; a failed encode skips the decoders.
+func runBatch(r io.Reader, w io.Writer, cfg *config.Config, enc encoders.Encoder, decs []decoders.Decoder, pixelSize int, ecLevel string) error {
+	// Per-test progress lines would interleave with the summaries
+	quiet := *cfg
+	quiet.Quiet = true
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		contentType := testdata.ContentUTF8
+		if !utf8.ValidString(line) {
+			contentType = testdata.ContentBinary
+		}
+		testCase := testdata.TestCase{
+			Name:                 fmt.Sprintf("batch-line%d", lineNum),
+			Data:                 []byte(line),
+			DataSize:             len(line),
+			PixelSize:            pixelSize,
+			ContentType:          contentType,
+			ErrorCorrectionLevel: ecLevel,
+		}
+
+		runner, err := matrix.NewRunner(&quiet, []encoders.Encoder{enc}, decs, []testdata.TestCase{testCase})
+		if err != nil {
+			return err
+		}
+		m, err := runner.RunAll()
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		writeBatchLine(w, lineNum, len(line), report.ConvertResults(m))
+	}
+	return scanner.Err()
+}
+
+// writeBatchLine writes the summary of one payload's results.
+func writeBatchLine(w io.Writer, lineNum, size int, results []report.RawTestResult) {
+	if len(results) > 0 && !results[0].Encoded() {
+		fmt.Fprintf(w, "line %d: %d bytes, encode failed (%s)\n", lineNum, size, results[0].ErrorType)
+		return
+	}
+
+	successes := 0
+	outcomes := make([]string, 0, len(results))
+	for _, r := range results {
+		if r.Success {
+			successes++
+			outcomes = append(outcomes, r.Decoder+" ✓")
+			continue
+		}
+		outcomes = append(outcomes, fmt.Sprintf("%s ✗ (%s)", r.Decoder, r.ErrorType))
+	}
+	fmt.Fprintf(w, "line %d: %d bytes, %d/%d decoders: %s\n", lineNum, size, successes, len(results), strings.Join(outcomes, ", "))
+}
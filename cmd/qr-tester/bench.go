@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+// Fixed payload and size for throughput runs: a typical URL-length code at
+// an integer module size, so every library decodes it.
+var (
+	benchData = []byte("https://example.com/qr-benchmarks/throughput?id=0123456789")
+	benchOpts = encoders.EncodeOptions{ErrorCorrectionLevel: encoders.ErrorCorrectionM, PixelSize: 400}
+)
+
+// runThroughput measures codes/sec for each encoder on benchData, then for
+// each decoder on one image encoded by the first encoder that succeeds, and
+// writes a table to w.
+func runThroughput(encs []encoders.Encoder, decs []decoders.Decoder, budget time.Duration, w io.Writer) error {
+	var img image.Image
+	var imgEncoder string
+	var results []matrix.Throughput
+	for _, enc := range encs {
+		if img == nil {
+			result, err := enc.Encode(benchData, benchOpts)
+			if err == nil {
+				img, imgEncoder = result.Image, enc.Name()
+			}
+		}
+		results = append(results, matrix.MeasureEncodeThroughput(enc, benchData, benchOpts, budget))
+	}
+	if img == nil {
+		return fmt.Errorf("bench: no encoder produced an image to decode")
+	}
+
+	fmt.Fprintf(w, "Throughput over %v per library (%d bytes, %dpx, EC %s)\n\n",
+		budget, len(benchData), benchOpts.PixelSize, benchOpts.ErrorCorrectionLevel)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STAGE\tLIBRARY\tCODES/SEC\tOPS\tERRORS")
+	for _, t := range results {
+		fmt.Fprintf(tw, "encode\t%s\t%.1f\t%d\t%d\n", t.Name, t.CodesPerSec, t.Ops, t.Errors)
+	}
+	for _, dec := range decs {
+		t := matrix.MeasureDecodeThroughput(dec, img, budget)
+		fmt.Fprintf(tw, "decode\t%s\t%.1f\t%d\t%d\n", t.Name, t.CodesPerSec, t.Ops, t.Errors)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nDecoders read an image from %s\n", imgEncoder)
+	return nil
+}
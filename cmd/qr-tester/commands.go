@@ -0,0 +1,186 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/dashboard"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/pkg/report"
+)
+
+// command is a qr-tester subcommand. Each parses its own flags from args,
+// the arguments after the subcommand name.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+// commands lists the subcommands in usage order. run is the default.
+var commands = []command{
+	{"run", "Run the encoder/decoder test matrix (default)", runCommand},
+	{"analyze", "Print a markdown analysis of saved results", analyzeCommand},
+	{"diff", "List tests whose outcome changed between two results directories", diffCommand},
+	{"batch", "Check newline-delimited payloads from stdin against every decoder", batchCommand},
+	{"serve", "Serve an HTML dashboard of saved results", serveCommand},
+	{"version", "Print the version, or build and capabilities with -json", versionCommand},
+}
+
+// dispatch runs the subcommand named by args[0] with the remaining args.
+// No arguments, or a flag first, runs the matrix, so invocations from before
+// subcommands existed keep working.
+func dispatch(args []string) error {
+	name := "run"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(args)
+		}
+	}
+
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[i] = cmd.name
+	}
+	return fmt.Errorf("unknown command %q: must be one of %s", name, strings.Join(names, ", "))
+}
+
+// resultsDirArg returns the results directory argument of fs, "results" by default.
+func resultsDirArg(fs *flag.FlagSet) string {
+	if fs.NArg() > 0 {
+		return fs.Arg(0)
+	}
+	return "results"
+}
+
+// loadResults loads the results in dir, failing when there are none.
+func loadResults(dir string) ([]report.RawTestResult, error) {
+	results, err := report.LoadResults(dir)
+	if err != nil {
+		return nil, fmt.Errorf("loading results: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no results found in %s", dir)
+	}
+	return results, nil
+}
+
+// analyzeCommand prints the markdown analysis of a saved results directory,
+// like qr-analyze.
+func analyzeCommand(args []string) error {
+	fs := flag.NewFlagSet("qr-tester analyze", flag.ExitOnError)
+	failuresOnly := fs.Bool("failures-only", false, "Write only the failing combinations, skipping all-passing pairs")
+	explain := fs.Bool("explain", false, "Annotate each failure with its likely cause")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	results, err := loadResults(resultsDirArg(fs))
+	if err != nil {
+		return err
+	}
+
+	return writeAnalysis(os.Stdout, results, *failuresOnly, *explain)
+}
+
+// writeAnalysis writes the markdown analysis of results to w, or only its
+// failing parts when failuresOnly is set, followed by the likely cause of
+// every failure when explain is set.
+func writeAnalysis(w io.Writer, results []report.RawTestResult, failuresOnly, explain bool) error {
+	write := report.WriteAnalysisMarkdown
+	if failuresOnly {
+		write = report.WriteFailuresMarkdown
+	}
+	if err := write(w, report.Analyze(results)); err != nil {
+		return err
+	}
+	if !explain {
+		return nil
+	}
+
+	archived := make(map[string]bool)
+	for _, name := range decoders.ArchivedDecoderNames() {
+		archived[name] = true
+	}
+	return report.WriteExplainMarkdown(w, results, archived)
+}
+
+// diffCommand lists the tests present in both results directories whose
+// outcome (success or error type) changed.
+func diffCommand(args []string) error {
+	fs := flag.NewFlagSet("qr-tester diff", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("diff: want two results directories, got %d", fs.NArg())
+	}
+
+	return writeDiff(os.Stdout, fs.Arg(0), fs.Arg(1))
+}
+
+// writeDiff writes the tests shared by oldDir and newDir whose outcome
+// changed, one result key per line, followed by a count.
+func writeDiff(w io.Writer, oldDir, newDir string) error {
+	_, conflicts, err := report.MergeResultsConflicts(oldDir, newDir)
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	for _, c := range conflicts {
+		if c.Changed {
+			fmt.Fprintln(w, c.Key)
+			changed++
+		}
+	}
+	fmt.Fprintf(w, "%d of %d shared tests changed outcome\n", changed, len(conflicts))
+	return nil
+}
+
+// serveCommand serves the HTML dashboard of a saved results directory,
+// like qr-serve.
+func serveCommand(args []string) error {
+	fs := flag.NewFlagSet("qr-tester serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	results, err := loadResults(resultsDirArg(fs))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Loaded %d test results, serving on http://%s/\n", len(results), *addr)
+	return http.ListenAndServe(*addr, dashboard.NewServer(results))
+}
+
+// versionCommand prints the version, or with -json the build and the
+// encoders/decoders available under the skip flags.
+func versionCommand(args []string) error {
+	fs := flag.NewFlagSet("qr-tester version", flag.ExitOnError)
+	cfg, parse := config.RegisterFlags(fs)
+	asJSON := fs.Bool("json", false, "Print version, build, and available encoders/decoders as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := parse(); err != nil {
+		return fmt.Errorf("config parse error: %w", err)
+	}
+
+	if *asJSON {
+		return writeVersionJSON(os.Stdout, cfg)
+	}
+	fmt.Printf("qr-tester v%s\n", version)
+	return nil
+}
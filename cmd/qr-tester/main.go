@@ -6,7 +6,14 @@
 //
 // Usage:
 //
-//	qr-tester [flags]
+//	qr-tester [run] [flags]
+//	qr-tester analyze [-failures-only] [-explain] [results-dir]
+//	qr-tester diff old-results-dir new-results-dir
+//	qr-tester batch [-encoder name] [-pixel-size n] [-ec level] < payloads.txt
+//	qr-tester serve [-addr host:port] [results-dir]
+//	qr-tester version [-json] [flags]
+//
+// Bare invocation, or a flag as the first argument, runs the matrix.
 //
 // Examples:
 //
@@ -21,13 +28,30 @@
 //
 //	# Run with custom test parameters
 //	qr-tester -data-sizes=500,600,700 -pixel-sizes=320,480,640
+//
+//	# Print version and capabilities as JSON
+//	qr-tester version -json -skip-cgo=true
+//
+//	# List tests whose outcome changed between two runs
+//	qr-tester diff ./old-results ./results
+//
+//	# Check which decoders read each line of a file
+//	qr-tester batch -encoder boombuler/barcode -pixel-size 320 < urls.txt
+//
+//	# Profile CPU and memory use
+//	qr-tester -cpuprofile=cpu.pprof -memprofile=mem.pprof
+//
+//	# Measure codes/sec per library for 2s each
+//	qr-tester -bench-duration=2s
 package main
 
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"time"
 
 	"github.com/13rac1/qr-library-test/internal/config"
 	"github.com/13rac1/qr-library-test/internal/decoders"
@@ -40,51 +64,70 @@ import (
 const version = "1.0.0"
 
 func main() {
-	// Check for version flag
-	if len(os.Args) > 1 {
-		switch os.Args[1] {
-		case "version", "-version", "--version":
-			fmt.Printf("qr-tester v%s\n", version)
-			return
-		}
+	if err := dispatch(os.Args[1:]); err != nil {
+		log.Fatal(err)
 	}
+}
 
+// runCommand runs the test matrix: the run subcommand and bare invocation.
+func runCommand(args []string) error {
 	// Register flags
-	fs := flag.NewFlagSet("qr-tester", flag.ExitOnError)
+	fs := flag.NewFlagSet("qr-tester run", flag.ExitOnError)
 	cfg, parse := config.RegisterFlags(fs)
 
-	// Add version flag
+	// Add version flags
 	showVersion := fs.Bool("version", false, "Print version and exit")
+	showVersionJSON := fs.Bool("version-json", false, "Print version, build, and available encoders/decoders as JSON and exit")
 
 	// Parse flags
-	if err := fs.Parse(os.Args[1:]); err != nil {
-		log.Fatalf("Flag parse error: %v", err)
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("flag parse error: %w", err)
 	}
 
 	// Handle version
 	if *showVersion {
 		fmt.Printf("qr-tester v%s\n", version)
-		os.Exit(0)
+		return nil
 	}
 
 	// Parse config from flags
 	if err := parse(); err != nil {
-		log.Fatalf("Config parse error: %v", err)
+		return fmt.Errorf("config parse error: %w", err)
+	}
+
+	// Handle JSON version after config parsing so skip flags apply
+	if *showVersionJSON {
+		if err := writeVersionJSON(os.Stdout, cfg); err != nil {
+			return fmt.Errorf("version output error: %w", err)
+		}
+		return nil
 	}
 
 	// Validate config
 	if err := cfg.Validate(); err != nil {
-		log.Fatalf("Config validation error: %v", err)
+		return fmt.Errorf("config validation error: %w", err)
 	}
 
 	// Run tests
 	if err := run(cfg); err != nil {
-		log.Fatalf("Test execution failed: %v", err)
+		return fmt.Errorf("test execution failed: %w", err)
 	}
+	return nil
 }
 
 // run executes the complete test matrix and generates reports.
-func run(cfg *config.Config) error {
+func run(cfg *config.Config) (err error) {
+	// Optional pprof profiling around the whole run
+	stopProfiling, err := startProfiling(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if stopErr := stopProfiling(); stopErr != nil && err == nil {
+			err = stopErr
+		}
+	}()
+
 	// Setup encoders (based on config flags)
 	encs := encoders.GetAvailableEncoders(cfg)
 
@@ -94,39 +137,275 @@ func run(cfg *config.Config) error {
 		return fmt.Errorf("no decoders available (check CGO build and skip flags)")
 	}
 
-	// Generate test data based on test mode
+	// Throughput mode replaces the matrix
+	if cfg.BenchDuration > 0 {
+		return runThroughput(encs, decs, cfg.BenchDuration, os.Stdout)
+	}
+
+	// Generate test data based on test mode, or load a committed vector file
+	// or the one case -single describes
 	var testCases []testdata.TestCase
-	switch cfg.TestMode {
-	case "comprehensive":
+	switch {
+	case cfg.Single != "":
+		tc, err := testdata.ParseSingleSpec(cfg.Single)
+		if err != nil {
+			return fmt.Errorf("single: %w", err)
+		}
+		testCases = []testdata.TestCase{tc}
+	case cfg.VectorsPath != "":
+		vectors, err := testdata.LoadVectors(cfg.VectorsPath)
+		if err != nil {
+			return err
+		}
+		testCases = vectors
+	case cfg.TestMode == "comprehensive":
 		testCases = testdata.GenerateComprehensiveMatrix()
-	case "standard":
-		fallthrough
 	default:
 		testCases = testdata.GeneratePixelSizeMatrix()
 	}
 
+	// Optionally narrow to a predicted module pixel size band
+	testCases = testdata.FilterByModulePixelSize(testCases, cfg.MinModulePx, cfg.MaxModulePx)
+	if len(testCases) == 0 {
+		return fmt.Errorf("no test cases in module pixel size band %.2f-%.2f", cfg.MinModulePx, cfg.MaxModulePx)
+	}
+
+	// Optionally keep only integer or fractional predicted module sizes
+	testCases = testdata.FilterByModuleSizeType(testCases, cfg.ModuleSizeFilter)
+	if len(testCases) == 0 {
+		return fmt.Errorf("no test cases with %s module sizes", cfg.ModuleSizeFilter)
+	}
+
+	return runMatrix(cfg, encs, decs, testCases, os.Stdout, os.Stderr)
+}
+
+// runMatrix runs the test cases against every encoder/decoder pair, writes
+// the reports, and prints a run summary to stderr unless cfg.Quiet is set.
+// Status lines go to stdout, or to stderr when cfg.OutputStdout leaves
+// stdout to the markdown report.
+func runMatrix(cfg *config.Config, encs []encoders.Encoder, decs []decoders.Decoder, testCases []testdata.TestCase, stdout, stderr io.Writer) error {
+	// A report on stdout leaves it to the report alone
+	status := stdout
+	if cfg.OutputStdout {
+		cfg.Quiet = true
+		status = stderr
+	}
+
+	// Saving failed images needs the encoded images after the run
+	if cfg.SaveFailedImages != "" {
+		cfg.RetainImages = true
+	}
+
+	// Each run gets its own subdirectory; latest moves once it is complete
+	baseDir := cfg.OutputDir
+	if cfg.SubdirPerRun {
+		runDir, err := createRunDir(baseDir, time.Now())
+		if err != nil {
+			return err
+		}
+		cfg.OutputDir = runDir
+	}
+
+	if cfg.CrossEncoder != "" && !hasDecoder(decs, cfg.CrossEncoder) {
+		return fmt.Errorf("cross-encoder: decoder %q is not in this run", cfg.CrossEncoder)
+	}
+
 	// Create runner
-	runner := matrix.NewRunner(cfg, encs, decs, testCases)
+	runner, err := matrix.NewRunner(cfg, encs, decs, testCases)
+	if err != nil {
+		return err
+	}
+
+	// Catch decoders that cannot read anything before they fail every test
+	failures, err := runner.Preflight()
+	if err != nil {
+		return err
+	}
+	for _, f := range failures {
+		action := "results will be failures"
+		if cfg.DropUnhealthyDecoders {
+			action = "dropped"
+		}
+		fmt.Fprintf(status, "Pre-flight failed: %s could not read a trivial QR code (%v); %s\n", f.DecoderName, f.Err, action)
+	}
+	if len(runner.Decoders) == 0 {
+		return fmt.Errorf("no decoders passed the pre-flight check")
+	}
+	decs = runner.Decoders
+
+	// Optional reference decoder for cross-validating shared failures
+	if cfg.CrossValidate {
+		ref, ok := decoders.FindReferenceDecoder()
+		if !ok {
+			return fmt.Errorf("cross-validate: no reference decoder (install zbarimg or build with CGO)")
+		}
+		runner.Reference = ref
+		fmt.Fprintf(status, "Cross-validating failures with %s\n", ref.Name())
+	}
 
 	// Calculate and display test count
-	totalTests := len(encs) * len(decs) * len(testCases)
-	fmt.Printf("Running %d test combinations (%s mode)...\n", totalTests, cfg.TestMode)
-	fmt.Printf("  Encoders: %d\n", len(encs))
-	fmt.Printf("  Decoders: %d\n", len(decs))
-	fmt.Printf("  Test cases: %d\n\n", len(testCases))
+	totalTests := runner.TotalTests()
+	if err := cfg.CheckCombinations(totalTests); err != nil {
+		return err
+	}
+	fmt.Fprintf(status, "Running %d test combinations (%s mode)...\n", totalTests, cfg.TestMode)
+	fmt.Fprintf(status, "  Encoders: %d\n", len(encs))
+	fmt.Fprintf(status, "  Decoders: %d\n", len(decs))
+	fmt.Fprintf(status, "  Test cases: %d\n\n", len(testCases))
+
+	if cfg.CheckDeterminism && len(testCases) > 0 {
+		reportNondeterministic(status, cfg, encs, testCases[0])
+	}
 
 	// Run all tests
 	results, err := runner.RunAll()
 	if err != nil {
 		return fmt.Errorf("test execution failed: %w", err)
 	}
+	if results.Skipped > 0 {
+		fmt.Fprintf(status, "Stopped at max-duration %v: %d of %d tests skipped\n", cfg.MaxDuration, results.Skipped, totalTests)
+	}
 
 	// Generate JSON report
-	reporter := report.NewJSONReporter(cfg.OutputDir)
-	if err := reporter.Generate(results); err != nil {
-		return fmt.Errorf("json report failed: %w", err)
+	if cfg.HasFormat("json") {
+		reporter := report.NewJSONReporter(cfg.OutputDir)
+		reporter.Compress = cfg.CompressOutput
+		reporter.FailuresOnly = cfg.FailuresOnly
+		if err := reporter.Generate(results); err != nil {
+			return fmt.Errorf("json report failed: %w", err)
+		}
+	}
+
+	// One result per line for data pipelines
+	if cfg.HasFormat("jsonl") {
+		reporter := report.NewJSONLReporter(cfg.OutputDir)
+		reporter.FailuresOnly = cfg.FailuresOnly
+		if err := reporter.Generate(results); err != nil {
+			return fmt.Errorf("jsonl report failed: %w", err)
+		}
+	}
+
+	// Human-readable report, as files or one document on stdout
+	if cfg.HasFormat("markdown") {
+		reporter := report.NewMarkdownReporter(cfg.OutputDir)
+		reporter.FailuresOnly = cfg.FailuresOnly
+		if cfg.OutputStdout {
+			reporter.Output = stdout
+		}
+		if err := reporter.Generate(results); err != nil {
+			return fmt.Errorf("markdown report failed: %w", err)
+		}
+	}
+
+	// Optional SQLite sink for querying across runs
+	if cfg.SQLitePath != "" {
+		sqliteReporter := report.NewSQLiteReporter(cfg.SQLitePath)
+		if err := sqliteReporter.Generate(results); err != nil {
+			return fmt.Errorf("sqlite report failed: %w", err)
+		}
+		fmt.Fprintf(status, "Results appended to %s (run %s)\n", cfg.SQLitePath, sqliteReporter.RunID)
+	}
+
+	fmt.Fprintf(status, "Results written to %s/\n", cfg.OutputDir)
+	if cfg.SubdirPerRun {
+		if err := linkLatest(baseDir, cfg.OutputDir); err != nil {
+			return err
+		}
+	}
+
+	if cfg.MetricsFile != "" {
+		if err := report.WriteMetricsFile(cfg.MetricsFile, report.ConvertResults(results)); err != nil {
+			return fmt.Errorf("metrics failed: %w", err)
+		}
+		fmt.Fprintf(status, "Metrics written to %s\n", cfg.MetricsFile)
+	}
+
+	if cfg.SaveFailedImages != "" {
+		saved, err := report.SaveFailedImages(cfg.SaveFailedImages, results)
+		if err != nil {
+			return fmt.Errorf("saving failed images: %w", err)
+		}
+		fmt.Fprintf(status, "%d failed images written to %s/\n", saved, cfg.SaveFailedImages)
 	}
 
-	fmt.Printf("Results written to %s/\n", cfg.OutputDir)
+	if !cfg.Quiet {
+		var excluded map[string]bool
+		if cfg.ExcludeArchivedFromRate {
+			excluded = archivedDecoderSet(decs)
+		}
+		analysis := report.AnalyzeExcluding(report.ConvertResults(results), excluded)
+		if err := report.WriteRunSummary(stderr, analysis); err != nil {
+			return fmt.Errorf("run summary failed: %w", err)
+		}
+		if len(results.SeedStability) > 0 {
+			if err := report.WriteSeedStability(stderr, results.SeedStability); err != nil {
+				return fmt.Errorf("seed stability failed: %w", err)
+			}
+		}
+		if len(results.Flakiness) > 0 {
+			if err := report.WriteFlakiness(stderr, results.Flakiness); err != nil {
+				return fmt.Errorf("flakiness failed: %w", err)
+			}
+		}
+		// Agreement needs a majority to dissent from
+		if len(decs) >= 3 {
+			if err := report.WriteConsensus(stderr, matrix.DecoderConsensus(results.Results)); err != nil {
+				return fmt.Errorf("consensus report failed: %w", err)
+			}
+		}
+		if cfg.CrossEncoder != "" {
+			readability := matrix.CrossEncoderReport(results.Results, cfg.CrossEncoder)
+			if err := report.WriteCrossEncoder(stderr, cfg.CrossEncoder, readability); err != nil {
+				return fmt.Errorf("cross-encoder report failed: %w", err)
+			}
+		}
+	}
 	return nil
 }
+
+// reportNondeterministic encodes tc twice with each encoder and writes a
+// warning for every encoder whose two images differ or that fails to encode.
+func reportNondeterministic(w io.Writer, cfg *config.Config, encs []encoders.Encoder, tc testdata.TestCase) {
+	opts := encoders.EncodeOptions{
+		ErrorCorrectionLevel: tc.ErrorCorrectionLevel,
+		PixelSize:            tc.PixelSize,
+		MicroQR:              cfg.MicroQR,
+		ForceVersion:         cfg.ForceVersion,
+
+		PixelSizeIncludesQuietZone: cfg.PixelSizeIncludesQuietZone,
+	}
+	if opts.ErrorCorrectionLevel == "" {
+		opts.ErrorCorrectionLevel = encoders.ErrorCorrectionM
+	}
+
+	for _, enc := range encs {
+		deterministic, err := encoders.CheckDeterminism(enc, tc.Data, opts)
+		switch {
+		case err != nil:
+			fmt.Fprintf(w, "Determinism check skipped: %v\n", err)
+		case !deterministic:
+			fmt.Fprintf(w, "Non-deterministic encoder: %s encoded the same data to different images\n", enc.Name())
+		}
+	}
+}
+
+// hasDecoder reports whether decs includes a decoder named name.
+func hasDecoder(decs []decoders.Decoder, name string) bool {
+	for _, dec := range decs {
+		if dec.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// archivedDecoderSet returns the names of the archived decoders in decs.
+func archivedDecoderSet(decs []decoders.Decoder) map[string]bool {
+	set := make(map[string]bool)
+	for _, dec := range decs {
+		if decoders.IsArchived(dec) {
+			set[dec.Name()] = true
+		}
+	}
+	return set
+}
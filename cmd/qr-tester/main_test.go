@@ -0,0 +1,489 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/matrix"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+	"github.com/13rac1/qr-library-test/pkg/report"
+)
+
+func TestStartProfiling_CPUAndHeap(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.ProfileCPU = filepath.Join(dir, "cpu.pprof")
+	cfg.ProfileMem = filepath.Join(dir, "mem.pprof")
+
+	stop, err := startProfiling(cfg)
+	if err != nil {
+		t.Fatalf("startProfiling() failed: %v", err)
+	}
+
+	// Tiny matrix: one encoder, one decoder, one test case
+	data := []byte("profile me")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-10b-256px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            256,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+	runner, err := matrix.NewRunner(cfg, []encoders.Encoder{&encoders.Skip2Encoder{}}, []decoders.Decoder{&decoders.GozxingDecoder{}}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	if _, err := runner.RunAll(); err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	if err := stop(); err != nil {
+		t.Fatalf("stop() failed: %v", err)
+	}
+
+	for _, path := range []string{cfg.ProfileCPU, cfg.ProfileMem} {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Failed to open profile: %v", err)
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			t.Fatalf("Failed to stat profile: %v", err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("Profile %s is empty", filepath.Base(path))
+		}
+
+		if _, err := profile.Parse(f); err != nil {
+			t.Errorf("profile.Parse(%s) failed: %v", filepath.Base(path), err)
+		}
+	}
+}
+
+func TestStartProfiling_Disabled(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	stop, err := startProfiling(cfg)
+	if err != nil {
+		t.Fatalf("startProfiling() failed: %v", err)
+	}
+	if err := stop(); err != nil {
+		t.Errorf("stop() failed: %v", err)
+	}
+}
+
+func TestWriteVersionJSON(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SkipArchived = true
+
+	var buf bytes.Buffer
+	if err := writeVersionJSON(&buf, cfg); err != nil {
+		t.Fatalf("writeVersionJSON() failed: %v", err)
+	}
+
+	var info VersionInfo
+	if err := json.Unmarshal(buf.Bytes(), &info); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if info.Version != version {
+		t.Errorf("version = %q, want %q", info.Version, version)
+	}
+	if info.GoVersion == "" {
+		t.Error("goVersion should not be empty")
+	}
+	if info.CGOEnabled != decoders.CGOEnabled() {
+		t.Errorf("cgoEnabled = %v, want %v", info.CGOEnabled, decoders.CGOEnabled())
+	}
+
+	// Decoder list honors skip flags
+	want := decoders.GetAvailableDecoders(cfg)
+	if len(info.Decoders) != len(want) {
+		t.Fatalf("decoders = %v, want %d entries", info.Decoders, len(want))
+	}
+	for i, dec := range want {
+		if info.Decoders[i] != dec.Name() {
+			t.Errorf("decoders[%d] = %q, want %q", i, info.Decoders[i], dec.Name())
+		}
+		if dec.Name() == "liyue201/goqr" {
+			t.Error("decoders should not include goqr with SkipArchived")
+		}
+	}
+
+	if len(info.Encoders) == 0 {
+		t.Error("encoders should not be empty")
+	}
+}
+
+func TestRunMatrix_Summary(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.OutputDir = t.TempDir()
+
+	data := []byte("summary")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-7b-256px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            256,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+	encs := []encoders.Encoder{&encoders.Skip2Encoder{}}
+	decs := []decoders.Decoder{&decoders.GozxingDecoder{}}
+
+	var stderr bytes.Buffer
+	if err := runMatrix(cfg, encs, decs, cases, io.Discard, &stderr); err != nil {
+		t.Fatalf("runMatrix() failed: %v", err)
+	}
+
+	out := stderr.String()
+	for _, want := range []string{
+		"Success rate:    100.0% (1/1)",
+		"Best:            skip2/go-qrcode → makiuchi-d/gozxing (100.0%)",
+		"Failures:        none",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Summary missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+
+	// Quiet suppresses the summary
+	cfg.Quiet = true
+	stderr.Reset()
+	if err := runMatrix(cfg, encs, decs, cases, io.Discard, &stderr); err != nil {
+		t.Fatalf("runMatrix() with Quiet failed: %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("Quiet run wrote to stderr:\n%s", stderr.String())
+	}
+}
+
+func TestRunMatrix_OutputStdout(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.OutputDir = t.TempDir()
+	cfg.Formats = []string{"markdown"}
+	cfg.OutputStdout = true
+
+	data := []byte("stdout")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-6b-256px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            256,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+	encs := []encoders.Encoder{&encoders.Skip2Encoder{}, &encoders.BoombulerEncoder{}}
+	decs := []decoders.Decoder{&decoders.GozxingDecoder{}}
+
+	var stdout, stderr bytes.Buffer
+	if err := runMatrix(cfg, encs, decs, cases, &stdout, &stderr); err != nil {
+		t.Fatalf("runMatrix() failed: %v", err)
+	}
+
+	out := stdout.String()
+	if !strings.HasPrefix(out, "# QR Compatibility Report") {
+		t.Errorf("stdout does not start with the report title:\n%s", out)
+	}
+	for _, want := range []string{
+		"## Summary",
+		"## boombuler/barcode → makiuchi-d/gozxing",
+		"## skip2/go-qrcode → makiuchi-d/gozxing",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stdout missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+
+	// Status lines move to stderr and no markdown files are written
+	if !strings.Contains(stderr.String(), "Running 2 test combinations") {
+		t.Errorf("stderr missing status lines:\n%s", stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(cfg.OutputDir, report.MarkdownSummaryFile)); !os.IsNotExist(err) {
+		t.Errorf("%s written with OutputStdout, stat error = %v", report.MarkdownSummaryFile, err)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	saved := commands
+	defer func() { commands = saved }()
+
+	var ran string
+	var gotArgs []string
+	commands = nil
+	for _, cmd := range saved {
+		name := cmd.name
+		commands = append(commands, command{name: name, run: func(args []string) error {
+			ran, gotArgs = name, args
+			return nil
+		}})
+	}
+
+	tests := []struct {
+		args     []string
+		wantCmd  string
+		wantArgs []string
+	}{
+		{[]string{"version"}, "version", []string{}},
+		{nil, "run", nil},
+		{[]string{"-test-mode=comprehensive"}, "run", []string{"-test-mode=comprehensive"}},
+		{[]string{"analyze", "-failures-only", "./results"}, "analyze", []string{"-failures-only", "./results"}},
+	}
+	for _, tt := range tests {
+		ran, gotArgs = "", nil
+		if err := dispatch(tt.args); err != nil {
+			t.Fatalf("dispatch(%q) failed: %v", tt.args, err)
+		}
+		if ran != tt.wantCmd {
+			t.Errorf("dispatch(%q) ran %q, want %q", tt.args, ran, tt.wantCmd)
+		}
+		if strings.Join(gotArgs, " ") != strings.Join(tt.wantArgs, " ") {
+			t.Errorf("dispatch(%q) passed args %q, want %q", tt.args, gotArgs, tt.wantArgs)
+		}
+	}
+
+	if err := dispatch([]string{"bogus"}); err == nil || !strings.Contains(err.Error(), `unknown command "bogus"`) {
+		t.Errorf("dispatch(bogus) error = %v, want unknown command", err)
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	write := func(dir, timestamp string, results []report.RawTestResult) {
+		t.Helper()
+		encDir := filepath.Join(dir, "encoders")
+		if err := os.MkdirAll(encDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(report.RawResults{Timestamp: timestamp, Results: results})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(encDir, "enc.json"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	write(oldDir, "2026-01-01T00:00:00Z", []report.RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, ErrorType: "decode"},
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", DataSize: 100, PixelSize: 300, Success: true},
+	})
+	write(newDir, "2026-02-01T00:00:00Z", []report.RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, Success: true},
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", DataSize: 100, PixelSize: 300, Success: true},
+	})
+
+	var buf bytes.Buffer
+	if err := writeDiff(&buf, oldDir, newDir); err != nil {
+		t.Fatalf("writeDiff() failed: %v", err)
+	}
+
+	want := "skip2/go-qrcode|tuotoo/qrcode|100|300||\n1 of 2 shared tests changed outcome\n"
+	if buf.String() != want {
+		t.Errorf("writeDiff() output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	cfg := config.DefaultConfig()
+	decs := []decoders.Decoder{&decoders.GozxingDecoder{}, &decoders.SegmentDecoder{}}
+	input := "HELLO WORLD\n\nhttps://example.com/a?b=c\n" + strings.Repeat("x", 3000) + "\n"
+
+	var out bytes.Buffer
+	if err := runBatch(strings.NewReader(input), &out, cfg, &encoders.Skip2Encoder{}, decs, 320, "M"); err != nil {
+		t.Fatalf("runBatch() failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("runBatch() wrote %d summaries, want 3 (blank line skipped)\n%s", len(lines), out.String())
+	}
+	if want := "line 1: 11 bytes, 2/2 decoders: "; !strings.HasPrefix(lines[0], want) {
+		t.Errorf("Summary 1 = %q, want prefix %q", lines[0], want)
+	}
+	if want := "line 3: 25 bytes, 2/2 decoders: "; !strings.HasPrefix(lines[1], want) {
+		t.Errorf("Summary 2 = %q, want prefix %q", lines[1], want)
+	}
+	if want := "line 4: 3000 bytes, encode failed (capacity)"; lines[2] != want {
+		t.Errorf("Summary 3 = %q, want %q", lines[2], want)
+	}
+}
+
+// flakyEncoder shifts its image by a pixel on every other encode, like a
+// library that randomizes its output.
+type flakyEncoder struct {
+	encoders.Skip2Encoder
+	calls int
+}
+
+func (e *flakyEncoder) Name() string { return "stub/flaky" }
+
+func (e *flakyEncoder) Encode(data []byte, opts encoders.EncodeOptions) (encoders.EncodeResult, error) {
+	e.calls++
+	if e.calls%2 == 0 {
+		opts.PixelSize++
+	}
+	return e.Skip2Encoder.Encode(data, opts)
+}
+
+func TestRunMatrix_CheckDeterminism(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.OutputDir = t.TempDir()
+	cfg.Quiet = true
+	cfg.CheckDeterminism = true
+
+	data := []byte("determinism")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-11b-256px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            256,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+	encs := []encoders.Encoder{&encoders.Skip2Encoder{}, &flakyEncoder{}}
+	decs := []decoders.Decoder{&decoders.GozxingDecoder{}}
+
+	var stdout, stderr bytes.Buffer
+	if err := runMatrix(cfg, encs, decs, cases, &stdout, &stderr); err != nil {
+		t.Fatalf("runMatrix() failed: %v", err)
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, "Non-deterministic encoder: stub/flaky") {
+		t.Errorf("output missing the flaky encoder warning:\n%s", out)
+	}
+	if strings.Contains(out, "Non-deterministic encoder: skip2/go-qrcode") {
+		t.Errorf("deterministic skip2 reported as non-deterministic:\n%s", out)
+	}
+}
+
+func TestRunMatrix_MaxCombinations(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.OutputDir = t.TempDir()
+	cfg.Quiet = true
+	cfg.MaxCombinations = 3
+
+	data := []byte("limit")
+	var cases []testdata.TestCase
+	for _, pixelSize := range []int{200, 240} {
+		cases = append(cases, testdata.TestCase{
+			Name:                 fmt.Sprintf("binary-5b-%dpx-ecM", pixelSize),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            pixelSize,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		})
+	}
+	encs := []encoders.Encoder{&encoders.Skip2Encoder{}}
+	decs := []decoders.Decoder{&decoders.GozxingDecoder{}, &decoders.GozxingDecoder{Binarizer: decoders.BinarizerGlobal}}
+
+	// 2 test cases × 1 encoder × 2 decoders = 4 tests
+	err := runMatrix(cfg, encs, decs, cases, io.Discard, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "4 combinations") {
+		t.Fatalf("runMatrix() error = %v, want the 4-test matrix rejected", err)
+	}
+
+	cfg.MaxCombinations = 4
+	if err := runMatrix(cfg, encs, decs, cases, io.Discard, io.Discard); err != nil {
+		t.Errorf("runMatrix() at the limit failed: %v", err)
+	}
+}
+
+func TestRunMatrix_SubdirPerRun(t *testing.T) {
+	base := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.OutputDir = base
+	cfg.SubdirPerRun = true
+	cfg.Quiet = true
+
+	data := []byte("per run")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-7b-256px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            256,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+	encs := []encoders.Encoder{&encoders.Skip2Encoder{}}
+	decs := []decoders.Decoder{&decoders.GozxingDecoder{}}
+
+	if err := runMatrix(cfg, encs, decs, cases, io.Discard, io.Discard); err != nil {
+		t.Fatalf("runMatrix() failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		t.Fatalf("ReadDir() failed: %v", err)
+	}
+	var runDirs []string
+	for _, e := range entries {
+		if e.IsDir() {
+			runDirs = append(runDirs, e.Name())
+		}
+	}
+	if len(runDirs) != 1 {
+		t.Fatalf("output has run directories %v, want one", runDirs)
+	}
+	if _, err := time.Parse(runDirLayout, runDirs[0]); err != nil {
+		t.Errorf("run directory %q is not a %s timestamp: %v", runDirs[0], runDirLayout, err)
+	}
+	if strings.Contains(runDirs[0], ":") {
+		t.Errorf("run directory %q contains a colon, which Windows does not allow", runDirs[0])
+	}
+
+	results, err := report.LoadResults(filepath.Join(base, runDirs[0]))
+	if err != nil {
+		t.Fatalf("LoadResults() failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("run directory has %d results, want 1", len(results))
+	}
+
+	target, err := os.Readlink(filepath.Join(base, latestLink))
+	if err != nil {
+		t.Fatalf("Readlink(latest) failed: %v", err)
+	}
+	if target != runDirs[0] {
+		t.Errorf("latest -> %q, want %q", target, runDirs[0])
+	}
+
+	// A later run moves the link
+	next, err := createRunDir(base, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("createRunDir() failed: %v", err)
+	}
+	if err := linkLatest(base, next); err != nil {
+		t.Fatalf("linkLatest() failed: %v", err)
+	}
+	if target, _ := os.Readlink(filepath.Join(base, latestLink)); target != filepath.Base(next) {
+		t.Errorf("latest -> %q after a second run, want %q", target, filepath.Base(next))
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+)
+
+// startProfiling starts CPU profiling when cfg.ProfileCPU is set.
+// The returned stop function ends CPU profiling and writes the heap
+// profile when cfg.ProfileMem is set. stop must be called once the run ends.
+func startProfiling(cfg *config.Config) (stop func() error, err error) {
+	var cpuFile *os.File
+	if cfg.ProfileCPU != "" {
+		cpuFile, err = os.Create(cfg.ProfileCPU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(cpuFile); err != nil {
+			cpuFile.Close()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+	}
+
+	stop = func() error {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			if err := cpuFile.Close(); err != nil {
+				return fmt.Errorf("failed to close CPU profile: %w", err)
+			}
+		}
+
+		if cfg.ProfileMem != "" {
+			return writeHeapProfile(cfg.ProfileMem)
+		}
+		return nil
+	}
+	return stop, nil
+}
+
+// writeHeapProfile writes a heap profile to path.
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create heap profile: %w", err)
+	}
+	defer f.Close()
+
+	// Collect garbage so the profile reflects live allocations
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return fmt.Errorf("failed to write heap profile: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// runDirLayout names per-run subdirectories by UTC start time in the ISO
+// 8601 basic format. Unlike RFC 3339 it has no colons, which Windows does
+// not allow in file names and shell scripts would need to quote.
+const runDirLayout = "20060102T150405Z"
+
+// latestLink is the symlink in the output directory that points at the
+// newest per-run subdirectory.
+const latestLink = "latest"
+
+// createRunDir creates the per-run subdirectory of base for a run started
+// at start, named by its UTC time in runDirLayout, and returns its path.
+func createRunDir(base string, start time.Time) (string, error) {
+	dir := filepath.Join(base, start.UTC().Format(runDirLayout))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create run directory: %w", err)
+	}
+	return dir, nil
+}
+
+// linkLatest points base/latest at runDir, replacing any previous link.
+// The link is relative so the output directory can be moved or archived,
+// and is swapped in with a rename so readers never see it missing.
+func linkLatest(base, runDir string) error {
+	link := filepath.Join(base, latestLink)
+	tmp := link + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(filepath.Base(runDir), tmp); err != nil {
+		return fmt.Errorf("failed to link %s: %w", link, err)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to link %s: %w", link, err)
+	}
+	return nil
+}
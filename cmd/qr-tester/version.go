@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+)
+
+// VersionInfo is the machine-readable output of -version-json.
+type VersionInfo struct {
+	Version    string   `json:"version"`
+	GoVersion  string   `json:"goVersion"`
+	BuildTime  string   `json:"buildTime"` // VCS commit time, "unknown" outside a VCS build
+	CGOEnabled bool     `json:"cgoEnabled"`
+	Encoders   []string `json:"encoders"`
+	Decoders   []string `json:"decoders"`
+}
+
+// newVersionInfo reports the build and the encoders/decoders available
+// under cfg (skip flags apply).
+func newVersionInfo(cfg *config.Config) VersionInfo {
+	info := VersionInfo{
+		Version:    version,
+		GoVersion:  runtime.Version(),
+		BuildTime:  "unknown",
+		CGOEnabled: decoders.CGOEnabled(),
+		Encoders:   []string{},
+		Decoders:   []string{},
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			if setting.Key == "vcs.time" {
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+
+	for _, enc := range encoders.GetAvailableEncoders(cfg) {
+		info.Encoders = append(info.Encoders, enc.Name())
+	}
+	for _, dec := range decoders.GetAvailableDecoders(cfg) {
+		info.Decoders = append(info.Decoders, dec.Name())
+	}
+
+	return info
+}
+
+// writeVersionJSON writes the version info for cfg to w as indented JSON.
+func writeVersionJSON(w io.Writer, cfg *config.Config) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(newVersionInfo(cfg))
+}
@@ -4,12 +4,21 @@ package config
 import (
 	"flag"
 	"fmt"
+	"io"
+	"math"
+	"os"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/makiuchi-d/gozxing/common"
 )
 
+// warningOutput receives warnings about flag values that were corrected.
+var warningOutput io.Writer = os.Stderr
+
 // Config holds all test parameters and execution options.
 // Use DefaultConfig() for sensible defaults or RegisterFlags() for CLI configuration.
 type Config struct {
@@ -34,11 +43,19 @@ type Config struct {
 	// Default: 10s
 	Timeout time.Duration
 
+	// MaxDuration caps the wall-clock time of the whole run, for CI time
+	// budgets. Once it has elapsed the runner starts no new encoder and
+	// test case group and returns the results so far; the matrix order is
+	// fixed, so the skipped tail is the same on every run. Unlike Timeout,
+	// which bounds a single decode, this bounds the run.
+	// Default: 0 (no limit)
+	MaxDuration time.Duration
+
 	// MaxWorkers limits concurrent worker goroutines.
 	// Default: runtime.NumCPU()
 	MaxWorkers int
 
-	// SkipCGO excludes CGO-based decoders from testing.
+	// SkipCGO excludes CGO-based encoders and decoders from testing.
 	// Default: false
 	SkipCGO bool
 
@@ -50,6 +67,13 @@ type Config struct {
 	// Default: ./results
 	OutputDir string
 
+	// SubdirPerRun writes each run's reports to a new OutputDir/<timestamp>
+	// subdirectory, named by the UTC start time without colons (e.g.
+	// 20260117T093000Z), and points an OutputDir/latest symlink at it, so
+	// runs stay separate for diff and baseline comparisons.
+	// Default: false
+	SubdirPerRun bool
+
 	// Timestamp adds timestamp to output filenames.
 	// Default: true
 	Timestamp bool
@@ -60,23 +84,324 @@ type Config struct {
 	// - comprehensive: 576 tests (12 data sizes × 12 pixel sizes × 4 content types)
 	// Default: "standard"
 	TestMode string
+
+	// MicroQR requests Micro QR codes (M1-M4) instead of standard QR codes.
+	// Encoders whose libraries cannot emit Micro QR report an encode error.
+	// Default: false
+	MicroQR bool
+
+	// ForceVersion fixes the QR version (1-40) for every encode instead of
+	// automatic selection. Encoders that cannot fix the version report an encode error.
+	// Default: 0 (automatic)
+	ForceVersion int
+
+	// MaxCombinations caps the total number of tests in a run. A matrix
+	// larger than this is rejected instead of silently starting a run that
+	// could take hours. Zero disables the limit.
+	//
+	// The test count depends on the encoders, decoders, and test cases in
+	// use, which the Config does not know, so Validate only checks that the
+	// value is not negative. Every entry point that runs the matrix must
+	// call CheckCombinations with the runner's TotalTests before starting.
+	// Default: 100000
+	MaxCombinations int
+
+	// DisableAntialiasing resizes encoded images with nearest-neighbor sampling
+	// instead of bilinear interpolation, keeping module edges crisp. Setting
+	// it false opts into smoothing, which blends module edges to gray.
+	// Only applies to encoders whose output does not already match the
+	// requested pixel size (yeqown without PixelSizeIncludesQuietZone);
+	// skip2, boombuler, and gozxing render exact sizes.
+	// Default: true
+	DisableAntialiasing bool
+
+	// PixelSizeIncludesQuietZone makes every pixel size the total image,
+	// symbol plus a 4-module quiet zone, whatever the encoder library's own
+	// convention (see encoders.EncodeOptions.PixelSizeIncludesQuietZone), so
+	// module pixel sizes and the fractional classification mean the same
+	// thing for every encoder. False keeps each library's native sizing.
+	// Default: true
+	PixelSizeIncludesQuietZone bool
+
+	// SQLitePath, when set, also writes results to a SQLite database at this path.
+	// Requires a build with the sqlite tag.
+	// Default: "" (disabled)
+	SQLitePath string
+
+	// ProfileCPU, when set, writes a pprof CPU profile of the run to this path.
+	// Default: "" (disabled)
+	ProfileCPU string
+
+	// ProfileMem, when set, writes a pprof heap profile to this path after the run.
+	// Default: "" (disabled)
+	ProfileMem string
+
+	// RetainImages keeps each encoded image in the result matrix so other
+	// decoders can be run against it after the run without re-encoding.
+	// Default: false
+	RetainImages bool
+
+	// MaxRetainedImages caps the images kept by RetainImages; the oldest are
+	// evicted first. 0 means unlimited.
+	// Default: 1000
+	MaxRetainedImages int
+
+	// SaveFailedImages, when set, writes the image behind every failed test
+	// to this directory, with a magnified copy showing the module grid
+	// (<test case>-overlay.png). Implies RetainImages, so MaxRetainedImages
+	// still caps how many are available.
+	// Default: "" (disabled)
+	SaveFailedImages string
+
+	// CrossValidate re-decodes images that every decoder failed with a
+	// reference decoder (zbarimg if installed, else goquirc with CGO) and
+	// flags the failures as blind spots when the reference succeeds.
+	// Default: false
+	CrossValidate bool
+
+	// CheckDeterminism encodes the first test case twice with every encoder
+	// before the run and warns about encoders whose images differ, since
+	// image hashes and timing comparisons from them need care.
+	// Default: false
+	CheckDeterminism bool
+
+	// DropUnhealthyDecoders removes decoders that fail the pre-flight check
+	// (decoding a trivial known-good QR code) from the run instead of only
+	// warning about them, so a broken decoder does not fill the matrix
+	// with failures.
+	// Default: false
+	DropUnhealthyDecoders bool
+
+	// Quiet suppresses per-test progress lines and the end-of-run summary.
+	// Default: false
+	Quiet bool
+
+	// ExcludeArchivedFromRate keeps results from archived decoders (e.g., goqr)
+	// in the reports but leaves them out of the overall and best-combination
+	// rates, so their data mismatches do not drag down headline numbers.
+	// Default: false
+	ExcludeArchivedFromRate bool
+
+	// SeedSweep repeats each binary test case across this many random seeds
+	// and reports whether each combination passes for every seed or only
+	// some. Values of 0 or 1 disable the sweep.
+	// Default: 0
+	SeedSweep int
+
+	// VectorsPath loads test cases from a JSON test-vector file instead of
+	// the generated matrix (see testdata.LoadVectors). Empty uses TestMode.
+	// Default: ""
+	VectorsPath string
+
+	// Single runs exactly one test case, described by a spec such as
+	// "dataSize=550,pixelSize=440,content=alphanumeric,level=M" (see
+	// testdata.ParseSingleSpec), across every selected encoder and decoder.
+	// For reproducing one cell of the matrix. Cannot be combined with
+	// VectorsPath.
+	// Default: ""
+	Single string
+
+	// MinModulePx and MaxModulePx keep only test cases whose predicted module
+	// pixel size (testdata.PredictModulePixelSize) falls in this band, e.g.
+	// 4.0-6.0 where fractional-module failures cluster. 0 leaves a bound open.
+	// Default: 0 (no filtering)
+	MinModulePx float64
+	MaxModulePx float64
+
+	// ModuleSizeFilter keeps only test cases whose predicted module pixel
+	// size is an integer ("integer") or fractional ("fractional"), isolating
+	// the fractional-module hypothesis. "all" keeps every case.
+	// Default: "all"
+	ModuleSizeFilter string
+
+	// GozxingCharset is the character set the gozxing decoder uses for
+	// byte-mode data without an ECI header. gozxing otherwise guesses, which
+	// can garble UTF-8 text. Must be a QR ECI charset name.
+	// Default: "UTF-8"
+	GozxingCharset string
+
+	// CenterOcclusion blanks a white square covering this fraction of the
+	// image area (0.0-1.0) from the center of every encoded image before
+	// decoding, like a logo on a branded QR code. Run once per EC level and
+	// fraction to see how much occlusion each decoder tolerates.
+	// Default: 0 (off)
+	CenterOcclusion float64
+
+	// CorruptFraction inverts this fraction (0.0-1.0) of every encoded
+	// image's modules before decoding, leaving the finder and timing
+	// patterns intact, to measure how much damage each EC level recovers.
+	// Standard QR codes only; Micro QR is decoded uncorrupted.
+	// Default: 0 (off)
+	CorruptFraction float64
+
+	// CorruptSeed seeds the choice of modules CorruptFraction inverts, so a
+	// run can be repeated exactly.
+	// Default: 1
+	CorruptSeed int64
+
+	// EmbedScale pastes every encoded image off-center onto a white canvas
+	// this many times its width and height before decoding, like a QR code
+	// captured inside a larger photo. Single-symbol cases only.
+	// Default: 0 (off)
+	EmbedScale int
+
+	// LocateCrop finds the QR code with the finder-pattern search and crops
+	// the image to the symbol and its quiet zone before decoding, so
+	// decoders that expect a tightly framed symbol can read embedded ones.
+	// When no symbol is found the whole image is decoded.
+	// Default: false
+	LocateCrop bool
+
+	// ColorModel converts every image to one color model before decoding,
+	// so decoders are compared on identical input: "gray" (*image.Gray),
+	// "rgba" (*image.RGBA), or "as-is" to pass on whatever the encoder,
+	// resizing, or occlusion produced. Encoders emit different models
+	// (gozxing Gray, skip2 paletted from its PNG), and binarizers can
+	// treat them differently.
+	// Default: "as-is"
+	ColorModel string
+
+	// TrimDecodedPadding wraps every decoder with decoders.WithPaddingTrim,
+	// which drops trailing NUL and whitespace bytes past the expected payload
+	// length. Output is only trimmed when the result is exactly that length,
+	// so padding-only mismatches pass while real corruption still fails.
+	// Default: false
+	TrimDecodedPadding bool
+
+	// GozxingBinarizers runs gozxing once per binarizer, as the separate
+	// decoders makiuchi-d/gozxing-hybrid and makiuchi-d/gozxing-global,
+	// instead of once with its default hybrid binarizer. Binarization
+	// strongly affects fractional-module decoding.
+	// Default: false
+	GozxingBinarizers bool
+
+	// StructuredAppend adds the rsc.io/qr structured-append encoder, which
+	// splits data beyond version 40 capacity over a sequence of symbols.
+	// Decoders that cannot reassemble a sequence skip those cases.
+	// Default: false
+	StructuredAppend bool
+
+	// CompressOutput gzips the JSON result files (.json.gz) to shrink CI
+	// artifacts. generate-site reads both forms.
+	// Default: false
+	CompressOutput bool
+
+	// BenchDuration, when set, skips the matrix and instead measures each
+	// encoder's and decoder's throughput (codes/sec) on a fixed image for
+	// this long per library.
+	// Default: 0 (off)
+	BenchDuration time.Duration
+
+	// QuietZoneModules is the quiet zone assumed when computing module pixel
+	// size for standard QR codes. The runner prefers the margin measured
+	// from the encoded image and falls back to this value when it cannot
+	// be measured. Micro QR always uses 2.
+	// Default: 4
+	QuietZoneModules int
+
+	// FailuresOnly writes only failed results to the JSON reports, with no
+	// file for an encoder or decoder that never failed.
+	// Default: false
+	FailuresOnly bool
+
+	// Repeat runs the whole matrix this many times with the same data and
+	// reports combinations whose outcome varied between runs as flaky.
+	// Unlike SeedSweep, the payloads do not change.
+	// Default: 1
+	Repeat int
+
+	// CrossEncoder names a decoder to compare across encoders after the
+	// run: its success on each encoder's images of the payloads every
+	// encoder encoded, flagging encoders whose output it cannot read.
+	// Default: "" (off)
+	CrossEncoder string
+
+	// Formats lists the result files to write to OutputDir: "json" for the
+	// per-encoder and per-decoder files, "jsonl" for a single results.jsonl
+	// with one result per line, "markdown" for summary.md and one report
+	// per encoder/decoder pair.
+	// Default: ["json"]
+	Formats []string
+
+	// OutputStdout writes the markdown report to stdout as a single document
+	// covering every pair instead of files in OutputDir, for piping a quick
+	// check. Only the markdown format is affected; other formats still go to
+	// OutputDir. Implies Quiet and moves status lines to stderr, so stdout
+	// carries only the report.
+	// Default: false
+	OutputStdout bool
+
+	// MetricsFile, when set, receives per encoder/decoder pair gauges in
+	// the Prometheus text format after the run (qr_success_rate,
+	// qr_encode_ms, qr_decode_ms), for a node_exporter textfile collector.
+	// Default: "" (off)
+	MetricsFile string
 }
 
+// maxEmbedScale caps EmbedScale so canvases stay a reasonable size: a 560px
+// image at 8x is already 4480px square.
+const maxEmbedScale = 8
+
 // DefaultConfig returns a Config with sensible defaults.
 // Focuses on pixel size matrix testing (500-800 bytes, 320-560px).
 func DefaultConfig() *Config {
 	return &Config{
-		DataSizes:    []int{500, 550, 600, 650, 750, 800},
-		PixelSizes:   []int{320, 400, 440, 450, 460, 480, 512, 560},
-		ErrorLevels:  []string{"L", "M", "Q", "H"},
-		Parallel:     true,
-		Timeout:      10 * time.Second,
-		MaxWorkers:   runtime.NumCPU(),
-		SkipCGO:      false,
-		SkipArchived: false,
-		OutputDir:    "./results",
-		Timestamp:    true,
-		TestMode:     "standard",
+		DataSizes:           []int{500, 550, 600, 650, 750, 800},
+		PixelSizes:          []int{320, 400, 440, 450, 460, 480, 512, 560},
+		ErrorLevels:         []string{"L", "M", "Q", "H"},
+		Parallel:            true,
+		Timeout:             10 * time.Second,
+		MaxDuration:         0,
+		MaxWorkers:          runtime.NumCPU(),
+		SkipCGO:             false,
+		SkipArchived:        false,
+		OutputDir:           "./results",
+		SubdirPerRun:        false,
+		Timestamp:           true,
+		TestMode:            "standard",
+		MicroQR:             false,
+		ForceVersion:        0,
+		MaxCombinations:     100000,
+		DisableAntialiasing: true,
+		SQLitePath:          "",
+		ProfileCPU:          "",
+		ProfileMem:          "",
+		RetainImages:        false,
+		MaxRetainedImages:   1000,
+		SaveFailedImages:    "",
+		CrossValidate:       false,
+		CheckDeterminism:    false,
+		Quiet:               false,
+
+		PixelSizeIncludesQuietZone: true,
+
+		ExcludeArchivedFromRate: false,
+		DropUnhealthyDecoders:   false,
+		SeedSweep:               0,
+		VectorsPath:             "",
+		Single:                  "",
+		MinModulePx:             0,
+		MaxModulePx:             0,
+		ModuleSizeFilter:        "all",
+		GozxingCharset:          "UTF-8",
+		GozxingBinarizers:       false,
+		StructuredAppend:        false,
+		TrimDecodedPadding:      false,
+		CenterOcclusion:         0,
+		CorruptFraction:         0,
+		CorruptSeed:             1,
+		EmbedScale:              0,
+		LocateCrop:              false,
+		ColorModel:              "as-is",
+		CompressOutput:          false,
+		BenchDuration:           0,
+		QuietZoneModules:        4,
+		FailuresOnly:            false,
+		Repeat:                  1,
+		CrossEncoder:            "",
+		Formats:                 []string{"json"},
+		OutputStdout:            false,
+		MetricsFile:             "",
 	}
 }
 
@@ -94,24 +419,70 @@ func DefaultConfig() *Config {
 //	if err := cfg.Validate(); err != nil {
 //	    log.Fatal(err)
 //	}
+//	// ... build the runner ...
+//	if err := cfg.CheckCombinations(runner.TotalTests()); err != nil {
+//	    log.Fatal(err)
+//	}
 func RegisterFlags(fs *flag.FlagSet) (*Config, func() error) {
 	cfg := DefaultConfig()
 
 	var dataSizesStr string
 	var pixelSizesStr string
 	var errorLevelsStr string
+	var formatsStr string
 
 	fs.StringVar(&dataSizesStr, "data-sizes", "", "Comma-separated data sizes in bytes (default: 500,550,600,650,750,800)")
-	fs.StringVar(&pixelSizesStr, "pixel-sizes", "", "Comma-separated pixel dimensions (default: 320,400,440,450,460,480,512,560)")
+	fs.StringVar(&pixelSizesStr, "pixel-sizes", "", "Comma-separated pixel dimensions, or physical sizes like 2cm@300dpi (default: 320,400,440,450,460,480,512,560)")
 	fs.StringVar(&errorLevelsStr, "error-levels", "", "Comma-separated error correction levels: L,M,Q,H (default: L,M,Q,H)")
 	fs.BoolVar(&cfg.Parallel, "parallel", true, "Run tests in parallel")
 	fs.DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "Timeout per decoder operation")
+	fs.DurationVar(&cfg.MaxDuration, "max-duration", 0, "Stop starting new tests once the run has taken this long, e.g. 10m, and report the rest as skipped (0 = no limit)")
 	fs.IntVar(&cfg.MaxWorkers, "max-workers", runtime.NumCPU(), "Maximum concurrent workers")
-	fs.BoolVar(&cfg.SkipCGO, "skip-cgo", false, "Skip CGO-based decoders")
+	fs.BoolVar(&cfg.SkipCGO, "skip-cgo", false, "Skip CGO-based encoders and decoders")
 	fs.BoolVar(&cfg.SkipArchived, "skip-archived", false, "Skip archived libraries")
 	fs.StringVar(&cfg.OutputDir, "output", "./results", "Output directory for results")
+	fs.BoolVar(&cfg.SubdirPerRun, "output-dir-per-run", false, "Write each run to a new timestamped subdirectory of the output directory and point output/latest at it")
 	fs.BoolVar(&cfg.Timestamp, "timestamp", true, "Add timestamp to output filenames")
 	fs.StringVar(&cfg.TestMode, "test-mode", "standard", "Test matrix mode: standard (96 tests) or comprehensive (576 tests)")
+	fs.BoolVar(&cfg.MicroQR, "micro-qr", false, "Encode Micro QR codes (M1-M4) instead of standard QR codes")
+	fs.IntVar(&cfg.ForceVersion, "force-version", 0, "Force QR version 1-40 instead of automatic selection (0 = auto)")
+	fs.IntVar(&cfg.MaxCombinations, "max-combinations", 100000, "Maximum total tests allowed in a run (0 = unlimited)")
+	fs.BoolVar(&cfg.DisableAntialiasing, "disable-antialiasing", true, "Resize encoded images with nearest-neighbor scaling; false opts into bilinear smoothing")
+	fs.BoolVar(&cfg.PixelSizeIncludesQuietZone, "pixel-size-includes-quiet-zone", true, "Treat pixel size as the total image including a 4-module quiet zone for every encoder; false keeps each library's native sizing")
+	fs.BoolVar(&cfg.FailuresOnly, "failures-only", false, "Write only failed results to the JSON reports")
+	fs.BoolVar(&cfg.CompressOutput, "compress-output", false, "Gzip the JSON result files (.json.gz)")
+	fs.StringVar(&cfg.SQLitePath, "sqlite", "", "Also write results to this SQLite database (requires -tags sqlite build)")
+	fs.StringVar(&cfg.SaveFailedImages, "save-failed-images", "", "Write failed tests' images, plus magnified module grid overlays, to this directory")
+	fs.BoolVar(&cfg.CrossValidate, "cross-validate", false, "Re-decode images every decoder failed with a reference decoder (zbarimg or goquirc)")
+	fs.BoolVar(&cfg.CheckDeterminism, "check-determinism", false, "Encode the first test case twice per encoder and warn about encoders whose images differ")
+	fs.BoolVar(&cfg.DropUnhealthyDecoders, "drop-unhealthy-decoders", false, "Drop decoders that cannot read a trivial QR code in the pre-flight check instead of only warning")
+	fs.BoolVar(&cfg.Quiet, "quiet", false, "Suppress per-test progress and the end-of-run summary")
+	fs.StringVar(&cfg.VectorsPath, "vectors", "", "Load test cases from a JSON test-vector file instead of the generated matrix")
+	fs.StringVar(&cfg.Single, "single", "", "Run one test case, e.g. \"dataSize=550,pixelSize=440,content=alphanumeric,level=M\", instead of the generated matrix")
+	fs.Float64Var(&cfg.MinModulePx, "min-module-px", 0, "Only run test cases predicted to render at least this many pixels per module (0 = no minimum)")
+	fs.Float64Var(&cfg.MaxModulePx, "max-module-px", 0, "Only run test cases predicted to render at most this many pixels per module (0 = no maximum)")
+	fs.StringVar(&cfg.ModuleSizeFilter, "module-size", "all", "Only run test cases predicted to render integer or fractional module sizes: all, integer, or fractional")
+	fs.IntVar(&cfg.QuietZoneModules, "quiet-zone", 4, "Quiet zone modules assumed for module pixel size when the encoded margin cannot be measured")
+	fs.StringVar(&cfg.GozxingCharset, "gozxing-charset", "UTF-8", "Character set gozxing decodes byte-mode data with (e.g., UTF-8, ISO-8859-1, Shift_JIS)")
+	fs.BoolVar(&cfg.GozxingBinarizers, "gozxing-binarizers", false, "Run gozxing as two decoders, one per binarizer (hybrid and global histogram)")
+	fs.BoolVar(&cfg.StructuredAppend, "structured-append", false, "Add an encoder that splits data beyond version 40 capacity into a structured-append sequence")
+	fs.Float64Var(&cfg.CenterOcclusion, "center-occlusion", 0, "Blank this fraction of the image area (0.0-1.0) from the center before decoding, like a logo (0 = off)")
+	fs.Float64Var(&cfg.CorruptFraction, "corrupt-fraction", 0, "Invert this fraction (0.0-1.0) of each image's modules before decoding to test error correction (0 = off)")
+	fs.Int64Var(&cfg.CorruptSeed, "corrupt-seed", 1, "Seed for choosing the modules -corrupt-fraction inverts")
+	fs.IntVar(&cfg.EmbedScale, "embed-scale", 0, "Paste each image off-center onto a white canvas this many times its size before decoding (0 = off)")
+	fs.BoolVar(&cfg.LocateCrop, "locate-crop", false, "Locate the QR code by its finder patterns and crop to it before decoding")
+	fs.StringVar(&cfg.ColorModel, "color-model", "as-is", "Convert every image to this color model before decoding: gray, rgba, or as-is")
+	fs.BoolVar(&cfg.TrimDecodedPadding, "trim-decoded-padding", false, "Strip trailing NUL and whitespace padding from decoded data when the rest matches the expected length")
+	fs.IntVar(&cfg.Repeat, "repeat", 1, "Run the whole matrix N times and report combinations whose outcome varied as flaky")
+	fs.StringVar(&formatsStr, "formats", "", "Comma-separated result formats to write: json, jsonl, markdown (default: json)")
+	fs.BoolVar(&cfg.OutputStdout, "output-stdout", false, "Write the markdown report to stdout as one document instead of files (implies -quiet)")
+	fs.StringVar(&cfg.MetricsFile, "metrics-file", "", "Write Prometheus text-format gauges per encoder/decoder pair to this file after the run")
+	fs.StringVar(&cfg.CrossEncoder, "cross-encoder", "", "Report one decoder's success on each encoder's output for identical payloads")
+	fs.IntVar(&cfg.SeedSweep, "seed-sweep", 0, "Repeat binary test cases across N random seeds and report unstable combinations (0 or 1 = off)")
+	fs.BoolVar(&cfg.ExcludeArchivedFromRate, "exclude-archived-from-rate", false, "Leave archived decoders out of the overall and best-combination rates")
+	fs.DurationVar(&cfg.BenchDuration, "bench-duration", 0, "Measure each library's throughput (codes/sec) for this long instead of running the matrix (0 = off)")
+	fs.StringVar(&cfg.ProfileCPU, "cpuprofile", "", "Write a pprof CPU profile of the run to this file")
+	fs.StringVar(&cfg.ProfileMem, "memprofile", "", "Write a pprof heap profile to this file after the run")
 
 	// Return parse function to be called after fs.Parse()
 	parse := func() error {
@@ -120,21 +491,25 @@ func RegisterFlags(fs *flag.FlagSet) (*Config, func() error) {
 			if err != nil {
 				return fmt.Errorf("invalid data-sizes: %w", err)
 			}
-			cfg.DataSizes = sizes
+			cfg.DataSizes = normalizeSizes("data-sizes", sizes)
 		}
 
 		if pixelSizesStr != "" {
-			sizes, err := parseIntSlice(pixelSizesStr)
+			sizes, err := parsePixelSizes(pixelSizesStr)
 			if err != nil {
 				return fmt.Errorf("invalid pixel-sizes: %w", err)
 			}
-			cfg.PixelSizes = sizes
+			cfg.PixelSizes = normalizeSizes("pixel-sizes", sizes)
 		}
 
 		if errorLevelsStr != "" {
 			cfg.ErrorLevels = parseStringSlice(errorLevelsStr)
 		}
 
+		if formatsStr != "" {
+			cfg.Formats = parseStringSlice(formatsStr)
+		}
+
 		return nil
 	}
 
@@ -142,7 +517,8 @@ func RegisterFlags(fs *flag.FlagSet) (*Config, func() error) {
 }
 
 // Validate checks that the configuration is valid.
-// Returns an error if any values are invalid.
+// Returns an error if any values are invalid. The MaxCombinations limit
+// needs the runner's test count and is checked by CheckCombinations.
 func (c *Config) Validate() error {
 	if len(c.DataSizes) == 0 {
 		return fmt.Errorf("data-sizes cannot be empty")
@@ -151,6 +527,12 @@ func (c *Config) Validate() error {
 	if len(c.PixelSizes) == 0 {
 		return fmt.Errorf("pixel-sizes cannot be empty")
 	}
+	// A physical size at a low resolution, like 0.1mm@100dpi, rounds to 0px
+	for _, size := range c.PixelSizes {
+		if size <= 0 {
+			return fmt.Errorf("pixel-sizes must be greater than 0, got %d", size)
+		}
+	}
 
 	if len(c.ErrorLevels) == 0 {
 		return fmt.Errorf("error-levels cannot be empty")
@@ -163,22 +545,119 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if len(c.Formats) == 0 {
+		return fmt.Errorf("formats cannot be empty")
+	}
+	for _, format := range c.Formats {
+		if !isValidFormat(format) {
+			return fmt.Errorf("invalid format %q: must be json, jsonl, or markdown", format)
+		}
+	}
+	if c.OutputStdout && !c.HasFormat("markdown") {
+		return fmt.Errorf("output-stdout requires the markdown format (-formats markdown)")
+	}
+
+	if c.Single != "" && c.VectorsPath != "" {
+		return fmt.Errorf("single and vectors cannot be combined")
+	}
+
 	if c.Timeout <= 0 {
 		return fmt.Errorf("timeout must be greater than 0, got %v", c.Timeout)
 	}
 
+	if c.MaxDuration < 0 {
+		return fmt.Errorf("max-duration must be 0 or greater, got %v", c.MaxDuration)
+	}
+
 	if c.MaxWorkers <= 0 {
 		return fmt.Errorf("max-workers must be greater than 0, got %d", c.MaxWorkers)
 	}
 
+	if c.ForceVersion < 0 || c.ForceVersion > 40 {
+		return fmt.Errorf("force-version must be between 0 and 40, got %d", c.ForceVersion)
+	}
+
 	// Validate test mode
 	if c.TestMode != "standard" && c.TestMode != "comprehensive" {
 		return fmt.Errorf("invalid test-mode %q: must be 'standard' or 'comprehensive'", c.TestMode)
 	}
 
+	if c.MaxCombinations < 0 {
+		return fmt.Errorf("max-combinations must be 0 or greater, got %d", c.MaxCombinations)
+	}
+
+	if c.MaxRetainedImages < 0 {
+		return fmt.Errorf("max retained images must be 0 or greater, got %d", c.MaxRetainedImages)
+	}
+
+	if c.MinModulePx < 0 || c.MaxModulePx < 0 {
+		return fmt.Errorf("module pixel size bounds must be 0 or greater, got %.2f-%.2f", c.MinModulePx, c.MaxModulePx)
+	}
+	if c.MaxModulePx > 0 && c.MinModulePx > c.MaxModulePx {
+		return fmt.Errorf("min-module-px %.2f exceeds max-module-px %.2f", c.MinModulePx, c.MaxModulePx)
+	}
+
+	switch c.ModuleSizeFilter {
+	case "all", "integer", "fractional":
+	default:
+		return fmt.Errorf("invalid module-size %q: must be 'all', 'integer', or 'fractional'", c.ModuleSizeFilter)
+	}
+
+	switch c.ColorModel {
+	case "gray", "rgba", "as-is":
+	default:
+		return fmt.Errorf("invalid color-model %q: must be 'gray', 'rgba', or 'as-is'", c.ColorModel)
+	}
+
+	if _, ok := common.GetCharacterSetECIByName(c.GozxingCharset); !ok {
+		return fmt.Errorf("invalid gozxing-charset %q: must be a QR ECI character set such as UTF-8 or ISO-8859-1", c.GozxingCharset)
+	}
+
+	if c.CenterOcclusion < 0 || c.CenterOcclusion >= 1 {
+		return fmt.Errorf("center-occlusion must be at least 0 and below 1, got %.2f", c.CenterOcclusion)
+	}
+
+	if c.CorruptFraction < 0 || c.CorruptFraction >= 1 {
+		return fmt.Errorf("corrupt-fraction must be at least 0 and below 1, got %.2f", c.CorruptFraction)
+	}
+
+	if c.EmbedScale != 0 && (c.EmbedScale < 2 || c.EmbedScale > maxEmbedScale) {
+		return fmt.Errorf("embed-scale must be 0 (off) or between 2 and %d, got %d", maxEmbedScale, c.EmbedScale)
+	}
+
+	if c.QuietZoneModules < 0 {
+		return fmt.Errorf("quiet-zone must be 0 or greater, got %d", c.QuietZoneModules)
+	}
+
+	if c.BenchDuration < 0 {
+		return fmt.Errorf("bench-duration must be 0 or greater, got %v", c.BenchDuration)
+	}
+
+	if c.Repeat < 1 {
+		return fmt.Errorf("repeat must be 1 or greater, got %d", c.Repeat)
+	}
+
+	if c.SeedSweep < 0 {
+		return fmt.Errorf("seed-sweep must be 0 or greater, got %d", c.SeedSweep)
+	}
+
 	return nil
 }
 
+// CheckCombinations returns an error if total exceeds MaxCombinations.
+// A MaxCombinations of zero disables the check. Callers pass the runner's
+// test count, which reflects the libraries and test cases actually in use,
+// after Validate has passed; Validate alone does not enforce the limit.
+func (c *Config) CheckCombinations(total int) error {
+	if c.MaxCombinations == 0 || total <= c.MaxCombinations {
+		return nil
+	}
+
+	return fmt.Errorf("test matrix has %d combinations, exceeding max-combinations %d: "+
+		"narrow -data-sizes, -pixel-sizes, or -error-levels, skip libraries with -skip-cgo or -skip-archived, "+
+		"or raise -max-combinations (0 disables the limit)", total, c.MaxCombinations)
+}
+
 // parseIntSlice parses a comma-separated string into a slice of integers.
 func parseIntSlice(s string) ([]int, error) {
 	parts := strings.Split(s, ",")
@@ -201,6 +680,90 @@ func parseIntSlice(s string) ([]int, error) {
 	return result, nil
 }
 
+// unitsPerInch maps the physical length units accepted in -pixel-sizes to
+// how many of them make an inch.
+var unitsPerInch = map[string]float64{
+	"in": 1,
+	"cm": 2.54,
+	"mm": 25.4,
+}
+
+// parsePixelSizes parses a comma-separated list of pixel sizes. Each entry
+// is either a pixel count or a physical size at a print resolution, such as
+// "2cm@300dpi" or "1in@150dpi", which is converted to the nearest whole
+// pixel count.
+func parsePixelSizes(s string) ([]int, error) {
+	parts := strings.Split(s, ",")
+	result := make([]int, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if !strings.Contains(part, "@") {
+			val, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer %q: %w", part, err)
+			}
+			result = append(result, val)
+			continue
+		}
+
+		val, err := parsePhysicalSize(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, val)
+	}
+
+	return result, nil
+}
+
+// parsePhysicalSize converts a "<length><unit>@<n>dpi" size to pixels.
+func parsePhysicalSize(s string) (int, error) {
+	length, dpi, _ := strings.Cut(strings.ToLower(s), "@")
+
+	dpiValue, err := strconv.ParseFloat(strings.TrimSuffix(dpi, "dpi"), 64)
+	if err != nil || !strings.HasSuffix(dpi, "dpi") || dpiValue <= 0 {
+		return 0, fmt.Errorf("invalid size %q: resolution must be a positive number followed by dpi, like 300dpi", s)
+	}
+
+	for unit, perInch := range unitsPerInch {
+		if !strings.HasSuffix(length, unit) {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSuffix(length, unit), 64)
+		if err != nil || value <= 0 {
+			return 0, fmt.Errorf("invalid size %q: length must be a positive number", s)
+		}
+		return int(math.Round(value / perInch * dpiValue)), nil
+	}
+	return 0, fmt.Errorf("invalid size %q: length unit must be in, cm, or mm", s)
+}
+
+// normalizeSizes sorts sizes ascending and removes duplicates, which would
+// otherwise run the same matrix cells twice and double-count them. Removed
+// duplicates are reported on warningOutput, naming the flag.
+func normalizeSizes(flagName string, sizes []int) []int {
+	sorted := append([]int(nil), sizes...)
+	sort.Ints(sorted)
+
+	unique := make([]int, 0, len(sorted))
+	for _, size := range sorted {
+		if len(unique) > 0 && size == unique[len(unique)-1] {
+			continue
+		}
+		unique = append(unique, size)
+	}
+
+	if removed := len(sizes) - len(unique); removed > 0 {
+		fmt.Fprintf(warningOutput, "Warning: -%s: removed %d duplicate size(s), using %v\n", flagName, removed, unique)
+	}
+	return unique
+}
+
 // parseStringSlice parses a comma-separated string into a slice of strings.
 func parseStringSlice(s string) []string {
 	parts := strings.Split(s, ",")
@@ -216,6 +779,26 @@ func parseStringSlice(s string) []string {
 	return result
 }
 
+// isValidFormat checks if the result format is one qr-tester can write.
+func isValidFormat(format string) bool {
+	switch format {
+	case "json", "jsonl", "markdown":
+		return true
+	default:
+		return false
+	}
+}
+
+// HasFormat reports whether format is one of the configured Formats.
+func (c *Config) HasFormat(format string) bool {
+	for _, f := range c.Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
 // isValidErrorLevel checks if the error correction level is valid.
 func isValidErrorLevel(level string) bool {
 	switch level {
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"bytes"
 	"flag"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -55,9 +57,25 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("OutputDir = %q, want %q", cfg.OutputDir, "./results")
 	}
 
+	if cfg.SubdirPerRun {
+		t.Error("SubdirPerRun should be false by default")
+	}
+
+	if cfg.DropUnhealthyDecoders {
+		t.Error("DropUnhealthyDecoders should be false by default")
+	}
+
 	if !cfg.Timestamp {
 		t.Error("Timestamp should be true by default")
 	}
+
+	if !cfg.PixelSizeIncludesQuietZone {
+		t.Error("PixelSizeIncludesQuietZone should be true by default")
+	}
+
+	if !cfg.DisableAntialiasing {
+		t.Error("DisableAntialiasing should be true by default")
+	}
 }
 
 func TestValidate_ValidConfig(t *testing.T) {
@@ -89,6 +107,43 @@ func TestValidate_EmptyPixelSizes(t *testing.T) {
 	}
 }
 
+func TestValidate_NonPositivePixelSizes(t *testing.T) {
+	tests := []struct {
+		name  string
+		sizes []int
+	}{
+		{"zero", []int{0, 320}},
+		{"negative", []int{320, -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.PixelSizes = tt.sizes
+
+			if err := cfg.Validate(); err == nil {
+				t.Errorf("Validate() with PixelSizes %v error = nil, want error", tt.sizes)
+			}
+		})
+	}
+}
+
+func TestValidate_PhysicalSizeRoundingToZero(t *testing.T) {
+	sizes, err := parsePixelSizes("0.1mm@100dpi")
+	if err != nil {
+		t.Fatalf("parsePixelSizes() error = %v, want nil", err)
+	}
+	if want := []int{0}; !intSliceEqual(sizes, want) {
+		t.Fatalf("parsePixelSizes() = %v, want %v", sizes, want)
+	}
+
+	cfg := DefaultConfig()
+	cfg.PixelSizes = sizes
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for a size that rounds to 0px")
+	}
+}
+
 func TestValidate_EmptyErrorLevels(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.ErrorLevels = []string{}
@@ -124,6 +179,70 @@ func TestValidate_InvalidErrorLevel(t *testing.T) {
 	}
 }
 
+func TestValidate_Formats(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats []string
+		wantErr bool
+	}{
+		{"json", []string{"json"}, false},
+		{"json and jsonl", []string{"json", "jsonl"}, false},
+		{"markdown", []string{"markdown"}, false},
+		{"empty", []string{}, true},
+		{"unknown", []string{"csv"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Formats = tt.formats
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_OutputStdout(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats []string
+		wantErr bool
+	}{
+		{"markdown", []string{"markdown"}, false},
+		{"json and markdown", []string{"json", "markdown"}, false},
+		{"without markdown", []string{"json"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Formats = tt.formats
+			cfg.OutputStdout = true
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Single(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Single = "dataSize=550,pixelSize=440,content=alphanumeric,level=M"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with single error = %v", err)
+	}
+
+	cfg.VectorsPath = "vectors.json"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() with single and vectors should fail")
+	}
+}
+
 func TestValidate_ValidErrorLevels(t *testing.T) {
 	validLevels := []string{"L", "M", "Q", "H"}
 
@@ -180,6 +299,94 @@ func TestValidate_NegativeMaxWorkers(t *testing.T) {
 	}
 }
 
+func TestValidate_MaxDuration(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, d := range []time.Duration{0, time.Minute} {
+		cfg.MaxDuration = d
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with max-duration %v failed: %v", d, err)
+		}
+	}
+
+	cfg.MaxDuration = -time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for negative MaxDuration")
+	}
+}
+
+func TestValidate_ForceVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		wantErr bool
+	}{
+		{"auto", 0, false},
+		{"version 1", 1, false},
+		{"version 40", 40, false},
+		{"negative", -1, true},
+		{"version 41", 41, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.ForceVersion = tt.version
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckCombinations(t *testing.T) {
+	cfg := DefaultConfig()
+
+	err := cfg.CheckCombinations(640000)
+	if err == nil {
+		t.Fatal("CheckCombinations() should fail when the matrix exceeds max-combinations")
+	}
+
+	for _, want := range []string{"640000", "100000", "-data-sizes", "-max-combinations"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("CheckCombinations() error %q should mention %q", err, want)
+		}
+	}
+
+	if err := cfg.CheckCombinations(100000); err != nil {
+		t.Errorf("CheckCombinations() at the limit failed: %v", err)
+	}
+
+	// Disabling the limit allows any run
+	cfg.MaxCombinations = 0
+	if err := cfg.CheckCombinations(640000); err != nil {
+		t.Errorf("CheckCombinations() with limit disabled failed: %v", err)
+	}
+
+	cfg.MaxCombinations = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail with negative max-combinations")
+	}
+}
+
+func TestValidate_ThenCheckCombinations(t *testing.T) {
+	// The limit needs the test count, so a valid config can still be
+	// rejected once the runner has counted its tests
+	cfg := DefaultConfig()
+	cfg.MaxCombinations = 10
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() failed: %v", err)
+	}
+	if err := cfg.CheckCombinations(11); err == nil {
+		t.Error("CheckCombinations() after Validate() should fail when the matrix exceeds max-combinations")
+	}
+	if err := cfg.CheckCombinations(10); err != nil {
+		t.Errorf("CheckCombinations() after Validate() at the limit failed: %v", err)
+	}
+}
+
 func TestRegisterFlags_Defaults(t *testing.T) {
 	fs := flag.NewFlagSet("test", flag.ContinueOnError)
 	cfg, parse := RegisterFlags(fs)
@@ -279,6 +486,69 @@ func TestRegisterFlags_InvalidDataSizes(t *testing.T) {
 	}
 }
 
+func TestRegisterFlags_DuplicateSizes(t *testing.T) {
+	var warnings bytes.Buffer
+	saved := warningOutput
+	warningOutput = &warnings
+	defer func() { warningOutput = saved }()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg, parse := RegisterFlags(fs)
+
+	if err := fs.Parse([]string{"-pixel-sizes", "440,320,440", "-data-sizes", "300,100"}); err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+	if err := parse(); err != nil {
+		t.Fatalf("parse() error = %v, want nil", err)
+	}
+
+	if want := []int{320, 440}; !intSliceEqual(cfg.PixelSizes, want) {
+		t.Errorf("PixelSizes = %v, want %v", cfg.PixelSizes, want)
+	}
+	if want := []int{100, 300}; !intSliceEqual(cfg.DataSizes, want) {
+		t.Errorf("DataSizes = %v, want %v (sorted)", cfg.DataSizes, want)
+	}
+
+	if !strings.Contains(warnings.String(), "-pixel-sizes: removed 1 duplicate") {
+		t.Errorf("Warnings = %q, want the removed pixel size reported", warnings.String())
+	}
+	if strings.Contains(warnings.String(), "-data-sizes") {
+		t.Errorf("Warnings = %q, want no warning for data sizes without duplicates", warnings.String())
+	}
+}
+
+func TestParsePixelSizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{"inches", "1in@300dpi", []int{300}, false},
+		{"centimeters rounded", "2cm@300dpi", []int{236}, false},
+		{"millimeters", "25.4mm@150dpi", []int{150}, false},
+		{"mixed with pixels", "400, 1in@150dpi", []int{400, 150}, false},
+		{"uppercase", "1IN@300DPI", []int{300}, false},
+		{"missing dpi suffix", "1in@300", nil, true},
+		{"unknown unit", "1ft@300dpi", nil, true},
+		{"zero length", "0cm@300dpi", nil, true},
+		{"zero dpi", "1in@0dpi", nil, true},
+		{"not a number", "abc", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePixelSizes(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePixelSizes(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !intSliceEqual(got, tt.want) {
+				t.Errorf("parsePixelSizes(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseIntSlice(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -411,3 +681,174 @@ func stringSliceEqual(a, b []string) bool {
 	}
 	return true
 }
+
+func TestValidate_MaxRetainedImages(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxRetainedImages = -1
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail with negative MaxRetainedImages")
+	}
+}
+
+func TestValidate_SeedSweep(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SeedSweep = -1
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail with negative SeedSweep")
+	}
+}
+
+func TestValidate_ModulePxBand(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MinModulePx = 4.0
+	cfg.MaxModulePx = 6.0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with a 4.0-6.0 band failed: %v", err)
+	}
+
+	cfg.MinModulePx = 6.5
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail when min-module-px exceeds max-module-px")
+	}
+
+	cfg.MinModulePx = -1
+	cfg.MaxModulePx = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail with a negative min-module-px")
+	}
+}
+
+func TestValidate_ModuleSizeFilter(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.ModuleSizeFilter != "all" {
+		t.Errorf("Default ModuleSizeFilter = %q, want %q", cfg.ModuleSizeFilter, "all")
+	}
+
+	for _, filter := range []string{"all", "integer", "fractional"} {
+		cfg.ModuleSizeFilter = filter
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with module-size %q failed: %v", filter, err)
+		}
+	}
+
+	cfg.ModuleSizeFilter = "odd"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail with an unknown module-size")
+	}
+}
+
+func TestValidate_CorruptFraction(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, fraction := range []float64{0, 0.02, 0.5} {
+		cfg.CorruptFraction = fraction
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with corrupt-fraction %.2f failed: %v", fraction, err)
+		}
+	}
+
+	for _, fraction := range []float64{-0.01, 1} {
+		cfg.CorruptFraction = fraction
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() should fail with corrupt-fraction %.2f", fraction)
+		}
+	}
+}
+
+func TestValidate_EmbedScale(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, scale := range []int{0, 2, 8} {
+		cfg.EmbedScale = scale
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with embed-scale %d failed: %v", scale, err)
+		}
+	}
+
+	for _, scale := range []int{-1, 1, 9} {
+		cfg.EmbedScale = scale
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() should fail with embed-scale %d", scale)
+		}
+	}
+}
+
+func TestValidate_ColorModel(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, model := range []string{"gray", "rgba", "as-is"} {
+		cfg.ColorModel = model
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with color-model %q failed: %v", model, err)
+		}
+	}
+
+	for _, model := range []string{"", "paletted", "GRAY"} {
+		cfg.ColorModel = model
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() should fail with color-model %q", model)
+		}
+	}
+}
+
+func TestValidate_CenterOcclusion(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, fraction := range []float64{0, 0.1, 0.3} {
+		cfg.CenterOcclusion = fraction
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with center-occlusion %.1f failed: %v", fraction, err)
+		}
+	}
+
+	for _, fraction := range []float64{-0.1, 1} {
+		cfg.CenterOcclusion = fraction
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() should fail with center-occlusion %.1f", fraction)
+		}
+	}
+}
+
+func TestValidate_GozxingCharset(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.GozxingCharset != "UTF-8" {
+		t.Errorf("Default GozxingCharset = %q, want %q", cfg.GozxingCharset, "UTF-8")
+	}
+
+	cfg.GozxingCharset = "Shift_JIS"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with Shift_JIS failed: %v", err)
+	}
+
+	cfg.GozxingCharset = "klingon"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail with an unknown charset")
+	}
+}
+
+func TestValidate_QuietZoneModules(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.QuietZoneModules != 4 {
+		t.Errorf("Default QuietZoneModules = %d, want 4", cfg.QuietZoneModules)
+	}
+
+	cfg.QuietZoneModules = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with a zero quiet zone failed: %v", err)
+	}
+
+	cfg.QuietZoneModules = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail with a negative quiet zone")
+	}
+}
+
+func TestValidate_Repeat(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Repeat != 1 {
+		t.Errorf("Default Repeat = %d, want 1", cfg.Repeat)
+	}
+
+	cfg.Repeat = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should fail with repeat 0")
+	}
+}
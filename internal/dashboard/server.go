@@ -0,0 +1,380 @@
+// Package dashboard renders an HTML dashboard for saved qr-tester results:
+// the combination matrix, per-pair details, and failure charts, built with
+// html/template so no Hugo toolchain is needed.
+package dashboard
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/13rac1/qr-library-test/pkg/report"
+)
+
+// server renders the dashboard from results loaded at startup.
+type server struct {
+	results  []report.RawTestResult
+	analysis report.Analysis
+}
+
+// NewServer returns a handler serving the dashboard index at / and
+// per-pair details at /pair?encoder=...&decoder=...
+func NewServer(results []report.RawTestResult) http.Handler {
+	s := &server{
+		results:  results,
+		analysis: report.Analyze(results),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /pair", s.handlePair)
+	return mux
+}
+
+// gridRow is one encoder row of the combination matrix.
+type gridRow struct {
+	Encoder string
+	Cells   []*report.CombinationRate // one per decoder, nil when untested
+}
+
+// failureBar is one bar of a failures-by-type chart.
+type failureBar struct {
+	Type  string
+	Count int
+	Width float64 // percent of the largest bar
+}
+
+type indexPage struct {
+	Analysis    report.Analysis
+	OverallRate float64
+	Decoders    []string
+	Rows        []gridRow
+	Failures    []failureBar
+}
+
+// sizeCell aggregates one data size × pixel size cell of a pair's matrix.
+// A cell holds every EC level, seed, and repeat tested at that size.
+type sizeCell struct {
+	Tests            int // effective tests, excluding skips
+	Successes        int
+	CapacitySkips    int
+	UnsupportedSkips int
+	UndersizedSkips  int
+	Rate             float64
+}
+
+// sizeRow is one content type and data size row of a pair's matrix.
+type sizeRow struct {
+	ContentType string
+	DataSize    int
+	Cells       []*sizeCell // one per pixel size, nil when untested
+}
+
+// sizeMatrix is a pair's data size × pixel size matrix. Rows are keyed by
+// content type as well as data size, so a pair tested with several content
+// types at the same sizes keeps one row per content type.
+type sizeMatrix struct {
+	ShowContent bool // more than one content type is present
+	PixelSizes  []int
+	Rows        []sizeRow
+}
+
+type pairPage struct {
+	Pair     report.CombinationRate
+	Matrix   sizeMatrix
+	Failures []failureBar
+	Failed   []report.RawTestResult
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	a := s.analysis
+
+	page := indexPage{
+		Analysis:    a,
+		OverallRate: rate(a.Successes, a.EffectiveTests),
+		Failures:    failureBars(a.FailuresByType),
+	}
+	page.Decoders, page.Rows = combinationGrid(a.Combinations)
+
+	s.render(w, "index", page)
+}
+
+func (s *server) handlePair(w http.ResponseWriter, r *http.Request) {
+	encoder := r.URL.Query().Get("encoder")
+	decoder := r.URL.Query().Get("decoder")
+
+	var page pairPage
+	found := false
+	for _, c := range s.analysis.Combinations {
+		if c.Encoder == encoder && c.Decoder == decoder {
+			page.Pair = c
+			found = true
+			break
+		}
+	}
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	byType := make(map[string]int)
+	for _, res := range s.results {
+		if res.Encoder != encoder || res.Decoder != decoder || res.Success || res.IsSkipped() {
+			continue
+		}
+		byType[res.ErrorType]++
+		page.Failed = append(page.Failed, res)
+	}
+	page.Failures = failureBars(byType)
+	page.Matrix = build2DMatrix(s.results, encoder, decoder)
+
+	s.render(w, "pair", page)
+}
+
+// render executes the named template, reporting template errors as 500s.
+func (s *server) render(w http.ResponseWriter, name string, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ExecuteTemplate(w, name, data); err != nil {
+		log.Printf("qr-serve: rendering %s: %v", name, err)
+		http.Error(w, "template error", http.StatusInternalServerError)
+	}
+}
+
+// combinationGrid lays out combinations as encoder rows by decoder columns,
+// both sorted by name.
+func combinationGrid(combinations []report.CombinationRate) ([]string, []gridRow) {
+	encSet := make(map[string]bool)
+	decSet := make(map[string]bool)
+	byPair := make(map[[2]string]*report.CombinationRate)
+	for i := range combinations {
+		c := &combinations[i]
+		encSet[c.Encoder] = true
+		decSet[c.Decoder] = true
+		byPair[[2]string{c.Encoder, c.Decoder}] = c
+	}
+
+	decoders := sortedNames(decSet)
+	var rows []gridRow
+	for _, enc := range sortedNames(encSet) {
+		row := gridRow{Encoder: enc}
+		for _, dec := range decoders {
+			row.Cells = append(row.Cells, byPair[[2]string{enc, dec}])
+		}
+		rows = append(rows, row)
+	}
+	return decoders, rows
+}
+
+// build2DMatrix lays out one pair's results as data size rows by pixel size
+// columns. Rows are sorted by content type, then data size.
+func build2DMatrix(results []report.RawTestResult, encoder, decoder string) sizeMatrix {
+	type rowKey struct {
+		contentType string
+		dataSize    int
+	}
+	type cellKey struct {
+		row       rowKey
+		pixelSize int
+	}
+
+	cells := make(map[cellKey]*sizeCell)
+	rowSet := make(map[rowKey]bool)
+	pixelSet := make(map[int]bool)
+	contentSet := make(map[string]bool)
+	for _, res := range results {
+		if res.Encoder != encoder || res.Decoder != decoder {
+			continue
+		}
+
+		rk := rowKey{res.ContentType, res.DataSize}
+		ck := cellKey{rk, res.PixelSize}
+		rowSet[rk] = true
+		pixelSet[res.PixelSize] = true
+		contentSet[res.ContentType] = true
+
+		c := cells[ck]
+		if c == nil {
+			c = &sizeCell{}
+			cells[ck] = c
+		}
+		switch {
+		case res.IsUnsupported():
+			c.UnsupportedSkips++
+		case res.IsUndersized():
+			c.UndersizedSkips++
+		case res.IsCapacityExceeded:
+			c.CapacitySkips++
+		case res.Success:
+			c.Tests++
+			c.Successes++
+		default:
+			c.Tests++
+		}
+	}
+
+	m := sizeMatrix{ShowContent: len(contentSet) > 1}
+	for size := range pixelSet {
+		m.PixelSizes = append(m.PixelSizes, size)
+	}
+	sort.Ints(m.PixelSizes)
+
+	rows := make([]rowKey, 0, len(rowSet))
+	for rk := range rowSet {
+		rows = append(rows, rk)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].contentType != rows[j].contentType {
+			return rows[i].contentType < rows[j].contentType
+		}
+		return rows[i].dataSize < rows[j].dataSize
+	})
+
+	for _, rk := range rows {
+		row := sizeRow{ContentType: rk.contentType, DataSize: rk.dataSize}
+		for _, size := range m.PixelSizes {
+			c := cells[cellKey{rk, size}]
+			if c != nil {
+				c.Rate = rate(c.Successes, c.Tests)
+			}
+			row.Cells = append(row.Cells, c)
+		}
+		m.Rows = append(m.Rows, row)
+	}
+	return m
+}
+
+// failureBars converts failure counts into chart bars, largest first.
+func failureBars(byType map[string]int) []failureBar {
+	var bars []failureBar
+	largest := 0
+	for t, n := range byType {
+		bars = append(bars, failureBar{Type: t, Count: n})
+		if n > largest {
+			largest = n
+		}
+	}
+
+	sort.Slice(bars, func(i, j int) bool {
+		if bars[i].Count != bars[j].Count {
+			return bars[i].Count > bars[j].Count
+		}
+		return bars[i].Type < bars[j].Type
+	})
+
+	for i := range bars {
+		bars[i].Width = float64(bars[i].Count) / float64(largest) * 100
+	}
+	return bars
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for n := range set {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rate returns n as a percentage of total, or 0 when total is 0.
+func rate(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
+
+// rateClass maps a success rate to the same bands as the markdown grid.
+func rateClass(rate float64) string {
+	switch {
+	case rate >= 95:
+		return "good"
+	case rate >= 70:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"rateClass": rateClass,
+}).Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.}} - QR Compatibility</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+td.good { background: #c8e6c9; }
+td.fair { background: #fff59d; }
+td.poor { background: #ffcdd2; }
+.bar { background: #e57373; height: 1em; }
+.chart td { border: none; }
+.chart td.track { width: 20em; }
+</style>
+</head>
+<body>
+<p><a href="/">Overview</a></p>
+{{end}}
+
+{{define "footer"}}</body>
+</html>
+{{end}}
+
+{{define "failures"}}{{if .}}
+<table class="chart">
+{{range .}}<tr><td>{{.Type}}</td><td class="track"><div class="bar" style="width: {{printf "%.1f" .Width}}%"></div></td><td>{{.Count}}</td></tr>
+{{end}}</table>
+{{else}}<p>No failures.</p>
+{{end}}{{end}}
+
+{{define "index"}}{{template "header" "Overview"}}
+<h1>QR Compatibility Results</h1>
+<ul>
+<li>Total tests: {{.Analysis.TotalTests}}</li>
+<li>Effective tests: {{.Analysis.EffectiveTests}} ({{.Analysis.CapacitySkips}} capacity skips{{if .Analysis.UnsupportedSkips}}, {{.Analysis.UnsupportedSkips}} unsupported{{end}}{{if .Analysis.UndersizedSkips}}, {{.Analysis.UndersizedSkips}} undersized{{end}})</li>
+<li>Overall success rate: {{printf "%.1f" .OverallRate}}% ({{.Analysis.Successes}}/{{.Analysis.EffectiveTests}})</li>
+{{with .Analysis.Best}}{{if .EffectiveTests}}<li>Best: {{.Encoder}} → {{.Decoder}} ({{printf "%.1f" .SuccessRate}}%)</li>{{end}}{{end}}
+{{with .Analysis.Worst}}{{if .EffectiveTests}}<li>Worst: {{.Encoder}} → {{.Decoder}} ({{printf "%.1f" .SuccessRate}}%)</li>{{end}}{{end}}
+</ul>
+
+<h2>Combination Matrix</h2>
+<table>
+<tr><th>Encoder \ Decoder</th>{{range .Decoders}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr><th>{{.Encoder}}</th>{{range .Cells}}{{if .}}<td class="{{rateClass .SuccessRate}}"><a href="/pair?encoder={{.Encoder}}&amp;decoder={{.Decoder}}">{{printf "%.1f" .SuccessRate}}%</a></td>{{else}}<td>–</td>{{end}}{{end}}</tr>
+{{end}}</table>
+
+<h2>Failures by Type</h2>
+{{template "failures" .Failures}}
+{{template "footer"}}{{end}}
+
+{{define "pair"}}{{template "header" printf "%s → %s" .Pair.Encoder .Pair.Decoder}}
+<h1>{{.Pair.Encoder}} → {{.Pair.Decoder}}</h1>
+<ul>
+<li>Success rate: {{printf "%.1f" .Pair.SuccessRate}}% ({{.Pair.Successes}}/{{.Pair.EffectiveTests}})</li>
+<li>Capacity skips: {{.Pair.CapacitySkips}}</li>
+{{if .Pair.UnsupportedSkips}}<li>Unsupported skips: {{.Pair.UnsupportedSkips}}</li>
+{{end}}{{if .Pair.UndersizedSkips}}<li>Undersized skips: {{.Pair.UndersizedSkips}}</li>
+{{end}}</ul>
+
+{{with .Matrix}}{{if .Rows}}<h2>Data Size × Pixel Size</h2>
+<table>
+<tr>{{if .ShowContent}}<th>Content</th>{{end}}<th>Data size</th>{{range .PixelSizes}}<th>{{.}}px</th>{{end}}</tr>
+{{range .Rows}}<tr>{{if $.Matrix.ShowContent}}<th>{{.ContentType}}</th>{{end}}<th>{{.DataSize}}</th>{{range .Cells}}{{if not .}}<td>–</td>{{else if .Tests}}<td class="{{rateClass .Rate}}">{{.Successes}}/{{.Tests}}</td>{{else if .CapacitySkips}}<td>capacity</td>{{else if .UnsupportedSkips}}<td>unsupported</td>{{else}}<td>undersized</td>{{end}}{{end}}</tr>
+{{end}}</table>
+{{end}}{{end}}
+<h2>Failures by Type</h2>
+{{template "failures" .Failures}}
+
+{{if .Failed}}<h2>Failed Tests</h2>
+<table>
+<tr><th>Data size</th><th>Pixel size</th><th>Content</th><th>EC</th><th>Error type</th><th>Error</th></tr>
+{{range .Failed}}<tr><td>{{.DataSize}}</td><td>{{.PixelSize}}px</td><td>{{.ContentType}}</td><td>{{.ErrorCorrectionLevel}}</td><td>{{.ErrorType}}</td><td>{{.ErrorMsg}}</td></tr>
+{{end}}</table>
+{{end}}
+{{template "footer"}}{{end}}
+`))
@@ -0,0 +1,135 @@
+package dashboard
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/pkg/report"
+)
+
+func testResults() []report.RawTestResult {
+	return []report.RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", Success: true},
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", Success: true},
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", Success: true},
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", PixelSize: 300, ErrorType: "decode", ErrorMsg: "tuotoo: no finder patterns"},
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", ErrorType: "capacity", IsCapacityExceeded: true},
+	}
+}
+
+func get(t *testing.T, rawURL string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(rawURL)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Reading %s failed: %v", rawURL, err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestServer_IndexAndPair(t *testing.T) {
+	srv := httptest.NewServer(NewServer(testResults()))
+	defer srv.Close()
+
+	status, body := get(t, srv.URL+"/")
+	if status != http.StatusOK {
+		t.Fatalf("GET / status = %d, want 200", status)
+	}
+	for _, want := range []string{
+		"Overall success rate: 75.0% (3/4)",
+		"skip2/go-qrcode → tuotoo/qrcode (50.0%)",
+		"/pair?encoder=skip2%2fgo-qrcode&amp;decoder=tuotoo%2fqrcode",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Index missing %q\n\nBody:\n%s", want, body)
+		}
+	}
+
+	q := url.Values{"encoder": {"skip2/go-qrcode"}, "decoder": {"tuotoo/qrcode"}}
+	status, body = get(t, srv.URL+"/pair?"+q.Encode())
+	if status != http.StatusOK {
+		t.Fatalf("GET /pair status = %d, want 200", status)
+	}
+	for _, want := range []string{
+		"Success rate: 50.0% (1/2)",
+		"tuotoo: no finder patterns",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Pair page missing %q\n\nBody:\n%s", want, body)
+		}
+	}
+}
+
+func TestServer_UnknownPair(t *testing.T) {
+	srv := httptest.NewServer(NewServer(testResults()))
+	defer srv.Close()
+
+	if status, _ := get(t, srv.URL+"/pair?encoder=nope&decoder=nope"); status != http.StatusNotFound {
+		t.Errorf("GET unknown pair status = %d, want 404", status)
+	}
+	if status, _ := get(t, srv.URL+"/missing"); status != http.StatusNotFound {
+		t.Errorf("GET /missing status = %d, want 404", status)
+	}
+}
+
+func TestBuild2DMatrix_MixedContentTypes(t *testing.T) {
+	results := []report.RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, ContentType: "alphanumeric", Success: true},
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, ContentType: "utf8", ErrorType: "decode"},
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", DataSize: 100, PixelSize: 300, ContentType: "utf8", Success: true},
+	}
+
+	m := build2DMatrix(results, "skip2/go-qrcode", "tuotoo/qrcode")
+	if !m.ShowContent {
+		t.Error("ShowContent = false, want true with two content types")
+	}
+	if len(m.PixelSizes) != 1 || m.PixelSizes[0] != 300 {
+		t.Errorf("PixelSizes = %v, want [300]", m.PixelSizes)
+	}
+	if len(m.Rows) != 2 {
+		t.Fatalf("Rows = %+v, want one per content type", m.Rows)
+	}
+
+	want := []struct {
+		contentType string
+		successes   int
+	}{
+		{"alphanumeric", 1},
+		{"utf8", 0},
+	}
+	for i, w := range want {
+		row := m.Rows[i]
+		if row.ContentType != w.contentType || row.DataSize != 100 {
+			t.Errorf("Row %d = %s/%d, want %s/100", i, row.ContentType, row.DataSize, w.contentType)
+		}
+		c := row.Cells[0]
+		if c == nil || c.Tests != 1 || c.Successes != w.successes {
+			t.Errorf("Row %d cell = %+v, want %d/1", i, c, w.successes)
+		}
+	}
+
+	srv := httptest.NewServer(NewServer(results))
+	defer srv.Close()
+
+	q := url.Values{"encoder": {"skip2/go-qrcode"}, "decoder": {"tuotoo/qrcode"}}
+	_, body := get(t, srv.URL+"/pair?"+q.Encode())
+	for _, want := range []string{
+		"<th>Content</th>",
+		`<th>alphanumeric</th><th>100</th><td class="good">1/1</td>`,
+		`<th>utf8</th><th>100</th><td class="poor">0/1</td>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Pair page missing %q\n\nBody:\n%s", want, body)
+		}
+	}
+}
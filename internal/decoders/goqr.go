@@ -20,9 +20,24 @@ func (d *GoqrDecoder) Name() string {
 	return "liyue201/goqr"
 }
 
+// IsArchived reports true: liyue201/goqr was archived in July 2021.
+func (d *GoqrDecoder) IsArchived() bool {
+	return true
+}
+
 // Decode extracts data from a QR code image.
+// goqr returns every symbol it finds; only the first is returned here.
 // This archived library may fail on valid QR codes.
 func (d *GoqrDecoder) Decode(img image.Image) ([]byte, error) {
+	payloads, err := d.DecodeAll(img)
+	if err != nil {
+		return nil, err
+	}
+	return payloads[0], nil
+}
+
+// DecodeAll extracts data from every QR code in the image.
+func (d *GoqrDecoder) DecodeAll(img image.Image) ([][]byte, error) {
 	if img == nil {
 		return nil, fmt.Errorf("goqr: image is nil")
 	}
@@ -37,12 +52,13 @@ func (d *GoqrDecoder) Decode(img image.Image) ([]byte, error) {
 		return nil, fmt.Errorf("goqr: no QR code found")
 	}
 
-	// Extract data from the first QR code
-	// Note: goqr returns multiple codes if present, we take the first
-	payload := qrCodes[0].Payload
-	if payload == nil {
-		return nil, fmt.Errorf("goqr: QR code payload is nil")
+	payloads := make([][]byte, 0, len(qrCodes))
+	for i, code := range qrCodes {
+		if code.Payload == nil {
+			return nil, fmt.Errorf("goqr: QR code %d payload is nil", i)
+		}
+		payloads = append(payloads, code.Payload)
 	}
 
-	return payload, nil
+	return payloads, nil
 }
@@ -3,9 +3,12 @@ package decoders
 import (
 	"bytes"
 	"image"
+	"sort"
 	"testing"
 
 	"github.com/skip2/go-qrcode"
+
+	"github.com/13rac1/qr-library-test/internal/testdata"
 )
 
 func TestGoqrDecoder_Decode_Success(t *testing.T) {
@@ -155,3 +158,46 @@ func TestGoqrDecoder_Decode_DifferentPixelSizes(t *testing.T) {
 		})
 	}
 }
+
+func TestGoqrDecoder_DecodeAll_TwoSymbols(t *testing.T) {
+	dec := &GoqrDecoder{}
+	payloads := []string{"first symbol", "second symbol"}
+
+	imgs := make([]image.Image, len(payloads))
+	for i, payload := range payloads {
+		pngBytes, err := qrcode.Encode(payload, qrcode.Medium, 256)
+		if err != nil {
+			t.Fatalf("Failed to generate test QR code: %v", err)
+		}
+		imgs[i], _, err = image.Decode(bytes.NewReader(pngBytes))
+		if err != nil {
+			t.Fatalf("Failed to decode PNG: %v", err)
+		}
+	}
+
+	img := testdata.CompositeSideBySide(imgs...)
+
+	decoded, err := dec.DecodeAll(img)
+	if err != nil {
+		t.Fatalf("DecodeAll() failed: %v", err)
+	}
+
+	got := make([]string, len(decoded))
+	for i, data := range decoded {
+		got[i] = string(data)
+	}
+	sort.Strings(got)
+
+	if len(got) != 2 || got[0] != payloads[0] || got[1] != payloads[1] {
+		t.Errorf("DecodeAll() = %q, want %q", got, payloads)
+	}
+}
+
+func TestGoqrDecoder_DecodeAll_NilImage(t *testing.T) {
+	dec := &GoqrDecoder{}
+
+	_, err := dec.DecodeAll(nil)
+	if err == nil {
+		t.Error("DecodeAll() with nil image should fail")
+	}
+}
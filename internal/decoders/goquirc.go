@@ -31,6 +31,9 @@ func (d *GoquircDecoder) Name() string {
 //
 // The goquirc library uses the Quirc C library for decoding, which may handle
 // fractional module sizes differently than pure Go implementations.
+//
+// Quirc finds every symbol in the image; only the first is returned here.
+// Use DecodeAll for images that contain more than one QR code.
 func (d *GoquircDecoder) Decode(img image.Image) (data []byte, err error) {
 	// Recover from panics in the goquirc library
 	defer func() {
@@ -59,3 +62,38 @@ func (d *GoquircDecoder) Decode(img image.Image) (data []byte, err error) {
 
 	return result, nil
 }
+
+// DecodeAll extracts data from every QR code in the image using the goquirc library.
+// Payloads are in Quirc's detection order, which is the same for the same
+// image, so the first payload is the one Decode returns.
+func (d *GoquircDecoder) DecodeAll(img image.Image) (payloads [][]byte, err error) {
+	// Recover from panics in the goquirc library
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("goquirc: panic during decode: %v", r)
+		}
+	}()
+
+	if img == nil {
+		return nil, fmt.Errorf("goquirc: image is nil")
+	}
+
+	decoder := goquirc.New()
+	defer decoder.Destroy()
+
+	datas, decodeErr := decoder.Decode(img)
+	if decodeErr != nil {
+		return nil, fmt.Errorf("goquirc: decode failed: %w", decodeErr)
+	}
+
+	if len(datas) == 0 {
+		return nil, fmt.Errorf("goquirc: no data decoded from QR code")
+	}
+
+	payloads = make([][]byte, len(datas))
+	for i, data := range datas {
+		payloads[i] = append([]byte(nil), data.Payload[:data.PayloadLen]...)
+	}
+
+	return payloads, nil
+}
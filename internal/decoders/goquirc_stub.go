@@ -24,3 +24,8 @@ func (d *GoquircDecoder) Name() string {
 func (d *GoquircDecoder) Decode(img image.Image) ([]byte, error) {
 	return nil, fmt.Errorf("goquirc: decoder not available (CGO not enabled)")
 }
+
+// DecodeAll always returns an error when CGO is not available.
+func (d *GoquircDecoder) DecodeAll(img image.Image) ([][]byte, error) {
+	return nil, fmt.Errorf("goquirc: decoder not available (CGO not enabled)")
+}
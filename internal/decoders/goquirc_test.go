@@ -6,9 +6,12 @@ package decoders
 import (
 	"bytes"
 	"image"
+	"sort"
 	"testing"
 
 	"github.com/skip2/go-qrcode"
+
+	"github.com/13rac1/qr-library-test/internal/testdata"
 )
 
 func TestGoquircDecoder_Decode_Success(t *testing.T) {
@@ -214,3 +217,57 @@ func TestGoquircDecoder_Decode_ErrorCorrectionLevels(t *testing.T) {
 		})
 	}
 }
+
+func TestGoquircDecoder_DecodeAll_TwoSymbols(t *testing.T) {
+	dec := &GoquircDecoder{}
+	payloads := []string{"first symbol", "second symbol"}
+
+	imgs := make([]image.Image, len(payloads))
+	for i, payload := range payloads {
+		pngBytes, err := qrcode.Encode(payload, qrcode.Medium, 256)
+		if err != nil {
+			t.Fatalf("Failed to generate test QR code: %v", err)
+		}
+		imgs[i], _, err = image.Decode(bytes.NewReader(pngBytes))
+		if err != nil {
+			t.Fatalf("Failed to decode PNG: %v", err)
+		}
+	}
+
+	img := testdata.CompositeSideBySide(imgs...)
+
+	decoded, err := dec.DecodeAll(img)
+	if err != nil {
+		t.Fatalf("DecodeAll() failed: %v", err)
+	}
+
+	got := make([]string, len(decoded))
+	for i, data := range decoded {
+		got[i] = string(data)
+	}
+
+	// Same image, same order
+	again, err := dec.DecodeAll(img)
+	if err != nil {
+		t.Fatalf("Second DecodeAll() failed: %v", err)
+	}
+	for i := range again {
+		if i >= len(got) || string(again[i]) != got[i] {
+			t.Fatalf("Second DecodeAll() order = %q, want %q", again, got)
+		}
+	}
+
+	// Decode returns the first payload
+	first, err := dec.Decode(img)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if len(got) > 0 && string(first) != got[0] {
+		t.Errorf("Decode() = %q, want first DecodeAll() payload %q", first, got[0])
+	}
+
+	sort.Strings(got)
+	if len(got) != 2 || got[0] != payloads[0] || got[1] != payloads[1] {
+		t.Errorf("DecodeAll() = %q, want %q", got, payloads)
+	}
+}
@@ -9,38 +9,108 @@ import (
 	"github.com/makiuchi-d/gozxing/qrcode"
 )
 
+// Binarizers GozxingDecoder can convert images to black and white with.
+const (
+	// BinarizerHybrid thresholds each 8×8 block against its neighborhood
+	// (gozxing's HybridBinarizer, the NewBinaryBitmapFromImage default).
+	BinarizerHybrid = "hybrid"
+
+	// BinarizerGlobal thresholds each row against one luminance histogram
+	// (gozxing's GlobalHistogramBinarizer).
+	BinarizerGlobal = "global"
+)
+
+// DefaultCharacterSet is the charset GozxingDecoder decodes byte-mode
+// segments with when none is set.
+const DefaultCharacterSet = "UTF-8"
+
 // GozxingDecoder wraps github.com/makiuchi-d/gozxing for QR code decoding.
 // This decoder has known issues with fractional module pixel sizes,
 // particularly when paired with the skip2/go-qrcode encoder.
-type GozxingDecoder struct{}
+type GozxingDecoder struct {
+	// CharacterSet is passed as gozxing's CHARACTER_SET hint for byte-mode
+	// segments without an ECI header (e.g., "UTF-8", "ISO-8859-1", "Shift_JIS").
+	// Without the hint gozxing guesses, and can turn UTF-8 text into garbled
+	// output that looks like a data mismatch. Empty uses DefaultCharacterSet.
+	CharacterSet string
+
+	// Binarizer selects how the image is thresholded before detection:
+	// BinarizerHybrid or BinarizerGlobal. Binarization strongly affects
+	// fractional-module decoding, where module edges are anti-aliased gray.
+	// Empty uses gozxing's default (hybrid) and the plain decoder name;
+	// set, the name carries the binarizer so both can run side by side.
+	Binarizer string
+}
 
 // Name returns the decoder identifier.
 func (d *GozxingDecoder) Name() string {
+	if d.Binarizer != "" {
+		return "makiuchi-d/gozxing-" + d.Binarizer
+	}
 	return "makiuchi-d/gozxing"
 }
 
 // Decode extracts data from a QR code image.
 // The gozxing library requires conversion to BinaryBitmap for decoding.
 func (d *GozxingDecoder) Decode(img image.Image) ([]byte, error) {
+	result, err := d.decodeResult(img)
+	if err != nil {
+		return nil, fmt.Errorf("gozxing: %w", err)
+	}
+
+	// Extract raw bytes from result
+	return []byte(result.GetText()), nil
+}
+
+// DecodeSequence reassembles a Structured Append sequence with
+// DecodeStructuredAppend, reading each symbol with this decoder's
+// binarizer and character set.
+func (d *GozxingDecoder) DecodeSequence(imgs []image.Image) ([]byte, error) {
+	data, err := decodeStructuredAppend(imgs, d.decodeResult)
+	if err != nil {
+		return nil, fmt.Errorf("gozxing: %w", err)
+	}
+	return data, nil
+}
+
+// decodeResult decodes one QR symbol and returns gozxing's full result,
+// metadata included.
+func (d *GozxingDecoder) decodeResult(img image.Image) (*gozxing.Result, error) {
 	if img == nil {
-		return nil, fmt.Errorf("gozxing: image is nil")
+		return nil, fmt.Errorf("image is nil")
 	}
 
 	// Convert image to gozxing BinaryBitmap
-	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+	source := gozxing.NewLuminanceSourceFromImage(img)
+	var binarizer gozxing.Binarizer
+	switch d.Binarizer {
+	case "", BinarizerHybrid:
+		binarizer = gozxing.NewHybridBinarizer(source)
+	case BinarizerGlobal:
+		binarizer = gozxing.NewGlobalHistgramBinarizer(source)
+	default:
+		return nil, fmt.Errorf("unknown binarizer %q", d.Binarizer)
+	}
+	bmp, err := gozxing.NewBinaryBitmap(binarizer)
 	if err != nil {
-		return nil, fmt.Errorf("gozxing: failed to create binary bitmap: %w", err)
+		return nil, fmt.Errorf("failed to create binary bitmap: %w", err)
 	}
 
 	// Create QR code reader
 	reader := qrcode.NewQRCodeReader()
 
+	charset := d.CharacterSet
+	if charset == "" {
+		charset = DefaultCharacterSet
+	}
+	hints := map[gozxing.DecodeHintType]interface{}{
+		gozxing.DecodeHintType_CHARACTER_SET: charset,
+	}
+
 	// Decode the QR code
-	result, err := reader.Decode(bmp, nil)
+	result, err := reader.Decode(bmp, hints)
 	if err != nil {
-		return nil, fmt.Errorf("gozxing: decode failed: %w", err)
+		return nil, fmt.Errorf("decode failed: %w", err)
 	}
-
-	// Extract raw bytes from result
-	return []byte(result.GetText()), nil
+	return result, nil
 }
@@ -3,8 +3,10 @@ package decoders
 import (
 	"bytes"
 	"image"
+	"strings"
 	"testing"
 
+	"github.com/13rac1/qr-library-test/internal/raster"
 	"github.com/skip2/go-qrcode"
 )
 
@@ -179,3 +181,97 @@ func formatInt(n int) string {
 	}
 	return string(result)
 }
+
+func TestGozxingDecoder_Decode_UTF8CharacterSet(t *testing.T) {
+	originalData := "你好世界🎉"
+
+	// skip2 encodes UTF-8 text in byte mode without an ECI header
+	pngBytes, err := qrcode.Encode(originalData, qrcode.Medium, 256)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	for _, dec := range []*GozxingDecoder{{}, {CharacterSet: "UTF-8"}} {
+		decodedData, err := dec.Decode(img)
+		if err != nil {
+			t.Fatalf("Decode() with charset %q failed: %v", dec.CharacterSet, err)
+		}
+		if !bytes.Equal(decodedData, []byte(originalData)) {
+			t.Errorf("Decode() with charset %q = %q, want %q", dec.CharacterSet, decodedData, originalData)
+		}
+	}
+
+	// A wrong charset garbles the text, showing the hint is honored
+	latin1 := &GozxingDecoder{CharacterSet: "ISO-8859-1"}
+	decodedData, err := latin1.Decode(img)
+	if err != nil {
+		t.Fatalf("Decode() with ISO-8859-1 failed: %v", err)
+	}
+	if bytes.Equal(decodedData, []byte(originalData)) {
+		t.Error("Decode() with ISO-8859-1 should not reproduce the UTF-8 bytes")
+	}
+}
+
+func TestGozxingDecoder_Binarizers_FractionalModules(t *testing.T) {
+	hybrid := &GozxingDecoder{Binarizer: BinarizerHybrid}
+	global := &GozxingDecoder{Binarizer: BinarizerGlobal}
+
+	if hybrid.Name() != "makiuchi-d/gozxing-hybrid" || global.Name() != "makiuchi-d/gozxing-global" {
+		t.Errorf("Names = %q, %q, want binarizer suffixes", hybrid.Name(), global.Name())
+	}
+
+	// Natively rendered codes smooth-scaled to a size where modules are
+	// fractional: anti-aliased gray edges the binarizers threshold differently
+	tests := []struct {
+		name       string
+		data       string
+		pixelSize  int
+		wantHybrid bool
+		wantGlobal bool
+	}{
+		// Version 1 with its quiet zone (29 modules): 7.79px per module
+		{"hybrid only", strings.Repeat("ABCDEFGHIJ", 2), 226, true, false},
+		// Version 3 with its quiet zone (37 modules): 1.65px per module
+		{"global only", strings.Repeat("ABCDEFGHIJ", 5), 61, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := qrcode.New(tt.data, qrcode.Medium)
+			if err != nil {
+				t.Fatalf("Failed to generate QR code: %v", err)
+			}
+			img := raster.ScaleSmooth(q.Image(-1), tt.pixelSize)
+
+			_, hybridErr := hybrid.Decode(img)
+			_, globalErr := global.Decode(img)
+			if (hybridErr == nil) != tt.wantHybrid {
+				t.Errorf("Hybrid success = %v, want %v (err: %v)", hybridErr == nil, tt.wantHybrid, hybridErr)
+			}
+			if (globalErr == nil) != tt.wantGlobal {
+				t.Errorf("Global success = %v, want %v (err: %v)", globalErr == nil, tt.wantGlobal, globalErr)
+			}
+		})
+	}
+}
+
+func TestGozxingDecoder_UnknownBinarizer(t *testing.T) {
+	pngBytes, err := qrcode.Encode("A", qrcode.Medium, 256)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	_, err = (&GozxingDecoder{Binarizer: "otsu"}).Decode(img)
+	if err == nil || !strings.Contains(err.Error(), `unknown binarizer "otsu"`) {
+		t.Errorf("Decode() error = %v, want unknown binarizer", err)
+	}
+}
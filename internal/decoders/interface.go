@@ -1,7 +1,10 @@
 // Package decoders defines the interface for QR code decoders.
 package decoders
 
-import "image"
+import (
+	"errors"
+	"image"
+)
 
 // Decoder extracts data from QR code images.
 // Implementations wrap different QR decoding libraries to provide a uniform interface.
@@ -16,3 +19,77 @@ type Decoder interface {
 	// Implementations should handle panics internally and return them as errors.
 	Decode(img image.Image) ([]byte, error)
 }
+
+// ErrMultiSymbolUnsupported indicates the decoder cannot return more than one
+// QR symbol per image.
+var ErrMultiSymbolUnsupported = errors.New("multi-symbol decoding not supported")
+
+// SequenceDecoder is implemented by decoders that can reassemble a
+// Structured Append sequence, one image per symbol, into the original
+// payload.
+type SequenceDecoder interface {
+	Decoder
+
+	// DecodeSequence decodes every symbol of a sequence, given in any
+	// order, and returns the reassembled payload.
+	DecodeSequence(imgs []image.Image) ([]byte, error)
+}
+
+// MultiDecoder is implemented by decoders that can return every QR symbol
+// found in an image, not just the first one.
+// Decode on these decoders returns only the first symbol.
+type MultiDecoder interface {
+	Decoder
+
+	// DecodeAll extracts data from every QR symbol in the image.
+	// The order of payloads is decoder-defined and may not match
+	// the layout of symbols in the image.
+	DecodeAll(img image.Image) ([][]byte, error)
+}
+
+// ExpectedLenDecoder is implemented by decoders that can use the length of
+// the payload the test expects, such as WithPaddingTrim. The runner passes
+// the expected length to DecodeWithExpectedLen instead of calling Decode.
+type ExpectedLenDecoder interface {
+	Decoder
+
+	// DecodeWithExpectedLen extracts data from a QR code image, using
+	// expectedLen as a hint. The hint must never make a wrong payload pass:
+	// it may only undo changes that leave the expected bytes intact.
+	DecodeWithExpectedLen(img image.Image, expectedLen int) ([]byte, error)
+}
+
+// ArchivedDecoder is implemented by decoders that wrap an archived
+// (unmaintained) library. Their results are still recorded, but can be
+// excluded from headline success rates.
+type ArchivedDecoder interface {
+	Decoder
+
+	// IsArchived reports whether the wrapped library is archived.
+	IsArchived() bool
+}
+
+// TextOnlyDecoder is implemented by decoders that can report whether they
+// return byte-mode payloads faithfully. A decoder whose library hands back
+// text (such as a Go string built from a charset conversion) cannot, and
+// the runner skips binary test cases for it instead of failing them.
+type TextOnlyDecoder interface {
+	Decoder
+
+	// SupportsBinary reports whether decoded byte-mode data is returned
+	// unchanged.
+	SupportsBinary() bool
+}
+
+// SupportsBinary reports whether dec returns binary payloads faithfully.
+// Decoders that do not implement TextOnlyDecoder are assumed to.
+func SupportsBinary(dec Decoder) bool {
+	t, ok := dec.(TextOnlyDecoder)
+	return !ok || t.SupportsBinary()
+}
+
+// IsArchived reports whether dec wraps an archived library.
+func IsArchived(dec Decoder) bool {
+	a, ok := dec.(ArchivedDecoder)
+	return ok && a.IsArchived()
+}
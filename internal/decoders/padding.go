@@ -0,0 +1,79 @@
+package decoders
+
+import "image"
+
+// paddingTrimDecoder wraps a decoder and strips trailing padding that
+// pushes its output past the expected payload length.
+type paddingTrimDecoder struct {
+	inner Decoder
+}
+
+// WithPaddingTrim wraps inner so that, given the expected payload length
+// (see ExpectedLenDecoder), trailing NUL and whitespace bytes beyond that
+// length are dropped. Output is only trimmed when every extra byte is
+// padding, so the result is exactly the expected length or unchanged;
+// a decoder that garbles or loses data still fails validation.
+//
+// The wrapper keeps the inner decoder's name and forwards IsArchived,
+// SupportsBinary, and DecodeAll, so results stay comparable with unwrapped
+// runs.
+func WithPaddingTrim(inner Decoder) Decoder {
+	return &paddingTrimDecoder{inner: inner}
+}
+
+// Name returns the inner decoder's identifier.
+func (d *paddingTrimDecoder) Name() string {
+	return d.inner.Name()
+}
+
+// Decode returns the inner decoder's output untouched; without an expected
+// length there is no safe way to tell padding from payload.
+func (d *paddingTrimDecoder) Decode(img image.Image) ([]byte, error) {
+	return d.inner.Decode(img)
+}
+
+// DecodeWithExpectedLen decodes with the inner decoder and trims trailing
+// padding down to expectedLen.
+func (d *paddingTrimDecoder) DecodeWithExpectedLen(img image.Image, expectedLen int) ([]byte, error) {
+	data, err := d.inner.Decode(img)
+	if err != nil {
+		return nil, err
+	}
+	return TrimPadding(data, expectedLen), nil
+}
+
+// DecodeAll forwards to the inner decoder when it supports multiple symbols.
+func (d *paddingTrimDecoder) DecodeAll(img image.Image) ([][]byte, error) {
+	md, ok := d.inner.(MultiDecoder)
+	if !ok {
+		return nil, ErrMultiSymbolUnsupported
+	}
+	return md.DecodeAll(img)
+}
+
+// IsArchived reports whether the inner decoder wraps an archived library.
+func (d *paddingTrimDecoder) IsArchived() bool {
+	return IsArchived(d.inner)
+}
+
+// SupportsBinary reports whether the inner decoder returns binary payloads
+// faithfully.
+func (d *paddingTrimDecoder) SupportsBinary() bool {
+	return SupportsBinary(d.inner)
+}
+
+// TrimPadding returns data[:expectedLen] when every byte past expectedLen is
+// a NUL or ASCII whitespace, and data unchanged otherwise.
+func TrimPadding(data []byte, expectedLen int) []byte {
+	if expectedLen < 0 || len(data) <= expectedLen {
+		return data
+	}
+	for _, b := range data[expectedLen:] {
+		switch b {
+		case 0x00, ' ', '\t', '\n', '\r':
+		default:
+			return data
+		}
+	}
+	return data[:expectedLen]
+}
@@ -0,0 +1,90 @@
+package decoders
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+)
+
+// paddingStubDecoder ignores the image and returns a fixed output.
+type paddingStubDecoder struct {
+	out []byte
+}
+
+func (d *paddingStubDecoder) Name() string { return "stub/padding" }
+
+func (d *paddingStubDecoder) Decode(img image.Image) ([]byte, error) {
+	return append([]byte(nil), d.out...), nil
+}
+
+func TestWithPaddingTrim(t *testing.T) {
+	payload := []byte("HELLO WORLD")
+
+	tests := []struct {
+		name string
+		out  []byte
+		want []byte
+	}{
+		{"trailing NULs", append(append([]byte(nil), payload...), 0, 0, 0), payload},
+		{"trailing whitespace", append(append([]byte(nil), payload...), ' ', '\r', '\n'), payload},
+		{"exact length", payload, payload},
+		// A non-padding byte past the payload is a real mismatch
+		{"extra data", append(append([]byte(nil), payload...), 0, 'X'), append(append([]byte(nil), payload...), 0, 'X')},
+		// Too short: nothing to trim
+		{"truncated", payload[:5], payload[:5]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := WithPaddingTrim(&paddingStubDecoder{out: tt.out})
+
+			ed, ok := dec.(ExpectedLenDecoder)
+			if !ok {
+				t.Fatal("WithPaddingTrim() should implement ExpectedLenDecoder")
+			}
+			got, err := ed.DecodeWithExpectedLen(nil, len(payload))
+			if err != nil {
+				t.Fatalf("DecodeWithExpectedLen() failed: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("DecodeWithExpectedLen() = %q, want %q", got, tt.want)
+			}
+
+			// Without the hint the output is untouched
+			if got, _ := dec.Decode(nil); !bytes.Equal(got, tt.out) {
+				t.Errorf("Decode() = %q, want %q", got, tt.out)
+			}
+		})
+	}
+}
+
+func TestWithPaddingTrim_ForwardsInner(t *testing.T) {
+	dec := WithPaddingTrim(&GoqrDecoder{})
+	if dec.Name() != "liyue201/goqr" {
+		t.Errorf("Name() = %q, want the inner decoder's name", dec.Name())
+	}
+	if !IsArchived(dec) {
+		t.Error("IsArchived() = false, want the inner decoder's archived status")
+	}
+
+	md, ok := WithPaddingTrim(&TuotooDecoder{}).(MultiDecoder)
+	if !ok {
+		t.Fatal("WithPaddingTrim() should implement MultiDecoder")
+	}
+	if _, err := md.DecodeAll(nil); err != ErrMultiSymbolUnsupported {
+		t.Errorf("DecodeAll() on a single-symbol decoder error = %v, want ErrMultiSymbolUnsupported", err)
+	}
+}
+
+func TestGetAvailableDecoders_TrimDecodedPadding(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.TrimDecodedPadding = true
+
+	for _, dec := range GetAvailableDecoders(cfg) {
+		if _, ok := dec.(ExpectedLenDecoder); !ok {
+			t.Errorf("Decoder %q is not wrapped with WithPaddingTrim", dec.Name())
+		}
+	}
+}
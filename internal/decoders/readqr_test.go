@@ -0,0 +1,117 @@
+package decoders
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+)
+
+func TestReadqrDecoder_Decode_Success(t *testing.T) {
+	dec := &ReadqrDecoder{}
+	originalData := "Hello, QR Code!"
+
+	// Generate a QR code using skip2/go-qrcode
+	pngBytes, err := qrcode.Encode(originalData, qrcode.Medium, 256)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+
+	// Decode PNG bytes to image.Image
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	// Decode the QR code
+	decodedData, err := dec.Decode(img)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+
+	if string(decodedData) != originalData {
+		t.Errorf("Decode() = %q, want %q", string(decodedData), originalData)
+	}
+}
+
+func TestReadqrDecoder_Decode_NilImage(t *testing.T) {
+	dec := &ReadqrDecoder{}
+
+	_, err := dec.Decode(nil)
+	if err == nil {
+		t.Error("Decode() with nil image should fail")
+	}
+}
+
+func TestReadqrDecoder_Decode_VariousData(t *testing.T) {
+	dec := &ReadqrDecoder{}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Short", "A"},
+		{"URL", "https://example.com/test"},
+		{"Numeric", "1234567890"},
+		{"Binary", string([]byte{0x01, 0x02, 0x03, 0x04, 0x05})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Generate QR code
+			pngBytes, err := qrcode.Encode(tt.data, qrcode.Medium, 256)
+			if err != nil {
+				t.Fatalf("Failed to generate QR code: %v", err)
+			}
+
+			img, _, err := image.Decode(bytes.NewReader(pngBytes))
+			if err != nil {
+				t.Fatalf("Failed to decode PNG: %v", err)
+			}
+
+			// Decode QR code
+			decodedData, err := dec.Decode(img)
+			if err != nil {
+				t.Fatalf("Decode() failed: %v", err)
+			}
+
+			if string(decodedData) != tt.data {
+				t.Errorf("Decode() = %q, want %q", string(decodedData), tt.data)
+			}
+		})
+	}
+}
+
+func TestReadqrDecoder_Decode_LargeData(t *testing.T) {
+	dec := &ReadqrDecoder{}
+
+	// Generate 500 bytes of alphanumeric data (safe for string encoding)
+	// Using alphanumeric avoids issues with binary encoding in QR codes
+	data := make([]byte, 500)
+	chars := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	for i := range data {
+		data[i] = chars[i%len(chars)]
+	}
+
+	// Generate QR code with larger pixel size to accommodate data
+	pngBytes, err := qrcode.Encode(string(data), qrcode.Medium, 512)
+	if err != nil {
+		t.Fatalf("Failed to generate QR code: %v", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	// Decode QR code
+	decodedData, err := dec.Decode(img)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+
+	if !bytes.Equal(decodedData, data) {
+		t.Errorf("Decode() data mismatch: got %d bytes, want %d bytes", len(decodedData), len(data))
+	}
+}
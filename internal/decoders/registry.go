@@ -4,15 +4,23 @@ package decoders
 import "github.com/13rac1/qr-library-test/internal/config"
 
 // GetAvailableDecoders returns the list of decoders available based on configuration.
-// Always includes pure Go decoders (gozxing, tuotoo).
+// Always includes pure Go decoders (gozxing, tuotoo, readqr).
+// With cfg.GozxingBinarizers, gozxing runs once per binarizer.
+// With cfg.TrimDecodedPadding, every decoder is wrapped with WithPaddingTrim.
 // Conditionally includes:
 //   - goqr if !cfg.SkipArchived
 //   - goquirc if !cfg.SkipCGO and CGO is enabled at build time
 func GetAvailableDecoders(cfg *config.Config) []Decoder {
 	decoders := []Decoder{
-		&GozxingDecoder{},
-		&TuotooDecoder{},
+		&GozxingDecoder{CharacterSet: cfg.GozxingCharset},
+	}
+	if cfg.GozxingBinarizers {
+		decoders = []Decoder{
+			&GozxingDecoder{CharacterSet: cfg.GozxingCharset, Binarizer: BinarizerHybrid},
+			&GozxingDecoder{CharacterSet: cfg.GozxingCharset, Binarizer: BinarizerGlobal},
+		}
 	}
+	decoders = append(decoders, &TuotooDecoder{}, &ReadqrDecoder{})
 
 	if !cfg.SkipArchived {
 		decoders = append(decoders, &GoqrDecoder{})
@@ -23,6 +31,12 @@ func GetAvailableDecoders(cfg *config.Config) []Decoder {
 		decoders = append(decoders, &GoquircDecoder{})
 	}
 
+	if cfg.TrimDecodedPadding {
+		for i, dec := range decoders {
+			decoders[i] = WithPaddingTrim(dec)
+		}
+	}
+
 	return decoders
 }
 
@@ -32,6 +46,7 @@ func GetAllDecoders() []Decoder {
 	decoders := []Decoder{
 		&GozxingDecoder{},
 		&TuotooDecoder{},
+		&ReadqrDecoder{},
 		&GoqrDecoder{},
 	}
 
@@ -42,3 +57,20 @@ func GetAllDecoders() []Decoder {
 
 	return decoders
 }
+
+// CGOEnabled reports whether the binary was built with CGO,
+// and therefore whether CGO decoders (goquirc) are compiled in.
+func CGOEnabled() bool {
+	return cgoEnabled()
+}
+
+// ArchivedDecoderNames returns the names of all decoders that wrap archived libraries.
+func ArchivedDecoderNames() []string {
+	var names []string
+	for _, dec := range GetAllDecoders() {
+		if IsArchived(dec) {
+			names = append(names, dec.Name())
+		}
+	}
+	return names
+}
@@ -1,6 +1,7 @@
 package decoders
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/13rac1/qr-library-test/internal/config"
@@ -11,11 +12,11 @@ func TestGetAvailableDecoders_DefaultConfig(t *testing.T) {
 
 	decoders := GetAvailableDecoders(cfg)
 
-	// Default config should include all decoders (gozxing, tuotoo, goqr)
+	// Default config should include all decoders (gozxing, tuotoo, readqr, goqr)
 	// Plus goquirc if CGO is enabled
-	expectedCount := 3
+	expectedCount := 4
 	if cgoEnabled() {
-		expectedCount = 4
+		expectedCount = 5
 	}
 	if len(decoders) != expectedCount {
 		t.Errorf("GetAvailableDecoders() returned %d decoders, want %d", len(decoders), expectedCount)
@@ -27,7 +28,7 @@ func TestGetAvailableDecoders_DefaultConfig(t *testing.T) {
 		names[dec.Name()] = true
 	}
 
-	expected := []string{"makiuchi-d/gozxing", "tuotoo/qrcode", "liyue201/goqr"}
+	expected := []string{"makiuchi-d/gozxing", "tuotoo/qrcode", "caiguanhao/readqr", "liyue201/goqr"}
 	for _, name := range expected {
 		if !names[name] {
 			t.Errorf("GetAvailableDecoders() missing decoder %q", name)
@@ -48,8 +49,8 @@ func TestGetAvailableDecoders_SkipArchived(t *testing.T) {
 
 	decoders := GetAvailableDecoders(cfg)
 
-	// Should only have gozxing and tuotoo (no goqr)
-	expectedCount := 2
+	// Should only have gozxing, tuotoo, and readqr (no goqr)
+	expectedCount := 3
 	if len(decoders) != expectedCount {
 		t.Errorf("GetAvailableDecoders() with SkipArchived returned %d decoders, want %d", len(decoders), expectedCount)
 	}
@@ -61,13 +62,13 @@ func TestGetAvailableDecoders_SkipArchived(t *testing.T) {
 		}
 	}
 
-	// Verify we still have gozxing and tuotoo
+	// Verify we still have gozxing, tuotoo, and readqr
 	names := make(map[string]bool)
 	for _, dec := range decoders {
 		names[dec.Name()] = true
 	}
 
-	expected := []string{"makiuchi-d/gozxing", "tuotoo/qrcode"}
+	expected := []string{"makiuchi-d/gozxing", "tuotoo/qrcode", "caiguanhao/readqr"}
 	for _, name := range expected {
 		if !names[name] {
 			t.Errorf("GetAvailableDecoders() missing decoder %q", name)
@@ -81,8 +82,8 @@ func TestGetAvailableDecoders_SkipCGO(t *testing.T) {
 
 	decoders := GetAvailableDecoders(cfg)
 
-	// With SkipCGO, should only have pure Go decoders (gozxing, tuotoo, goqr)
-	expectedCount := 3
+	// With SkipCGO, should only have pure Go decoders (gozxing, tuotoo, readqr, goqr)
+	expectedCount := 4
 	if len(decoders) != expectedCount {
 		t.Errorf("GetAvailableDecoders() with SkipCGO returned %d decoders, want %d", len(decoders), expectedCount)
 	}
@@ -102,8 +103,8 @@ func TestGetAvailableDecoders_SkipBoth(t *testing.T) {
 
 	decoders := GetAvailableDecoders(cfg)
 
-	// Should only have gozxing and tuotoo
-	expectedCount := 2
+	// Should only have gozxing, tuotoo, and readqr
+	expectedCount := 3
 	if len(decoders) != expectedCount {
 		t.Errorf("GetAvailableDecoders() with both skip flags returned %d decoders, want %d", len(decoders), expectedCount)
 	}
@@ -114,7 +115,7 @@ func TestGetAvailableDecoders_SkipBoth(t *testing.T) {
 		names[dec.Name()] = true
 	}
 
-	expected := []string{"makiuchi-d/gozxing", "tuotoo/qrcode"}
+	expected := []string{"makiuchi-d/gozxing", "tuotoo/qrcode", "caiguanhao/readqr"}
 	for _, name := range expected {
 		if !names[name] {
 			t.Errorf("GetAvailableDecoders() missing decoder %q", name)
@@ -122,14 +123,32 @@ func TestGetAvailableDecoders_SkipBoth(t *testing.T) {
 	}
 }
 
+func TestGetAvailableDecoders_GozxingBinarizers(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SkipArchived = true
+	cfg.SkipCGO = true
+	cfg.GozxingBinarizers = true
+
+	decoders := GetAvailableDecoders(cfg)
+
+	var names []string
+	for _, dec := range decoders {
+		names = append(names, dec.Name())
+	}
+	expected := []string{"makiuchi-d/gozxing-hybrid", "makiuchi-d/gozxing-global", "tuotoo/qrcode", "caiguanhao/readqr"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("GetAvailableDecoders() with GozxingBinarizers = %v, want %v", names, expected)
+	}
+}
+
 func TestGetAllDecoders(t *testing.T) {
 	decoders := GetAllDecoders()
 
-	// Should return all 3 decoders regardless of config
+	// Should return all 4 decoders regardless of config
 	// Plus goquirc if CGO is enabled
-	expectedCount := 3
+	expectedCount := 4
 	if cgoEnabled() {
-		expectedCount = 4
+		expectedCount = 5
 	}
 	if len(decoders) != expectedCount {
 		t.Errorf("GetAllDecoders() returned %d decoders, want %d", len(decoders), expectedCount)
@@ -141,7 +160,7 @@ func TestGetAllDecoders(t *testing.T) {
 		names[dec.Name()] = true
 	}
 
-	expected := []string{"makiuchi-d/gozxing", "tuotoo/qrcode", "liyue201/goqr"}
+	expected := []string{"makiuchi-d/gozxing", "tuotoo/qrcode", "caiguanhao/readqr", "liyue201/goqr"}
 	for _, name := range expected {
 		if !names[name] {
 			t.Errorf("GetAllDecoders() missing decoder %q", name)
@@ -178,7 +197,7 @@ func TestGetAvailableDecoders_AlwaysIncludesCoreDecoders(t *testing.T) {
 		names[dec.Name()] = true
 	}
 
-	core := []string{"makiuchi-d/gozxing", "tuotoo/qrcode"}
+	core := []string{"makiuchi-d/gozxing", "tuotoo/qrcode", "caiguanhao/readqr"}
 	for _, name := range core {
 		if !names[name] {
 			t.Errorf("GetAvailableDecoders() should always include core decoder %q", name)
@@ -224,3 +243,35 @@ func TestCgoEnabled(t *testing.T) {
 		t.Log("CGO is disabled - goquirc decoder will not be available")
 	}
 }
+
+func TestArchivedDecoderNames(t *testing.T) {
+	names := ArchivedDecoderNames()
+	if len(names) != 1 || names[0] != "liyue201/goqr" {
+		t.Errorf("ArchivedDecoderNames() = %v, want [liyue201/goqr]", names)
+	}
+}
+
+func TestRegisteredDecoders_UniqueNames(t *testing.T) {
+	// Results are keyed by library name, so a collision would merge two
+	// decoders' results
+	binarizers := config.DefaultConfig()
+	binarizers.GozxingBinarizers = true
+
+	sets := map[string][]Decoder{
+		"all":        GetAllDecoders(),
+		"binarizers": GetAvailableDecoders(binarizers),
+	}
+	for setName, decs := range sets {
+		seen := make(map[string]bool)
+		for i, dec := range decs {
+			name := dec.Name()
+			if name == "" {
+				t.Errorf("%s: decoder %d has an empty name", setName, i)
+			}
+			if seen[name] {
+				t.Errorf("%s: decoder name %q is registered more than once", setName, name)
+			}
+			seen[name] = true
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Package decoders provides QR code decoder implementations.
+package decoders
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/common"
+	"github.com/makiuchi-d/gozxing/common/reedsolomon"
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
+	"github.com/makiuchi-d/gozxing/qrcode/detector"
+)
+
+// Segment describes one mode segment of a QR code's data bitstream,
+// as read before any mode or character set interpretation.
+type Segment struct {
+	// Mode is the segment mode name (e.g., "NUMERIC", "ALPHANUMERIC", "BYTE", "ECI").
+	Mode string
+
+	// ModeIndicator is the raw 4-bit mode indicator value.
+	ModeIndicator int
+
+	// CharCount is the character count indicator for data modes.
+	// For ECI segments it holds the ECI assignment number.
+	CharCount int
+
+	// BitOffset is the position of the mode indicator within the data bitstream.
+	BitOffset int
+
+	// Data holds the segment payload: digits or characters for numeric and
+	// alphanumeric segments, raw bytes for byte segments.
+	// Nil for modes that carry no interpreted payload (ECI, FNC1, Kanji, Hanzi).
+	Data []byte
+}
+
+// SegmentDecoder is a diagnostic decoder built on gozxing's lower-level API.
+// It detects and error-corrects the QR symbol like GozxingDecoder, but stops
+// before mode interpretation so the raw segment list and mode indicators can be
+// inspected. This shows exactly where padding or extra bytes enter a payload.
+//
+// It is not registered in the decoder registry; use it directly for analysis.
+type SegmentDecoder struct{}
+
+// Name returns the decoder identifier.
+func (d *SegmentDecoder) Name() string {
+	return "gozxing-segments"
+}
+
+// Decode extracts data from a QR code image by concatenating segment payloads.
+// Byte segments are returned as raw bytes without character set conversion.
+func (d *SegmentDecoder) Decode(img image.Image) ([]byte, error) {
+	segments, err := d.DecodeSegments(img)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	for _, seg := range segments {
+		data = append(data, seg.Data...)
+	}
+	return data, nil
+}
+
+// DecodeSegments returns the ordered list of data segments in a QR code image.
+// The terminator, if present, is not included in the result.
+func (d *SegmentDecoder) DecodeSegments(img image.Image) ([]Segment, error) {
+	if img == nil {
+		return nil, fmt.Errorf("segments: image is nil")
+	}
+
+	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return nil, fmt.Errorf("segments: failed to create binary bitmap: %w", err)
+	}
+
+	matrix, err := bmp.GetBlackMatrix()
+	if err != nil {
+		return nil, fmt.Errorf("segments: failed to binarize image: %w", err)
+	}
+
+	detected, err := detector.NewDetector(matrix).Detect(nil)
+	if err != nil {
+		return nil, fmt.Errorf("segments: detect failed: %w", err)
+	}
+
+	dataBytes, version, err := readDataCodewords(detected.GetBits())
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSegments(dataBytes, version)
+}
+
+// readDataCodewords reads and error-corrects the data codewords from a sampled bit matrix.
+func readDataCodewords(bits *gozxing.BitMatrix) ([]byte, *decoder.Version, error) {
+	parser, err := decoder.NewBitMatrixParser(bits)
+	if err != nil {
+		return nil, nil, fmt.Errorf("segments: invalid bit matrix: %w", err)
+	}
+
+	version, err := parser.ReadVersion()
+	if err != nil {
+		return nil, nil, fmt.Errorf("segments: failed to read version: %w", err)
+	}
+
+	formatInfo, err := parser.ReadFormatInformation()
+	if err != nil {
+		return nil, nil, fmt.Errorf("segments: failed to read format information: %w", err)
+	}
+
+	codewords, err := parser.ReadCodewords()
+	if err != nil {
+		return nil, nil, fmt.Errorf("segments: failed to read codewords: %w", err)
+	}
+
+	blocks, err := decoder.DataBlock_GetDataBlocks(codewords, version, formatInfo.GetErrorCorrectionLevel())
+	if err != nil {
+		return nil, nil, fmt.Errorf("segments: failed to split data blocks: %w", err)
+	}
+
+	rs := reedsolomon.NewReedSolomonDecoder(reedsolomon.GenericGF_QR_CODE_FIELD_256)
+	var dataBytes []byte
+	for _, block := range blocks {
+		blockBytes := block.GetCodewords()
+		numData := block.GetNumDataCodewords()
+
+		ints := make([]int, len(blockBytes))
+		for i, b := range blockBytes {
+			ints[i] = int(b)
+		}
+		if err := rs.Decode(ints, len(blockBytes)-numData); err != nil {
+			return nil, nil, fmt.Errorf("segments: error correction failed: %w", err)
+		}
+
+		for i := 0; i < numData; i++ {
+			dataBytes = append(dataBytes, byte(ints[i]))
+		}
+	}
+
+	return dataBytes, version, nil
+}
+
+// parseSegments walks the data bitstream and splits it into mode segments.
+func parseSegments(dataBytes []byte, version *decoder.Version) ([]Segment, error) {
+	bits := common.NewBitSource(dataBytes)
+	var segments []Segment
+
+	for bits.Available() >= 4 {
+		offset := bits.GetByteOffset()*8 + bits.GetBitOffset()
+
+		indicator, _ := bits.ReadBits(4)
+		mode, err := decoder.ModeForBits(indicator)
+		if err != nil {
+			return nil, fmt.Errorf("segments: invalid mode indicator 0x%X at bit %d", indicator, offset)
+		}
+		if mode == decoder.Mode_TERMINATOR {
+			break
+		}
+
+		seg := Segment{
+			Mode:          mode.String(),
+			ModeIndicator: indicator,
+			BitOffset:     offset,
+		}
+
+		switch mode {
+		case decoder.Mode_FNC1_FIRST_POSITION, decoder.Mode_FNC1_SECOND_POSITION:
+			// No payload or count follows these indicators.
+		case decoder.Mode_STRUCTURED_APPEND:
+			// Sequence number and parity data (8 bits each)
+			if _, err := bits.ReadBits(16); err != nil {
+				return nil, fmt.Errorf("segments: truncated structured append header: %w", err)
+			}
+		case decoder.Mode_ECI:
+			value, err := decoder.DecodedBitStreamParser_parseECIValue(bits)
+			if err != nil {
+				return nil, fmt.Errorf("segments: invalid ECI value: %w", err)
+			}
+			seg.CharCount = value
+		default:
+			if mode == decoder.Mode_HANZI {
+				// Hanzi segments carry a 4-bit subset indicator before the count
+				if _, err := bits.ReadBits(4); err != nil {
+					return nil, fmt.Errorf("segments: truncated hanzi subset: %w", err)
+				}
+			}
+
+			count, err := bits.ReadBits(mode.GetCharacterCountBits(version))
+			if err != nil {
+				return nil, fmt.Errorf("segments: truncated character count: %w", err)
+			}
+			seg.CharCount = count
+
+			seg.Data, err = readSegmentData(bits, mode, count)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		segments = append(segments, seg)
+	}
+
+	return segments, nil
+}
+
+// readSegmentData reads the payload bits of a single data segment.
+func readSegmentData(bits *common.BitSource, mode *decoder.Mode, count int) ([]byte, error) {
+	switch mode {
+	case decoder.Mode_NUMERIC:
+		data, err := decoder.DecodedBitStreamParser_decodeNumericSegment(bits, nil, count)
+		if err != nil {
+			return nil, fmt.Errorf("segments: invalid numeric segment: %w", err)
+		}
+		return data, nil
+	case decoder.Mode_ALPHANUMERIC:
+		data, err := decoder.DecodedBitStreamParser_decodeAlphanumericSegment(bits, nil, count, false)
+		if err != nil {
+			return nil, fmt.Errorf("segments: invalid alphanumeric segment: %w", err)
+		}
+		return data, nil
+	case decoder.Mode_BYTE:
+		if 8*count > bits.Available() {
+			return nil, fmt.Errorf("segments: byte segment needs %d bits, only %d available", 8*count, bits.Available())
+		}
+		data := make([]byte, count)
+		for i := range data {
+			b, _ := bits.ReadBits(8)
+			data[i] = byte(b)
+		}
+		return data, nil
+	case decoder.Mode_KANJI, decoder.Mode_HANZI:
+		// 13 bits per character; skipped without interpretation
+		if 13*count > bits.Available() {
+			return nil, fmt.Errorf("segments: %s segment truncated", mode)
+		}
+		for i := 0; i < count; i++ {
+			bits.ReadBits(13)
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("segments: unsupported mode %s", mode)
+	}
+}
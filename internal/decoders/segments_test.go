@@ -0,0 +1,111 @@
+package decoders
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+)
+
+func TestSegmentDecoder_DecodeSegments_Alphanumeric(t *testing.T) {
+	dec := &SegmentDecoder{}
+	originalData := "HELLO WORLD 123"
+
+	// skip2/go-qrcode encodes uppercase text in alphanumeric mode
+	pngBytes, err := qrcode.Encode(originalData, qrcode.Medium, 256)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	segments, err := dec.DecodeSegments(img)
+	if err != nil {
+		t.Fatalf("DecodeSegments() failed: %v", err)
+	}
+
+	if len(segments) != 1 {
+		t.Fatalf("DecodeSegments() returned %d segments, want 1: %+v", len(segments), segments)
+	}
+
+	seg := segments[0]
+	if seg.Mode != "ALPHANUMERIC" {
+		t.Errorf("Segment mode = %q, want %q", seg.Mode, "ALPHANUMERIC")
+	}
+
+	if seg.ModeIndicator != 0x2 {
+		t.Errorf("Segment mode indicator = 0x%X, want 0x2", seg.ModeIndicator)
+	}
+
+	if seg.CharCount != len(originalData) {
+		t.Errorf("Segment char count = %d, want %d", seg.CharCount, len(originalData))
+	}
+
+	if seg.BitOffset != 0 {
+		t.Errorf("Segment bit offset = %d, want 0", seg.BitOffset)
+	}
+
+	if string(seg.Data) != originalData {
+		t.Errorf("Segment data = %q, want %q", string(seg.Data), originalData)
+	}
+}
+
+func TestSegmentDecoder_DecodeSegments_Byte(t *testing.T) {
+	dec := &SegmentDecoder{}
+	originalData := "hello, qr code"
+
+	// Lowercase text forces byte mode
+	pngBytes, err := qrcode.Encode(originalData, qrcode.Medium, 256)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	segments, err := dec.DecodeSegments(img)
+	if err != nil {
+		t.Fatalf("DecodeSegments() failed: %v", err)
+	}
+
+	if len(segments) == 0 {
+		t.Fatal("DecodeSegments() returned no segments")
+	}
+
+	if segments[0].Mode != "BYTE" {
+		t.Errorf("Segment mode = %q, want %q", segments[0].Mode, "BYTE")
+	}
+
+	// Decode concatenates segment payloads
+	decodedData, err := dec.Decode(img)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+
+	if string(decodedData) != originalData {
+		t.Errorf("Decode() = %q, want %q", string(decodedData), originalData)
+	}
+}
+
+func TestSegmentDecoder_DecodeSegments_NilImage(t *testing.T) {
+	dec := &SegmentDecoder{}
+
+	_, err := dec.DecodeSegments(nil)
+	if err == nil {
+		t.Error("DecodeSegments() with nil image should fail")
+	}
+}
+
+func TestSegmentDecoder_Name(t *testing.T) {
+	dec := &SegmentDecoder{}
+
+	if dec.Name() != "gozxing-segments" {
+		t.Errorf("Name() = %q, want %q", dec.Name(), "gozxing-segments")
+	}
+}
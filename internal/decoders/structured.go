@@ -0,0 +1,93 @@
+package decoders
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/makiuchi-d/gozxing"
+)
+
+// DecodeStructuredAppend decodes the symbols of a Structured Append
+// sequence, one per image in any order, and reassembles the original
+// payload. Every symbol must carry the same symbol count and parity, the
+// count must match len(imgs) with each position present once, and the
+// parity must match the reassembled bytes. A single image without a
+// structured-append header decodes as a plain symbol.
+//
+// The symbols are read with gozxing, which reports the structured-append
+// header as result metadata. Byte-mode payloads are taken from the raw
+// byte segments so binary data survives without a charset conversion.
+func DecodeStructuredAppend(imgs []image.Image) ([]byte, error) {
+	return decodeStructuredAppend(imgs, (&GozxingDecoder{}).decodeResult)
+}
+
+// decodeStructuredAppend implements DecodeStructuredAppend, reading each
+// symbol with decode.
+func decodeStructuredAppend(imgs []image.Image, decode func(image.Image) (*gozxing.Result, error)) ([]byte, error) {
+	if len(imgs) == 0 {
+		return nil, fmt.Errorf("structured append: no images")
+	}
+
+	parts := make([][]byte, len(imgs))
+	var parity int
+	for i, img := range imgs {
+		result, err := decode(img)
+		if err != nil {
+			return nil, fmt.Errorf("structured append: image %d: %w", i, err)
+		}
+
+		metadata := result.GetResultMetadata()
+		sequence, ok := metadata[gozxing.ResultMetadataType_STRUCTURED_APPEND_SEQUENCE].(int)
+		if !ok {
+			if len(imgs) == 1 {
+				return symbolBytes(result), nil
+			}
+			return nil, fmt.Errorf("structured append: image %d has no structured append header", i)
+		}
+
+		index, count := sequence>>4, sequence&0x0F+1
+		if count != len(imgs) || index >= count {
+			return nil, fmt.Errorf("structured append: image %d is symbol %d of %d, got %d images", i, index+1, count, len(imgs))
+		}
+		if parts[index] != nil {
+			return nil, fmt.Errorf("structured append: symbol %d appears more than once", index+1)
+		}
+
+		symbolParity, _ := metadata[gozxing.ResultMetadataType_STRUCTURED_APPEND_PARITY].(int)
+		if i == 0 {
+			parity = symbolParity
+		} else if symbolParity != parity {
+			return nil, fmt.Errorf("structured append: image %d parity 0x%02X, want 0x%02X", i, symbolParity, parity)
+		}
+		parts[index] = symbolBytes(result)
+	}
+
+	var data []byte
+	for _, part := range parts {
+		data = append(data, part...)
+	}
+
+	var got byte
+	for _, b := range data {
+		got ^= b
+	}
+	if int(got) != parity {
+		return nil, fmt.Errorf("structured append: reassembled data parity 0x%02X, want 0x%02X", got, parity)
+	}
+	return data, nil
+}
+
+// symbolBytes returns the raw byte segments of result, or its text when the
+// symbol has no byte-mode segment.
+func symbolBytes(result *gozxing.Result) []byte {
+	segments, _ := result.GetResultMetadata()[gozxing.ResultMetadataType_BYTE_SEGMENTS].([][]byte)
+	if len(segments) == 0 {
+		return []byte(result.GetText())
+	}
+
+	var data []byte
+	for _, segment := range segments {
+		data = append(data, segment...)
+	}
+	return data
+}
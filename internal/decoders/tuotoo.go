@@ -20,6 +20,12 @@ func (d *TuotooDecoder) Name() string {
 	return "tuotoo/qrcode"
 }
 
+// SupportsBinary reports false: tuotoo returns the payload as a string
+// (Matrix.Content), so byte-mode data does not survive the round trip.
+func (d *TuotooDecoder) SupportsBinary() bool {
+	return false
+}
+
 // Decode extracts data from a QR code image.
 // The tuotoo library requires an io.Reader, so we convert the image to PNG bytes.
 // This decoder handles panics from the underlying library and returns them as errors.
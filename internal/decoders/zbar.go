@@ -0,0 +1,86 @@
+// Package decoders provides QR code decoder implementations.
+package decoders
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+)
+
+// ZbarDecoder shells out to the external zbarimg tool (from the ZBar project).
+// It is a reference decoder for cross-validation only: it is not registered
+// in the decoder registry and requires zbarimg on the PATH.
+type ZbarDecoder struct {
+	// Path is the zbarimg executable.
+	Path string
+}
+
+// NewZbarDecoder returns a ZbarDecoder when zbarimg is installed.
+func NewZbarDecoder() (*ZbarDecoder, error) {
+	path, err := exec.LookPath("zbarimg")
+	if err != nil {
+		return nil, fmt.Errorf("zbar: zbarimg not found: %w", err)
+	}
+	return &ZbarDecoder{Path: path}, nil
+}
+
+// Name returns the decoder identifier.
+func (d *ZbarDecoder) Name() string {
+	return "zbar/zbarimg"
+}
+
+// Decode writes the image to a temporary PNG and decodes it with zbarimg.
+// Only the first symbol is returned.
+func (d *ZbarDecoder) Decode(img image.Image) ([]byte, error) {
+	if img == nil {
+		return nil, fmt.Errorf("zbar: image is nil")
+	}
+
+	f, err := os.CreateTemp("", "qr-zbar-*.png")
+	if err != nil {
+		return nil, fmt.Errorf("zbar: failed to create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("zbar: failed to write image: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("zbar: failed to write image: %w", err)
+	}
+
+	// -Sbinary returns byte-mode payloads without text conversion;
+	// --oneshot stops after the first symbol
+	cmd := exec.Command(d.Path, "--quiet", "--raw", "--oneshot", "-Sbinary", f.Name())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("zbar: decode failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	// zbarimg terminates each symbol with a newline
+	out = bytes.TrimSuffix(out, []byte("\n"))
+	if len(out) == 0 {
+		return nil, fmt.Errorf("zbar: no QR code found")
+	}
+
+	return out, nil
+}
+
+// FindReferenceDecoder returns the most reliable decoder available for
+// cross-validating failures: zbarimg when installed, otherwise goquirc when
+// built with CGO. Returns false when neither is available.
+func FindReferenceDecoder() (Decoder, bool) {
+	if zbar, err := NewZbarDecoder(); err == nil {
+		return zbar, true
+	}
+	if CGOEnabled() {
+		return &GoquircDecoder{}, true
+	}
+	return nil, false
+}
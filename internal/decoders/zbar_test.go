@@ -0,0 +1,58 @@
+package decoders
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+)
+
+func TestZbarDecoder_Decode(t *testing.T) {
+	dec, err := NewZbarDecoder()
+	if err != nil {
+		t.Skipf("zbarimg not installed: %v", err)
+	}
+
+	originalData := "Hello, zbar!"
+	pngBytes, err := qrcode.Encode(originalData, qrcode.Medium, 256)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	decodedData, err := dec.Decode(img)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+
+	if string(decodedData) != originalData {
+		t.Errorf("Decode() = %q, want %q", string(decodedData), originalData)
+	}
+}
+
+func TestZbarDecoder_Decode_NilImage(t *testing.T) {
+	dec := &ZbarDecoder{Path: "zbarimg"}
+
+	_, err := dec.Decode(nil)
+	if err == nil {
+		t.Error("Decode() with nil image should fail")
+	}
+}
+
+func TestFindReferenceDecoder(t *testing.T) {
+	ref, ok := FindReferenceDecoder()
+
+	_, zbarErr := NewZbarDecoder()
+	wantAvailable := zbarErr == nil || CGOEnabled()
+	if ok != wantAvailable {
+		t.Fatalf("FindReferenceDecoder() available = %v, want %v", ok, wantAvailable)
+	}
+	if ok && ref == nil {
+		t.Error("FindReferenceDecoder() returned ok with a nil decoder")
+	}
+}
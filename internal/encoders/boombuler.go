@@ -2,6 +2,7 @@
 package encoders
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -11,6 +12,9 @@ import (
 
 // BoombulerEncoder wraps github.com/boombuler/barcode for QR code generation.
 // This encoder returns image.Image directly from the barcode interface.
+//
+// Natively PixelSize is the symbol alone: boombuler scales it to fill the
+// image with no quiet zone beyond the rounding slack.
 type BoombulerEncoder struct{}
 
 // Name returns the encoder identifier.
@@ -25,6 +29,14 @@ func (e *BoombulerEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult
 		return EncodeResult{}, fmt.Errorf("boombuler: cannot encode empty data")
 	}
 
+	if opts.MicroQR {
+		return EncodeResult{}, fmt.Errorf("boombuler: %w", ErrMicroQRUnsupported)
+	}
+
+	if opts.ForceVersion > 0 {
+		return EncodeResult{}, fmt.Errorf("boombuler: %w", ErrForcedVersionUnsupported)
+	}
+
 	// Map error correction level to qr package constants
 	var level qr.ErrorCorrectionLevel
 	switch opts.ErrorCorrectionLevel {
@@ -53,14 +65,27 @@ func (e *BoombulerEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult
 	dimension := bounds.Dx()
 	version := ((dimension - 21) / 4) + 1
 
-	// Scale barcode to desired pixel size
-	scaled, err := barcode.Scale(qrCode, opts.PixelSize, opts.PixelSize)
+	if !opts.PixelSizeIncludesQuietZone {
+		// Scale barcode to desired pixel size
+		scaled, err := barcode.Scale(qrCode, opts.PixelSize, opts.PixelSize)
+		if err != nil {
+			return EncodeResult{}, fmt.Errorf("boombuler: scale failed: %w", err)
+		}
+		return EncodeResult{
+			Image:   scaled,
+			Version: version,
+		}, nil
+	}
+
+	// Scale the symbol to leave room for the quiet zone, then center it
+	// in the full image
+	img, err := fitQuietZone(qrCode, opts.PixelSize)
 	if err != nil {
-		return EncodeResult{}, fmt.Errorf("boombuler: scale failed: %w", err)
+		return EncodeResult{}, fmt.Errorf("boombuler: %w", err)
 	}
 
 	return EncodeResult{
-		Image:   scaled,
+		Image:   img,
 		Version: version,
 	}, nil
 }
@@ -71,6 +96,7 @@ func (e *BoombulerEncoder) IsCapacityError(err error) bool {
 		return false
 	}
 	msg := err.Error()
-	return strings.Contains(msg, "To much data to encode") ||
+	return errors.Is(err, ErrPixelSizeTooSmall) ||
+		strings.Contains(msg, "To much data to encode") ||
 		strings.Contains(msg, "can not scale barcode to an image smaller than")
 }
@@ -0,0 +1,45 @@
+package encoders
+
+import (
+	"fmt"
+	"image"
+)
+
+// CheckDeterminism encodes data twice with opts and reports whether the two
+// images are pixel-identical. A library that embeds timestamps or picks
+// masks at random makes image hashes and per-image timing comparisons
+// noisy. Returns an error if either encode fails.
+func CheckDeterminism(enc Encoder, data []byte, opts EncodeOptions) (bool, error) {
+	first, err := enc.Encode(data, opts)
+	if err != nil {
+		return false, fmt.Errorf("%s: first encode failed: %w", enc.Name(), err)
+	}
+	second, err := enc.Encode(data, opts)
+	if err != nil {
+		return false, fmt.Errorf("%s: second encode failed: %w", enc.Name(), err)
+	}
+	return first.Version == second.Version && sameImage(first.Image, second.Image), nil
+}
+
+// sameImage reports whether a and b have the same bounds and the same
+// color at every pixel.
+func sameImage(a, b image.Image) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Bounds() != b.Bounds() {
+		return false
+	}
+
+	bounds := a.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			ar, ag, ab, aa := a.At(x, y).RGBA()
+			br, bg, bb, ba := b.At(x, y).RGBA()
+			if ar != br || ag != bg || ab != bb || aa != ba {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package encoders
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// noisyEncoder flips one pixel on every other encode, like a library that
+// embeds a timestamp or picks a mask at random.
+type noisyEncoder struct {
+	Skip2Encoder
+	calls int
+}
+
+func (e *noisyEncoder) Name() string { return "stub/noisy" }
+
+func (e *noisyEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, error) {
+	result, err := e.Skip2Encoder.Encode(data, opts)
+	if err != nil {
+		return result, err
+	}
+	e.calls++
+	if e.calls%2 == 0 {
+		b := result.Image.Bounds()
+		img := image.NewRGBA(b)
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				img.Set(x, y, result.Image.At(x, y))
+			}
+		}
+		img.Set(0, 0, color.Black)
+		result.Image = img
+	}
+	return result, nil
+}
+
+func TestCheckDeterminism(t *testing.T) {
+	data := []byte("determinism check 0123456789")
+	opts := EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionM, PixelSize: 320, PixelSizeIncludesQuietZone: true}
+
+	// All four wrapped libraries render identical images for identical
+	// input; none embeds a timestamp or randomizes the mask
+	for _, enc := range GetAllEncoders() {
+		deterministic, err := CheckDeterminism(enc, data, opts)
+		if err != nil {
+			t.Fatalf("CheckDeterminism(%s) failed: %v", enc.Name(), err)
+		}
+		if !deterministic {
+			t.Errorf("%s encoded the same data to different images", enc.Name())
+		}
+	}
+
+	deterministic, err := CheckDeterminism(&noisyEncoder{}, data, opts)
+	if err != nil {
+		t.Fatalf("CheckDeterminism(noisy) failed: %v", err)
+	}
+	if deterministic {
+		t.Error("CheckDeterminism() = true for an encoder whose images differ")
+	}
+
+	if _, err := CheckDeterminism(&Skip2Encoder{}, nil, opts); err == nil {
+		t.Error("CheckDeterminism() with empty data should fail")
+	}
+}
@@ -0,0 +1,87 @@
+package encoders
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncoders_ForceVersion(t *testing.T) {
+	data := []byte("Hello, QR Code!")
+
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionM,
+		PixelSize:            512,
+		ForceVersion:         20,
+	}
+
+	supported := []Encoder{
+		&Skip2Encoder{},
+		&GozxingEncoder{},
+		&YeqownEncoder{},
+	}
+
+	for _, enc := range supported {
+		t.Run(enc.Name(), func(t *testing.T) {
+			result, err := enc.Encode(data, opts)
+			if err != nil {
+				t.Fatalf("Encode() with ForceVersion=20 failed: %v", err)
+			}
+
+			if result.Version != 20 {
+				t.Errorf("Version = %d, want 20", result.Version)
+			}
+
+			// Version 20: 17 + 4*20 = 97 modules
+			moduleCount := 17 + 4*result.Version
+			if moduleCount != 97 {
+				t.Errorf("Module count = %d, want 97", moduleCount)
+			}
+		})
+	}
+}
+
+func TestEncoders_ForceVersion_DataTooLarge(t *testing.T) {
+	// 500 bytes cannot fit in version 2 at any error correction level
+	data := []byte(strings.Repeat("x", 500))
+
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionL,
+		PixelSize:            256,
+		ForceVersion:         2,
+	}
+
+	supported := []Encoder{
+		&Skip2Encoder{},
+		&GozxingEncoder{},
+		&YeqownEncoder{},
+	}
+
+	for _, enc := range supported {
+		t.Run(enc.Name(), func(t *testing.T) {
+			_, err := enc.Encode(data, opts)
+			if err == nil {
+				t.Fatal("Encode() with data exceeding forced version should fail")
+			}
+
+			if !enc.IsCapacityError(err) {
+				t.Errorf("IsCapacityError(%v) = false, want true", err)
+			}
+		})
+	}
+}
+
+func TestBoombulerEncoder_ForceVersion_Unsupported(t *testing.T) {
+	enc := &BoombulerEncoder{}
+
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionM,
+		PixelSize:            256,
+		ForceVersion:         20,
+	}
+
+	_, err := enc.Encode([]byte("Hello"), opts)
+	if !errors.Is(err, ErrForcedVersionUnsupported) {
+		t.Errorf("Encode() error = %v, want ErrForcedVersionUnsupported", err)
+	}
+}
@@ -9,10 +9,14 @@ import (
 
 	"github.com/makiuchi-d/gozxing"
 	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
 )
 
 // GozxingEncoder wraps github.com/makiuchi-d/gozxing encoder for QR code generation.
 // This encoder uses the gozxing library's QRCodeWriter to generate QR codes.
+//
+// Natively PixelSize is the whole image: the writer adds a 4-module margin
+// and centers the symbol, so PixelSizeIncludesQuietZone changes nothing.
 type GozxingEncoder struct{}
 
 // Name returns the encoder identifier.
@@ -27,31 +31,46 @@ func (e *GozxingEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult,
 		return EncodeResult{}, fmt.Errorf("gozxing: cannot encode empty data")
 	}
 
-	// Map error correction level to hint value
-	var levelString string
+	if opts.MicroQR {
+		return EncodeResult{}, fmt.Errorf("gozxing: %w", ErrMicroQRUnsupported)
+	}
+
+	// Map error correction level to gozxing's typed level. The writer also
+	// parses "L"/"M"/"Q"/"H" strings, but the typed value is what every
+	// gozxing version honors without conversion
+	var level decoder.ErrorCorrectionLevel
 	switch opts.ErrorCorrectionLevel {
 	case ErrorCorrectionL:
-		levelString = "L"
+		level = decoder.ErrorCorrectionLevel_L
 	case ErrorCorrectionM:
-		levelString = "M"
+		level = decoder.ErrorCorrectionLevel_M
 	case ErrorCorrectionQ:
-		levelString = "Q"
+		level = decoder.ErrorCorrectionLevel_Q
 	case ErrorCorrectionH:
-		levelString = "H"
+		level = decoder.ErrorCorrectionLevel_H
 	default:
 		return EncodeResult{}, fmt.Errorf("gozxing: invalid error correction level %q", opts.ErrorCorrectionLevel)
 	}
 
 	// Create encoding hints
 	hints := make(map[gozxing.EncodeHintType]interface{})
-	hints[gozxing.EncodeHintType_ERROR_CORRECTION] = levelString
+	hints[gozxing.EncodeHintType_ERROR_CORRECTION] = level
+	if opts.ForceVersion > 0 {
+		hints[gozxing.EncodeHintType_QR_VERSION] = opts.ForceVersion
+	}
 
 	// First encode at minimal size to detect QR version
-	// The gozxing writer scales the QR to pixel size, so we need to encode
-	// at module size first to get accurate version detection
+	// The gozxing writer scales the QR to pixel size and adds a quiet zone,
+	// so encode unscaled with no margin to get the bare module count
+	minHints := make(map[gozxing.EncodeHintType]interface{}, len(hints)+1)
+	for k, v := range hints {
+		minHints[k] = v
+	}
+	minHints[gozxing.EncodeHintType_MARGIN] = 0
+
 	writer := qrcode.NewQRCodeWriter()
 	minMatrix, err := writer.Encode(string(data), gozxing.BarcodeFormat_QR_CODE,
-		100, 100, hints)
+		0, 0, minHints)
 	if err != nil {
 		return EncodeResult{}, fmt.Errorf("gozxing: encode failed: %w", err)
 	}
@@ -1,7 +1,11 @@
 package encoders
 
 import (
+	"fmt"
 	"testing"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
 )
 
 func TestGozxingEncoder_Encode_Success(t *testing.T) {
@@ -159,3 +163,35 @@ func TestGozxingEncoder_Encode_DifferentPixelSizes(t *testing.T) {
 		})
 	}
 }
+
+func TestGozxingEncoder_Encode_HonorsErrorCorrectionLevel(t *testing.T) {
+	enc := &GozxingEncoder{}
+	data := []byte("Error correction level check")
+
+	levels := map[string]string{}
+	for _, level := range []string{ErrorCorrectionL, ErrorCorrectionH} {
+		result, err := enc.Encode(data, EncodeOptions{ErrorCorrectionLevel: level, PixelSize: 256})
+		if err != nil {
+			t.Fatalf("Encode() at %s failed: %v", level, err)
+		}
+
+		bmp, err := gozxing.NewBinaryBitmapFromImage(result.Image)
+		if err != nil {
+			t.Fatalf("Failed to create binary bitmap: %v", err)
+		}
+		decoded, err := qrcode.NewQRCodeReader().Decode(bmp, nil)
+		if err != nil {
+			t.Fatalf("Decode() at %s failed: %v", level, err)
+		}
+
+		recovered, ok := decoded.GetResultMetadata()[gozxing.ResultMetadataType_ERROR_CORRECTION_LEVEL]
+		if !ok {
+			t.Fatalf("Decoded result at %s has no error correction metadata", level)
+		}
+		levels[level] = fmt.Sprint(recovered)
+	}
+
+	if levels[ErrorCorrectionL] != "L" || levels[ErrorCorrectionH] != "H" {
+		t.Errorf("Recovered error correction levels = %v, want L→L and H→H", levels)
+	}
+}
@@ -1,7 +1,11 @@
 // Package encoders defines the interface for QR code encoders.
 package encoders
 
-import "image"
+import (
+	"errors"
+	"image"
+	"time"
+)
 
 // ErrorCorrectionLevel constants define QR code error correction levels.
 // Higher levels can recover from more errors but result in larger QR codes.
@@ -26,8 +30,37 @@ type EncodeOptions struct {
 	// When this calculation results in a fractional value, some decoder
 	// libraries may fail to decode the QR code.
 	PixelSize int
+
+	// MicroQR requests a Micro QR code (versions M1-M4) instead of a standard QR code.
+	// Micro QR codes have 11, 13, 15, or 17 modules per side and a 2-module quiet zone.
+	// Encoders whose libraries cannot emit Micro QR return ErrMicroQRUnsupported.
+	MicroQR bool
+
+	// ForceVersion fixes the QR version (1-40) instead of letting the library
+	// select the minimal version for the data. Zero means automatic selection.
+	// Forcing the version isolates version-specific module sizing from data size.
+	// When the data does not fit the forced version, encoders return a capacity error.
+	// Encoders whose libraries cannot fix the version return ErrForcedVersionUnsupported.
+	ForceVersion int
+
+	// PixelSizeIncludesQuietZone normalizes PixelSize to mean the total image,
+	// symbol plus a quiet zone of at least 4 modules per side, for every
+	// encoder. Libraries disagree natively: skip2, gozxing, and the SVG
+	// encoder already render the whole image at PixelSize; boombuler scales
+	// the bare symbol to fill PixelSize; yeqown sizes modules as if PixelSize
+	// were the symbol alone and adds a fixed 40px border. With this set,
+	// boombuler and yeqown scale the symbol, at a possibly fractional module
+	// size, to leave room for the quiet zone; an image too small for one pixel per module fails with
+	// ErrPixelSizeTooSmall. False keeps each library's native convention.
+	PixelSizeIncludesQuietZone bool
 }
 
+// ErrMicroQRUnsupported indicates the encoder library cannot produce Micro QR codes.
+var ErrMicroQRUnsupported = errors.New("micro QR not supported")
+
+// ErrForcedVersionUnsupported indicates the encoder library cannot fix the QR version.
+var ErrForcedVersionUnsupported = errors.New("forced QR version not supported")
+
 // EncodeResult contains the encoded QR code image and metadata.
 type EncodeResult struct {
 	// Image is the generated QR code.
@@ -40,7 +73,41 @@ type EncodeResult struct {
 	// - Version 2: 25x25 modules
 	// - Each version adds 4 modules per side
 	// - Formula: modules = 17 + (version * 4)
+	// For Micro QR codes this is the Micro version (1-4 for M1-M4).
+	Version int
+
+	// MicroQR indicates the image contains a Micro QR code.
+	// When true, Version is a Micro version and module count is 9 + (version * 2).
+	MicroQR bool
+}
+
+// ModuleInfo describes the symbol an encoder produced, as selected by the library.
+type ModuleInfo struct {
+	// Version is the QR version (1-40), or the Micro version (1-4) when MicroQR is set.
 	Version int
+
+	// ModuleCount is the number of modules per side, excluding the quiet zone.
+	ModuleCount int
+
+	// MicroQR indicates the symbol is a Micro QR code.
+	MicroQR bool
+}
+
+// ModuleInfo builds ModuleInfo from the result's reported version. The
+// module count is zero when the version is unknown.
+func (result EncodeResult) ModuleInfo() ModuleInfo {
+	info := ModuleInfo{
+		Version: result.Version,
+		MicroQR: result.MicroQR,
+	}
+	switch {
+	case result.Version <= 0:
+	case result.MicroQR:
+		info.ModuleCount = 9 + 2*result.Version
+	default:
+		info.ModuleCount = 17 + 4*result.Version
+	}
+	return info
 }
 
 // Encoder generates QR codes from input data.
@@ -62,3 +129,89 @@ type Encoder interface {
 	// not encoder bugs, and should be treated as skipped tests.
 	IsCapacityError(err error) bool
 }
+
+// EncodeTimings breaks one encode into phases for encoders whose libraries
+// build the symbol and then round-trip it through PNG. The phases together
+// account for nearly all of the encode time.
+type EncodeTimings struct {
+	// QRConstruct is the time the library spent building the symbol matrix.
+	QRConstruct time.Duration
+
+	// ImageEncode is the time spent rendering the symbol to PNG bytes.
+	ImageEncode time.Duration
+
+	// ImageDecode is the time spent decoding the PNG back to an image.Image.
+	ImageDecode time.Duration
+}
+
+// SequenceEncoder is implemented by encoders that can split one payload
+// over several symbols. The runner encodes every test case with
+// EncodeSequence and, when it returns more than one symbol, decodes them
+// with a decoders.SequenceDecoder.
+type SequenceEncoder interface {
+	Encoder
+
+	// EncodeSequence encodes data as one symbol or, when it does not fit,
+	// a sequence of symbols in order.
+	EncodeSequence(data []byte, opts EncodeOptions) ([]EncodeResult, error)
+}
+
+// PhaseTimedEncoder is implemented by encoders with distinct, separately
+// measurable encode phases. The runner prefers EncodeWithTimings over
+// Encode for these encoders and records the phases as sub-timings.
+type PhaseTimedEncoder interface {
+	Encoder
+
+	// EncodeWithTimings generates a QR code image and returns the version
+	// the library selected and how long each encode phase took.
+	EncodeWithTimings(data []byte, opts EncodeOptions) (image.Image, ModuleInfo, EncodeTimings, error)
+}
+
+// ArchivedEncoder is implemented by encoders that wrap an archived
+// (unmaintained) library. GetAvailableEncoders leaves them out with
+// cfg.SkipArchived, as the decoder registry does for archived decoders.
+type ArchivedEncoder interface {
+	Encoder
+
+	// IsArchived reports whether the wrapped library is archived.
+	IsArchived() bool
+}
+
+// IsArchived reports whether enc wraps an archived library.
+func IsArchived(enc Encoder) bool {
+	a, ok := enc.(ArchivedEncoder)
+	return ok && a.IsArchived()
+}
+
+// OversizedEncoder is implemented by encoders whose images are deliberately
+// larger than EncodeOptions.PixelSize, such as WithPadding's added border.
+// The runner decodes their images as rendered; resizing them to PixelSize
+// would shrink the symbol and undo the border.
+type OversizedEncoder interface {
+	Encoder
+
+	// RendersOversized reports whether images exceed PixelSize on purpose.
+	RendersOversized() bool
+}
+
+// RendersOversized reports whether enc renders images larger than PixelSize
+// on purpose.
+func RendersOversized(enc Encoder) bool {
+	o, ok := enc.(OversizedEncoder)
+	return ok && o.RendersOversized()
+}
+
+// CGOEncoder is implemented by encoders that wrap a C library through CGO.
+// GetAvailableEncoders leaves them out with cfg.SkipCGO.
+type CGOEncoder interface {
+	Encoder
+
+	// RequiresCGO reports whether the encoder calls into C.
+	RequiresCGO() bool
+}
+
+// RequiresCGO reports whether enc wraps a C library.
+func RequiresCGO(enc Encoder) bool {
+	c, ok := enc.(CGOEncoder)
+	return ok && c.RequiresCGO()
+}
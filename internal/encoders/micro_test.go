@@ -0,0 +1,39 @@
+package encoders
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncoders_MicroQR_Unsupported(t *testing.T) {
+	// None of the wrapped libraries can emit Micro QR codes. Encoding a short
+	// numeric payload must fail with ErrMicroQRUnsupported rather than silently
+	// producing a standard QR code that would be misclassified.
+	data := []byte("12345")
+
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionL,
+		PixelSize:            128,
+		MicroQR:              true,
+	}
+
+	for _, enc := range GetAllEncoders() {
+		t.Run(enc.Name(), func(t *testing.T) {
+			result, err := enc.Encode(data, opts)
+			if err == nil {
+				if !result.MicroQR {
+					t.Fatal("Encode() returned a standard QR code for a Micro QR request")
+				}
+				t.Skipf("%s supports Micro QR", enc.Name())
+			}
+
+			if !errors.Is(err, ErrMicroQRUnsupported) {
+				t.Errorf("Encode() error = %v, want ErrMicroQRUnsupported", err)
+			}
+
+			if enc.IsCapacityError(err) {
+				t.Error("IsCapacityError() = true for unsupported Micro QR, want false")
+			}
+		})
+	}
+}
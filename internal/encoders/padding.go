@@ -0,0 +1,126 @@
+package encoders
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// paddingEncoder wraps an encoder and adds a white border around its output.
+type paddingEncoder struct {
+	inner   Encoder
+	modules int
+}
+
+// WithPadding wraps inner so that every encoded image gets a white border
+// of modules modules on each side, measured from the rendered symbol. Some
+// libraries emit quiet zones narrower than the 4 modules ISO 18004 requires
+// (2 for Micro QR), and decoders that reject them fail for reasons unrelated
+// to module sizing; running both the plain and padded encoder shows whether
+// a failure is quiet-zone related.
+//
+// The padded image is larger than opts.PixelSize by twice the border, and
+// the wrapper reports RendersOversized so the runner keeps it that size. The
+// wrapper is named after the inner encoder with a "+pad<modules>" suffix so
+// both can run side by side, and forwards IsArchived and RequiresCGO.
+func WithPadding(inner Encoder, modules int) Encoder {
+	return &paddingEncoder{inner: inner, modules: modules}
+}
+
+// Name returns the inner encoder's identifier with the padding suffix.
+func (e *paddingEncoder) Name() string {
+	return fmt.Sprintf("%s+pad%d", e.inner.Name(), e.modules)
+}
+
+// Encode encodes with the inner encoder and pads the result. The module
+// count is needed to measure the module size, so an encode whose version
+// is unknown fails.
+func (e *paddingEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, error) {
+	result, err := e.inner.Encode(data, opts)
+	if err != nil {
+		return EncodeResult{}, err
+	}
+
+	info := result.ModuleInfo()
+	if info.ModuleCount <= 0 {
+		return EncodeResult{}, fmt.Errorf("%s: cannot measure module size: QR version unknown", e.Name())
+	}
+	padded, err := PadImage(result.Image, info.ModuleCount, e.modules)
+	if err != nil {
+		return EncodeResult{}, fmt.Errorf("%s: %w", e.Name(), err)
+	}
+	result.Image = padded
+	return result, nil
+}
+
+// IsCapacityError forwards to the inner encoder.
+func (e *paddingEncoder) IsCapacityError(err error) bool {
+	return e.inner.IsCapacityError(err)
+}
+
+// IsArchived reports whether the inner encoder wraps an archived library.
+func (e *paddingEncoder) IsArchived() bool {
+	return IsArchived(e.inner)
+}
+
+// RendersOversized reports true: the border is added outside PixelSize.
+func (e *paddingEncoder) RendersOversized() bool {
+	return true
+}
+
+// RequiresCGO reports whether the inner encoder wraps a C library.
+func (e *paddingEncoder) RequiresCGO() bool {
+	return RequiresCGO(e.inner)
+}
+
+// PadImage returns img surrounded by a white border of modules modules per
+// side. The module size is the width of the symbol, found as the bounding
+// box of dark pixels, divided by moduleCount, rounded to whole pixels so
+// the border does not itself introduce fractional modules.
+func PadImage(img image.Image, moduleCount, modules int) (*image.RGBA, error) {
+	if img == nil {
+		return nil, fmt.Errorf("image is nil")
+	}
+	if moduleCount <= 0 {
+		return nil, fmt.Errorf("invalid module count %d", moduleCount)
+	}
+
+	symbol, ok := symbolBounds(img)
+	if !ok {
+		return nil, fmt.Errorf("no dark pixels found")
+	}
+	border := int(math.Round(float64(symbol.Dx())/float64(moduleCount))) * modules
+
+	b := img.Bounds()
+	padded := image.NewRGBA(image.Rect(0, 0, b.Dx()+2*border, b.Dy()+2*border))
+	draw.Draw(padded, padded.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(padded, image.Rect(border, border, border+b.Dx(), border+b.Dy()), img, b.Min, draw.Src)
+	return padded, nil
+}
+
+// symbolBounds returns the bounding box of pixels darker than mid-gray.
+// The finder patterns reach three symbol corners, so the box is the symbol.
+func symbolBounds(img image.Image) (image.Rectangle, bool) {
+	b := img.Bounds()
+	minX, minY := b.Max.X, b.Max.Y
+	maxX, maxY := b.Min.X-1, b.Min.Y-1
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y >= 128 {
+				continue
+			}
+			minX = min(minX, x)
+			minY = min(minY, y)
+			maxX = max(maxX, x)
+			maxY = max(maxY, y)
+		}
+	}
+
+	if maxX < minX {
+		return image.Rectangle{}, false
+	}
+	return image.Rect(minX, minY, maxX+1, maxY+1), true
+}
@@ -0,0 +1,102 @@
+package encoders
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+)
+
+// noQuietZoneEncoder crops the inner encoder's output to the bare symbol,
+// like a library that renders no quiet zone.
+type noQuietZoneEncoder struct {
+	Encoder
+}
+
+func (e *noQuietZoneEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, error) {
+	result, err := e.Encoder.Encode(data, opts)
+	if err != nil {
+		return result, err
+	}
+	symbol, ok := symbolBounds(result.Image)
+	if !ok {
+		return result, nil
+	}
+	cropped := image.NewRGBA(image.Rect(0, 0, symbol.Dx(), symbol.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), result.Image, symbol.Min, draw.Src)
+	result.Image = cropped
+	return result, nil
+}
+
+func TestWithPadding(t *testing.T) {
+	data := []byte("HELLO WORLD")
+	opts := EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionM, PixelSize: 256}
+	bare := &noQuietZoneEncoder{Encoder: &Skip2Encoder{}}
+	dec := &decoders.GoqrDecoder{}
+
+	// goqr needs a light margin to find the finder patterns; without a
+	// quiet zone the symbol is unreadable
+	result, err := bare.Encode(data, opts)
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	if _, err := dec.Decode(result.Image); err == nil {
+		t.Fatal("Decode() of a symbol without a quiet zone succeeded; the case is no longer borderline")
+	}
+
+	padded := WithPadding(bare, 4)
+	if padded.Name() != "skip2/go-qrcode+pad4" {
+		t.Errorf("Name() = %q, want %q", padded.Name(), "skip2/go-qrcode+pad4")
+	}
+	paddedResult, err := padded.Encode(data, opts)
+	if err != nil {
+		t.Fatalf("padded Encode() failed: %v", err)
+	}
+	if paddedResult.Version != result.Version {
+		t.Errorf("padded Version = %d, want %d", paddedResult.Version, result.Version)
+	}
+
+	// The border is 4 modules of the rendered module size on each side
+	moduleCount := 17 + 4*result.Version
+	modulePx := int(math.Round(float64(result.Image.Bounds().Dx()) / float64(moduleCount)))
+	if got, want := paddedResult.Image.Bounds().Dx(), result.Image.Bounds().Dx()+2*4*modulePx; got != want {
+		t.Errorf("padded width = %d, want %d", got, want)
+	}
+
+	got, err := dec.Decode(paddedResult.Image)
+	if err != nil {
+		t.Fatalf("Decode() of the padded symbol failed: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("Decode() = %q, want %q", got, data)
+	}
+}
+
+func TestWithPadding_ForwardsInner(t *testing.T) {
+	enc := WithPadding(&GozxingEncoder{}, 4)
+	if IsArchived(enc) || RequiresCGO(enc) {
+		t.Error("WithPadding() of a maintained pure Go encoder should be neither archived nor CGO")
+	}
+
+	_, err := enc.Encode(make([]byte, 10000), EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionH, PixelSize: 256})
+	if err == nil || !enc.IsCapacityError(err) {
+		t.Errorf("Encode() of oversized data error = %v, want a capacity error", err)
+	}
+}
+
+func TestPadImage_Errors(t *testing.T) {
+	blank := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	draw.Draw(blank, blank.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	if _, err := PadImage(nil, 21, 4); err == nil {
+		t.Error("PadImage(nil) should fail")
+	}
+	if _, err := PadImage(blank, 0, 4); err == nil {
+		t.Error("PadImage() with module count 0 should fail")
+	}
+	if _, err := PadImage(blank, 21, 4); err == nil {
+		t.Error("PadImage() of a blank image should fail")
+	}
+}
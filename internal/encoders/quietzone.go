@@ -0,0 +1,48 @@
+package encoders
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/13rac1/qr-library-test/internal/raster"
+)
+
+// quietZoneModules is the quiet zone ISO 18004 requires around a standard
+// QR symbol, in modules per side.
+const quietZoneModules = 4
+
+// ErrPixelSizeTooSmall indicates the requested pixel size cannot hold the
+// symbol and its quiet zone at one pixel per module or more.
+var ErrPixelSizeTooSmall = errors.New("pixel size too small for symbol and quiet zone")
+
+// fitQuietZone scales symbol, rendered at one pixel per module with no
+// margin, so it and a quietZoneModules quiet zone on each side fill
+// pixelSize, and centers it on a white canvas. The module size is
+// pixelSize/(modules+2*quietZoneModules), the testdata.CalculateModulePixelSize
+// formula with both margins counted, and is not floored: fractional module
+// sizes are what the benchmark measures. Encoders whose libraries size only
+// the data area use it to honor EncodeOptions.PixelSizeIncludesQuietZone.
+func fitQuietZone(symbol image.Image, pixelSize int) (*image.RGBA, error) {
+	modules := symbol.Bounds().Dx()
+	total := modules + 2*quietZoneModules
+	if pixelSize < total {
+		return nil, fmt.Errorf("%w: %dpx for %d modules", ErrPixelSizeTooSmall, pixelSize, modules)
+	}
+	side := pixelSize * modules / total
+	return centerOnCanvas(raster.ScaleNearest(symbol, side), pixelSize), nil
+}
+
+// centerOnCanvas draws img centered on a white size×size canvas. The
+// leftover pixels go to the right and bottom when they do not split evenly.
+func centerOnCanvas(img image.Image, size int) *image.RGBA {
+	canvas := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+
+	b := img.Bounds()
+	at := image.Pt((size-b.Dx())/2, (size-b.Dy())/2)
+	draw.Draw(canvas, image.Rectangle{Min: at, Max: at.Add(b.Size())}, img, b.Min, draw.Src)
+	return canvas
+}
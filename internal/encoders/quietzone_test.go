@@ -0,0 +1,59 @@
+package encoders
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncoders_PixelSizeIncludesQuietZone(t *testing.T) {
+	data := []byte("HELLO WORLD 12345")
+
+	for _, enc := range append(GetAllEncoders(), &SVGEncoder{}) {
+		for _, pixelSize := range []int{200, 256, 333, 512} {
+			opts := EncodeOptions{
+				ErrorCorrectionLevel:       ErrorCorrectionM,
+				PixelSize:                  pixelSize,
+				PixelSizeIncludesQuietZone: true,
+			}
+			result, err := enc.Encode(data, opts)
+			if err != nil {
+				t.Fatalf("%s at %dpx: Encode() failed: %v", enc.Name(), pixelSize, err)
+			}
+
+			// The whole image, quiet zone included, is PixelSize
+			b := result.Image.Bounds()
+			if b.Dx() != pixelSize || b.Dy() != pixelSize {
+				t.Errorf("%s at %dpx: image is %dx%d, want %dx%d", enc.Name(), pixelSize, b.Dx(), b.Dy(), pixelSize, pixelSize)
+			}
+
+			// ...and leaves the 4-module quiet zone around the symbol, give or
+			// take a couple of pixels where a library splits odd slack
+			symbol, ok := symbolBounds(result.Image)
+			if !ok {
+				t.Fatalf("%s at %dpx: no symbol found", enc.Name(), pixelSize)
+			}
+			modulePx := float64(symbol.Dx()) / float64(17+4*result.Version)
+			margin := min(symbol.Min.X-b.Min.X, symbol.Min.Y-b.Min.Y, b.Max.X-symbol.Max.X, b.Max.Y-symbol.Max.Y)
+			if float64(margin) < quietZoneModules*modulePx-2 {
+				t.Errorf("%s at %dpx: quiet zone is %dpx, want at least %d modules of %.2fpx",
+					enc.Name(), pixelSize, margin, quietZoneModules, modulePx)
+			}
+		}
+	}
+}
+
+func TestEncoders_PixelSizeIncludesQuietZone_TooSmall(t *testing.T) {
+	// 29 modules (version 3) plus the quiet zone cannot fit in 30px
+	data := []byte("HELLO WORLD HELLO WORLD HELLO WORLD")
+	opts := EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionM, PixelSize: 30, PixelSizeIncludesQuietZone: true}
+
+	for _, enc := range []Encoder{&BoombulerEncoder{}, &YeqownEncoder{}} {
+		_, err := enc.Encode(data, opts)
+		if !errors.Is(err, ErrPixelSizeTooSmall) {
+			t.Errorf("%s: Encode() error = %v, want ErrPixelSizeTooSmall", enc.Name(), err)
+		}
+		if !enc.IsCapacityError(err) {
+			t.Errorf("%s: IsCapacityError(%v) = false, want a capacity skip", enc.Name(), err)
+		}
+	}
+}
@@ -3,16 +3,34 @@ package encoders
 import "github.com/13rac1/qr-library-test/internal/config"
 
 // GetAvailableEncoders returns the list of encoders available based on configuration.
-// Always includes pure Go encoders.
-// Conditionally includes CGO encoders if CGO is enabled at build time and not skipped.
+// Always includes pure Go encoders that wrap maintained libraries.
+// Leaves out:
+//   - archived encoders (ArchivedEncoder) if cfg.SkipArchived
+//   - CGO encoders (CGOEncoder) if cfg.SkipCGO
+//
+// No current encoder is archived or uses CGO; the filters keep the encoder
+// registry in step with the decoder registry as libraries are added.
+// With cfg.StructuredAppend, the structured-append encoder is added.
 func GetAvailableEncoders(cfg *config.Config) []Encoder {
-	encoders := []Encoder{
-		&Skip2Encoder{},
-		&BoombulerEncoder{},
-		&YeqownEncoder{},
-		&GozxingEncoder{},
+	all := GetAllEncoders()
+	if cfg.StructuredAppend {
+		all = append(all, &StructuredAppendEncoder{})
 	}
+	return filterEncoders(cfg, all)
+}
 
+// filterEncoders returns the encoders in all that cfg's skip flags allow.
+func filterEncoders(cfg *config.Config, all []Encoder) []Encoder {
+	encoders := make([]Encoder, 0, len(all))
+	for _, enc := range all {
+		if cfg.SkipArchived && IsArchived(enc) {
+			continue
+		}
+		if cfg.SkipCGO && RequiresCGO(enc) {
+			continue
+		}
+		encoders = append(encoders, enc)
+	}
 	return encoders
 }
 
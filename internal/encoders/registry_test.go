@@ -0,0 +1,111 @@
+package encoders
+
+import (
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+)
+
+// archivedStubEncoder is a Skip2Encoder reporting an archived library.
+type archivedStubEncoder struct{ Skip2Encoder }
+
+func (e *archivedStubEncoder) Name() string     { return "stub/archived" }
+func (e *archivedStubEncoder) IsArchived() bool { return true }
+
+// cgoStubEncoder is a Skip2Encoder reporting a CGO library.
+type cgoStubEncoder struct{ Skip2Encoder }
+
+func (e *cgoStubEncoder) Name() string      { return "stub/cgo" }
+func (e *cgoStubEncoder) RequiresCGO() bool { return true }
+
+func TestGetAvailableEncoders_DefaultConfig(t *testing.T) {
+	encoders := GetAvailableEncoders(config.DefaultConfig())
+
+	if len(encoders) != 4 {
+		t.Errorf("GetAvailableEncoders() returned %d encoders, want 4", len(encoders))
+	}
+
+	names := make(map[string]bool)
+	for _, enc := range encoders {
+		names[enc.Name()] = true
+	}
+	for _, name := range []string{"skip2/go-qrcode", "boombuler/barcode", "yeqown/go-qrcode", "makiuchi-d/gozxing"} {
+		if !names[name] {
+			t.Errorf("GetAvailableEncoders() missing encoder %q", name)
+		}
+	}
+}
+
+func TestGetAvailableEncoders_SkipFlagsKeepCurrentEncoders(t *testing.T) {
+	// No registered encoder is archived or uses CGO
+	cfg := config.DefaultConfig()
+	cfg.SkipArchived = true
+	cfg.SkipCGO = true
+
+	if got := len(GetAvailableEncoders(cfg)); got != 4 {
+		t.Errorf("GetAvailableEncoders() with both skip flags returned %d encoders, want 4", got)
+	}
+}
+
+func TestGetAvailableEncoders_StructuredAppend(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.StructuredAppend = true
+
+	encoders := GetAvailableEncoders(cfg)
+	if len(encoders) != 5 {
+		t.Fatalf("GetAvailableEncoders() with StructuredAppend returned %d encoders, want 5", len(encoders))
+	}
+	if _, ok := encoders[4].(SequenceEncoder); !ok {
+		t.Errorf("last encoder %q does not implement SequenceEncoder", encoders[4].Name())
+	}
+}
+
+func TestFilterEncoders(t *testing.T) {
+	all := []Encoder{&Skip2Encoder{}, &archivedStubEncoder{}, &cgoStubEncoder{}}
+
+	tests := []struct {
+		name         string
+		skipArchived bool
+		skipCGO      bool
+		want         []string
+	}{
+		{"no skips", false, false, []string{"skip2/go-qrcode", "stub/archived", "stub/cgo"}},
+		{"skip archived", true, false, []string{"skip2/go-qrcode", "stub/cgo"}},
+		{"skip CGO", false, true, []string{"skip2/go-qrcode", "stub/archived"}},
+		{"skip both", true, true, []string{"skip2/go-qrcode"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.SkipArchived = tt.skipArchived
+			cfg.SkipCGO = tt.skipCGO
+
+			got := filterEncoders(cfg, all)
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterEncoders() returned %d encoders, want %d", len(got), len(tt.want))
+			}
+			for i, enc := range got {
+				if enc.Name() != tt.want[i] {
+					t.Errorf("filterEncoders()[%d] = %q, want %q", i, enc.Name(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetAllEncoders_UniqueNames(t *testing.T) {
+	// Results are keyed by library name, so a collision would merge two
+	// encoders' results
+	seen := make(map[string]bool)
+	for i, enc := range GetAllEncoders() {
+		name := enc.Name()
+		if name == "" {
+			t.Errorf("encoder %d has an empty name", i)
+		}
+		if seen[name] {
+			t.Errorf("encoder name %q is registered more than once", name)
+		}
+		seen[name] = true
+	}
+}
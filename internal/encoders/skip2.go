@@ -7,6 +7,7 @@ import (
 	"image"
 	_ "image/png"
 	"strings"
+	"time"
 
 	"github.com/skip2/go-qrcode"
 )
@@ -18,6 +19,9 @@ import (
 // Note: skip2/go-qrcode treats input as a string. Binary data containing
 // null bytes and special characters may not round-trip correctly through
 // the encode→decode cycle. This is a library limitation, not a bug in this wrapper.
+//
+// Natively PixelSize is the whole image: skip2 renders a 4-module quiet zone
+// and centers the symbol, so PixelSizeIncludesQuietZone changes nothing.
 type Skip2Encoder struct{}
 
 // Name returns the encoder identifier.
@@ -28,8 +32,20 @@ func (e *Skip2Encoder) Name() string {
 // Encode generates a QR code image from the input data.
 // The skip2/go-qrcode library generates PNG bytes which are decoded back to image.Image.
 func (e *Skip2Encoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, error) {
+	result, _, err := e.encode(data, opts)
+	return result, err
+}
+
+// encode is Encode with each phase timed.
+func (e *Skip2Encoder) encode(data []byte, opts EncodeOptions) (EncodeResult, EncodeTimings, error) {
+	var timings EncodeTimings
+
 	if len(data) == 0 {
-		return EncodeResult{}, fmt.Errorf("skip2: cannot encode empty data")
+		return EncodeResult{}, timings, fmt.Errorf("skip2: cannot encode empty data")
+	}
+
+	if opts.MicroQR {
+		return EncodeResult{}, timings, fmt.Errorf("skip2: %w", ErrMicroQRUnsupported)
 	}
 
 	// Map error correction level to qrcode package constants
@@ -44,34 +60,62 @@ func (e *Skip2Encoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, er
 	case ErrorCorrectionH:
 		level = qrcode.Highest
 	default:
-		return EncodeResult{}, fmt.Errorf("skip2: invalid error correction level %q", opts.ErrorCorrectionLevel)
+		return EncodeResult{}, timings, fmt.Errorf("skip2: invalid error correction level %q", opts.ErrorCorrectionLevel)
 	}
 
 	// Create QRCode struct to access version
-	qr, err := qrcode.New(string(data), level)
+	start := time.Now()
+	var qr *qrcode.QRCode
+	var err error
+	if opts.ForceVersion > 0 {
+		qr, err = qrcode.NewWithForcedVersion(string(data), opts.ForceVersion, level)
+	} else {
+		qr, err = qrcode.New(string(data), level)
+	}
+	timings.QRConstruct = time.Since(start)
 	if err != nil {
-		return EncodeResult{}, fmt.Errorf("skip2: encode failed: %w", err)
+		return EncodeResult{}, timings, fmt.Errorf("skip2: encode failed: %w", err)
 	}
 
 	// Generate PNG at requested size
+	start = time.Now()
 	pngBytes, err := qr.PNG(opts.PixelSize)
+	timings.ImageEncode = time.Since(start)
 	if err != nil {
-		return EncodeResult{}, fmt.Errorf("skip2: PNG generation failed: %w", err)
+		return EncodeResult{}, timings, fmt.Errorf("skip2: PNG generation failed: %w", err)
 	}
 
 	// Decode PNG bytes to image.Image
+	start = time.Now()
 	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	timings.ImageDecode = time.Since(start)
 	if err != nil {
-		return EncodeResult{}, fmt.Errorf("skip2: PNG decode failed: %w", err)
+		return EncodeResult{}, timings, fmt.Errorf("skip2: PNG decode failed: %w", err)
 	}
 
 	return EncodeResult{
 		Image:   img,
 		Version: qr.VersionNumber,
-	}, nil
+	}, timings, nil
+}
+
+// EncodeWithTimings generates a QR code image and times skip2's phases:
+// building the symbol, writing the PNG, and decoding it back.
+func (e *Skip2Encoder) EncodeWithTimings(data []byte, opts EncodeOptions) (image.Image, ModuleInfo, EncodeTimings, error) {
+	result, timings, err := e.encode(data, opts)
+	if err != nil {
+		return nil, ModuleInfo{}, timings, err
+	}
+	return result.Image, result.ModuleInfo(), timings, nil
 }
 
 // IsCapacityError returns true if the error indicates data exceeds QR capacity.
 func (e *Skip2Encoder) IsCapacityError(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "content too long to encode")
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "content too long to encode") ||
+		strings.Contains(msg, "content too large for fixed size") ||
+		strings.Contains(msg, "length too long to be represented")
 }
@@ -0,0 +1,200 @@
+package encoders
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+
+	"rsc.io/qr/coding"
+)
+
+// MaxStructuredAppendSymbols is the most symbols one structured-append
+// sequence can hold: the header stores the symbol count in 4 bits.
+const MaxStructuredAppendSymbols = 16
+
+// structuredAppendHeaderBits is the size of the structured-append header:
+// the 4-bit mode indicator, 4-bit symbol index, 4-bit symbol count, and
+// 8-bit parity.
+const structuredAppendHeaderBits = 20
+
+// ErrStructuredAppendTooLarge indicates the data does not fit in
+// MaxStructuredAppendSymbols version 40 symbols.
+var ErrStructuredAppendTooLarge = errors.New("data exceeds structured append capacity")
+
+// ErrSequenceRequired indicates the data needs more than one symbol, which
+// only StructuredAppendEncoder.EncodeSequence can return.
+var ErrSequenceRequired = errors.New("data needs a structured append sequence")
+
+// StructuredAppendEncoder encodes with EncodeStructuredAppend. It is not in
+// GetAllEncoders; cfg.StructuredAppend adds it to a run.
+type StructuredAppendEncoder struct{}
+
+// Name returns the encoder identifier.
+func (e *StructuredAppendEncoder) Name() string {
+	return "rsc.io/qr/structured-append"
+}
+
+// Encode encodes data that fits one symbol. Larger data fails with
+// ErrSequenceRequired; use EncodeSequence.
+func (e *StructuredAppendEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, error) {
+	results, err := e.EncodeSequence(data, opts)
+	if err != nil {
+		return EncodeResult{}, err
+	}
+	if len(results) > 1 {
+		return EncodeResult{}, fmt.Errorf("structured append: %w: %d symbols", ErrSequenceRequired, len(results))
+	}
+	return results[0], nil
+}
+
+// EncodeSequence encodes data as one symbol or a structured-append sequence.
+func (e *StructuredAppendEncoder) EncodeSequence(data []byte, opts EncodeOptions) ([]EncodeResult, error) {
+	return EncodeStructuredAppend(data, opts)
+}
+
+// IsCapacityError returns true if the data exceeds even a full sequence.
+func (e *StructuredAppendEncoder) IsCapacityError(err error) bool {
+	return errors.Is(err, ErrStructuredAppendTooLarge) || errors.Is(err, ErrPixelSizeTooSmall)
+}
+
+// structuredAppendHeader is the coding.Encoding of a structured-append
+// header, written before the data segment of each symbol in a sequence.
+type structuredAppendHeader struct {
+	index, count int
+	parity       byte
+}
+
+func (h structuredAppendHeader) Check() error {
+	if h.count < 2 || h.count > MaxStructuredAppendSymbols || h.index < 0 || h.index >= h.count {
+		return fmt.Errorf("invalid structured append symbol %d of %d", h.index+1, h.count)
+	}
+	return nil
+}
+
+func (h structuredAppendHeader) Bits(v coding.Version) int {
+	return structuredAppendHeaderBits
+}
+
+func (h structuredAppendHeader) Encode(b *coding.Bits, v coding.Version) {
+	b.Write(3, 4)
+	b.Write(uint(h.index), 4)
+	b.Write(uint(h.count-1), 4)
+	b.Write(uint(h.parity), 8)
+}
+
+// EncodeStructuredAppend encodes data as a Structured Append sequence
+// (ISO 18004 section 8): when data exceeds the byte-mode capacity of a
+// version 40 symbol at opts.ErrorCorrectionLevel, it is split evenly over
+// as few symbols as hold it, up to MaxStructuredAppendSymbols, each
+// carrying its position in the sequence and the parity of the whole
+// payload. Data that fits one symbol is encoded as a single plain symbol.
+//
+// None of the wrapped encoder libraries can write the structured-append
+// header, so the symbols are built with rsc.io/qr's low-level coding
+// package, using mask 0 as its own encoder does. Each symbol uses the
+// smallest version that holds its part and is rendered with a 4-module
+// quiet zone centered in an opts.PixelSize square image. Results are in
+// sequence order; DecodeStructuredAppend in package decoders reassembles
+// them.
+func EncodeStructuredAppend(data []byte, opts EncodeOptions) ([]EncodeResult, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("structured append: cannot encode empty data")
+	}
+
+	var level coding.Level
+	switch opts.ErrorCorrectionLevel {
+	case ErrorCorrectionL:
+		level = coding.L
+	case ErrorCorrectionM:
+		level = coding.M
+	case ErrorCorrectionQ:
+		level = coding.Q
+	case ErrorCorrectionH:
+		level = coding.H
+	default:
+		return nil, fmt.Errorf("structured append: invalid error correction level %q", opts.ErrorCorrectionLevel)
+	}
+
+	// Byte-mode capacity of a version 40 symbol, with and without a header
+	single := (coding.Version(40).DataBytes(level)*8 - coding.String("").Bits(40)) / 8
+	perSymbol := (coding.Version(40).DataBytes(level)*8 - structuredAppendHeaderBits - coding.String("").Bits(40)) / 8
+
+	if len(data) <= single {
+		result, err := encodeStructuredSymbol(level, opts.PixelSize, coding.String(data))
+		if err != nil {
+			return nil, err
+		}
+		return []EncodeResult{result}, nil
+	}
+
+	count := (len(data) + perSymbol - 1) / perSymbol
+	if count > MaxStructuredAppendSymbols {
+		return nil, fmt.Errorf("structured append: %w: %d bytes need %d symbols at level %s, max %d",
+			ErrStructuredAppendTooLarge, len(data), count, opts.ErrorCorrectionLevel, MaxStructuredAppendSymbols)
+	}
+
+	var parity byte
+	for _, b := range data {
+		parity ^= b
+	}
+
+	partSize := (len(data) + count - 1) / count
+	results := make([]EncodeResult, 0, count)
+	for i := 0; i < count; i++ {
+		part := data[i*partSize : min((i+1)*partSize, len(data))]
+		header := structuredAppendHeader{index: i, count: count, parity: parity}
+		result, err := encodeStructuredSymbol(level, opts.PixelSize, header, coding.String(part))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// encodeStructuredSymbol encodes segments in the smallest version that
+// holds them and renders the symbol in a pixelSize square image.
+func encodeStructuredSymbol(level coding.Level, pixelSize int, segments ...coding.Encoding) (EncodeResult, error) {
+	for v := coding.Version(1); v <= 40; v++ {
+		bits := 0
+		for _, s := range segments {
+			bits += s.Bits(v)
+		}
+		if bits > v.DataBytes(level)*8 {
+			continue
+		}
+
+		plan, err := coding.NewPlan(v, level, 0)
+		if err != nil {
+			return EncodeResult{}, fmt.Errorf("structured append: %w", err)
+		}
+		code, err := plan.Encode(segments...)
+		if err != nil {
+			return EncodeResult{}, fmt.Errorf("structured append: encode failed: %w", err)
+		}
+
+		img, err := renderCode(code, pixelSize)
+		if err != nil {
+			return EncodeResult{}, fmt.Errorf("structured append: %w", err)
+		}
+		return EncodeResult{Image: img, Version: int(v)}, nil
+	}
+	return EncodeResult{}, fmt.Errorf("structured append: symbol exceeds version 40 capacity")
+}
+
+// renderCode draws code at one pixel per module and scales it, with its
+// quiet zone, into pixelSize.
+func renderCode(code *coding.Code, pixelSize int) (image.Image, error) {
+	img := image.NewGray(image.Rect(0, 0, code.Size, code.Size))
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			c := color.Gray{Y: 255}
+			if code.Black(x, y) {
+				c = color.Gray{Y: 0}
+			}
+			img.SetGray(x, y, c)
+		}
+	}
+	return fitQuietZone(img, pixelSize)
+}
@@ -0,0 +1,96 @@
+package encoders
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"math/rand"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+)
+
+func TestEncodeStructuredAppend_RoundTrip(t *testing.T) {
+	// 3000 bytes exceed the 2331-byte capacity of version 40-M
+	data := make([]byte, 3000)
+	rand.New(rand.NewSource(1)).Read(data)
+	opts := EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionM, PixelSize: 740}
+
+	results, err := EncodeStructuredAppend(data, opts)
+	if err != nil {
+		t.Fatalf("EncodeStructuredAppend() failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("EncodeStructuredAppend() returned %d symbols, want 2", len(results))
+	}
+
+	imgs := make([]image.Image, len(results))
+	for i, result := range results {
+		if b := result.Image.Bounds(); b.Dx() != opts.PixelSize || b.Dy() != opts.PixelSize {
+			t.Errorf("symbol %d is %dx%d, want %dx%d", i, b.Dx(), b.Dy(), opts.PixelSize, opts.PixelSize)
+		}
+		if result.Version < 1 || result.Version > 40 {
+			t.Errorf("symbol %d version = %d, want 1-40", i, result.Version)
+		}
+		// Reverse the order: reassembly follows the header, not the images
+		imgs[len(results)-1-i] = result.Image
+	}
+
+	decoded, err := decoders.DecodeStructuredAppend(imgs)
+	if err != nil {
+		t.Fatalf("DecodeStructuredAppend() failed: %v", err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Errorf("DecodeStructuredAppend() returned %d bytes, want the original %d", len(decoded), len(data))
+	}
+
+	// A partial sequence cannot be reassembled
+	if _, err := decoders.DecodeStructuredAppend(imgs[:1]); err == nil {
+		t.Error("DecodeStructuredAppend() with one of two symbols should fail")
+	}
+}
+
+func TestEncodeStructuredAppend_SingleSymbol(t *testing.T) {
+	data := []byte("HELLO WORLD")
+	results, err := EncodeStructuredAppend(data, EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionM, PixelSize: 400})
+	if err != nil {
+		t.Fatalf("EncodeStructuredAppend() failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("EncodeStructuredAppend() returned %d symbols, want 1", len(results))
+	}
+
+	decoded, err := decoders.DecodeStructuredAppend([]image.Image{results[0].Image})
+	if err != nil {
+		t.Fatalf("DecodeStructuredAppend() failed: %v", err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Errorf("DecodeStructuredAppend() = %q, want %q", decoded, data)
+	}
+}
+
+func TestEncodeStructuredAppend_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		opts    EncodeOptions
+		wantErr error
+	}{
+		{"empty data", nil, EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionM, PixelSize: 400}, nil},
+		{"invalid level", []byte("A"), EncodeOptions{ErrorCorrectionLevel: "X", PixelSize: 400}, nil},
+		{"too large", make([]byte, 17*2953), EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionL, PixelSize: 800}, ErrStructuredAppendTooLarge},
+		{"pixel size too small", []byte("A"), EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionM, PixelSize: 20}, ErrPixelSizeTooSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EncodeStructuredAppend(tt.data, tt.opts)
+			if err == nil {
+				t.Fatal("EncodeStructuredAppend() should fail")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("EncodeStructuredAppend() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
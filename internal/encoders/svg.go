@@ -0,0 +1,120 @@
+// Package encoders provides QR code encoder implementations.
+package encoders
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/13rac1/qr-library-test/internal/raster"
+	"github.com/skip2/go-qrcode"
+)
+
+// svgQuietZone is the quiet zone in modules added around the SVG symbol.
+const svgQuietZone = 4
+
+// SVGEncoder emits QR codes as SVG and rasterizes them with antialiasing.
+// Many production systems render QR codes as SVG and let the browser or OS
+// rasterize at display size, so module edges that fall between pixels become
+// gray. This encoder reproduces that vector→raster path to characterize
+// decoder sensitivity to antialiasing.
+//
+// Module layout comes from skip2/go-qrcode; each dark module becomes one
+// <rect> in a viewBox measured in modules.
+//
+// PixelSize is the whole image, the symbol plus its 4-module quiet zone, so
+// PixelSizeIncludesQuietZone changes nothing.
+//
+// It is not registered in the encoder registry; use it directly for analysis.
+type SVGEncoder struct{}
+
+// Name returns the encoder identifier.
+func (e *SVGEncoder) Name() string {
+	return "svg/rasterized"
+}
+
+// Encode generates an SVG QR code and rasterizes it at opts.PixelSize.
+func (e *SVGEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, error) {
+	svg, version, err := e.EncodeSVG(data, opts)
+	if err != nil {
+		return EncodeResult{}, err
+	}
+
+	img, err := raster.RasterizeSVG(svg, opts.PixelSize)
+	if err != nil {
+		return EncodeResult{}, fmt.Errorf("svg: rasterize failed: %w", err)
+	}
+
+	return EncodeResult{
+		Image:   img,
+		Version: version,
+	}, nil
+}
+
+// EncodeSVG generates the SVG document for data and returns it with the QR version.
+// opts.PixelSize sets the SVG width and height attributes only; the viewBox is in modules.
+func (e *SVGEncoder) EncodeSVG(data []byte, opts EncodeOptions) ([]byte, int, error) {
+	if len(data) == 0 {
+		return nil, 0, fmt.Errorf("svg: cannot encode empty data")
+	}
+
+	if opts.MicroQR {
+		return nil, 0, fmt.Errorf("svg: %w", ErrMicroQRUnsupported)
+	}
+
+	var level qrcode.RecoveryLevel
+	switch opts.ErrorCorrectionLevel {
+	case ErrorCorrectionL:
+		level = qrcode.Low
+	case ErrorCorrectionM:
+		level = qrcode.Medium
+	case ErrorCorrectionQ:
+		level = qrcode.High
+	case ErrorCorrectionH:
+		level = qrcode.Highest
+	default:
+		return nil, 0, fmt.Errorf("svg: invalid error correction level %q", opts.ErrorCorrectionLevel)
+	}
+
+	var qr *qrcode.QRCode
+	var err error
+	if opts.ForceVersion > 0 {
+		qr, err = qrcode.NewWithForcedVersion(string(data), opts.ForceVersion, level)
+	} else {
+		qr, err = qrcode.New(string(data), level)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("svg: encode failed: %w", err)
+	}
+
+	qr.DisableBorder = true
+	bitmap := qr.Bitmap()
+	size := len(bitmap) + 2*svgQuietZone
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		opts.PixelSize, opts.PixelSize, size, size)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", size, size)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="1" height="1" fill="#000000"/>`+"\n",
+					x+svgQuietZone, y+svgQuietZone)
+			}
+		}
+	}
+	buf.WriteString("</svg>\n")
+
+	return buf.Bytes(), qr.VersionNumber, nil
+}
+
+// IsCapacityError returns true if the error indicates data exceeds QR capacity.
+func (e *SVGEncoder) IsCapacityError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "content too long to encode") ||
+		strings.Contains(msg, "content too large for fixed size") ||
+		strings.Contains(msg, "length too long to be represented")
+}
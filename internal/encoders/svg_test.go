@@ -0,0 +1,103 @@
+package encoders
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/raster"
+)
+
+func TestSVGEncoder_EncodeSVG(t *testing.T) {
+	enc := &SVGEncoder{}
+
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionM,
+		PixelSize:            440,
+	}
+
+	svg, version, err := enc.EncodeSVG([]byte("Hello, QR Code!"), opts)
+	if err != nil {
+		t.Fatalf("EncodeSVG() failed: %v", err)
+	}
+
+	if !bytes.HasPrefix(svg, []byte("<svg ")) {
+		t.Errorf("EncodeSVG() output does not start with <svg: %q", svg[:20])
+	}
+
+	if version < 1 || version > 40 {
+		t.Errorf("Version = %d, want 1..40", version)
+	}
+
+	// viewBox is measured in modules, including the quiet zone
+	size := 17 + 4*version + 2*svgQuietZone
+	viewBox := `viewBox="0 0 ` + formatInt(size) + " " + formatInt(size) + `"`
+	if !strings.Contains(string(svg), viewBox) {
+		t.Errorf("EncodeSVG() output missing %s", viewBox)
+	}
+}
+
+func TestSVGEncoder_RasterizeAndDecode(t *testing.T) {
+	enc := &SVGEncoder{}
+	data := []byte("Hello, QR Code!")
+
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionM,
+		PixelSize:            440,
+	}
+
+	svg, _, err := enc.EncodeSVG(data, opts)
+	if err != nil {
+		t.Fatalf("EncodeSVG() failed: %v", err)
+	}
+
+	img, err := raster.RasterizeSVG(svg, 440)
+	if err != nil {
+		t.Fatalf("RasterizeSVG() failed: %v", err)
+	}
+
+	if img.Bounds().Dx() != 440 || img.Bounds().Dy() != 440 {
+		t.Errorf("Rasterized size = %v, want 440x440", img.Bounds())
+	}
+
+	decoded, err := (&decoders.GozxingDecoder{}).Decode(img)
+	if err != nil {
+		t.Fatalf("gozxing failed to decode rasterized SVG: %v", err)
+	}
+
+	if !bytes.Equal(decoded, data) {
+		t.Errorf("Decoded = %q, want %q", decoded, data)
+	}
+}
+
+func TestSVGEncoder_Encode(t *testing.T) {
+	enc := &SVGEncoder{}
+
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionM,
+		PixelSize:            440,
+	}
+
+	result, err := enc.Encode([]byte("Hello, QR Code!"), opts)
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+
+	if result.Image.Bounds().Dx() != 440 {
+		t.Errorf("Image width = %d, want 440", result.Image.Bounds().Dx())
+	}
+
+	if result.Version < 1 {
+		t.Errorf("Version = %d, want >= 1", result.Version)
+	}
+}
+
+func TestSVGEncoder_Encode_EmptyData(t *testing.T) {
+	enc := &SVGEncoder{}
+
+	_, err := enc.Encode([]byte{}, EncodeOptions{ErrorCorrectionLevel: ErrorCorrectionM, PixelSize: 440})
+	if err == nil {
+		t.Error("Encode() with empty data should fail")
+	}
+}
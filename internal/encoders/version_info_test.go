@@ -0,0 +1,64 @@
+package encoders
+
+import (
+	"testing"
+)
+
+func TestEncoders_ReportVersion(t *testing.T) {
+	data := []byte("Hello, QR Code!")
+
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionM,
+		PixelSize:            256,
+	}
+
+	supported := []Encoder{
+		&Skip2Encoder{},
+		&BoombulerEncoder{},
+		&YeqownEncoder{},
+		&GozxingEncoder{},
+		&SVGEncoder{},
+	}
+
+	for _, enc := range supported {
+		t.Run(enc.Name(), func(t *testing.T) {
+			result, err := enc.Encode(data, opts)
+			if err != nil {
+				t.Fatalf("Encode() failed: %v", err)
+			}
+
+			info := result.ModuleInfo()
+			if info.Version < 1 || info.Version > 40 {
+				t.Errorf("Version = %d, want 1..40", info.Version)
+			}
+
+			if want := 17 + 4*info.Version; info.ModuleCount != want {
+				t.Errorf("ModuleCount = %d, want %d", info.ModuleCount, want)
+			}
+
+			if info.MicroQR {
+				t.Error("MicroQR should be false for a standard QR code")
+			}
+		})
+	}
+}
+
+func TestEncodeResult_ModuleInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		result EncodeResult
+		want   ModuleInfo
+	}{
+		{"version 7", EncodeResult{Version: 7}, ModuleInfo{Version: 7, ModuleCount: 45}},
+		{"micro M3", EncodeResult{Version: 3, MicroQR: true}, ModuleInfo{Version: 3, ModuleCount: 15, MicroQR: true}},
+		{"unknown", EncodeResult{Version: -1}, ModuleInfo{Version: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.ModuleInfo(); got != tt.want {
+				t.Errorf("ModuleInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package encoders
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"image"
 	_ "image/png"
 	"strings"
+	"time"
 
 	qrc "github.com/yeqown/go-qrcode/v2"
 	"github.com/yeqown/go-qrcode/writer/standard"
@@ -14,6 +16,10 @@ import (
 
 // YeqownEncoder wraps github.com/yeqown/go-qrcode/v2 for QR code generation.
 // This encoder uses a builder/writer pattern to generate QR codes.
+//
+// Natively PixelSize is the symbol alone: modules are PixelSize divided by
+// the module count, and yeqown adds a fixed 40px border, so the image is
+// larger than PixelSize.
 type YeqownEncoder struct{}
 
 // Name returns the encoder identifier.
@@ -33,9 +39,29 @@ func (bc *bufferCloser) Close() error {
 
 // Encode generates a QR code image from the input data.
 // The yeqown/go-qrcode library uses a writer pattern to generate images.
+// The library panics when data does not fit a forced version; the panic is
+// recovered and returned as an error.
 func (e *YeqownEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, error) {
+	result, _, err := e.encode(data, opts)
+	return result, err
+}
+
+// encode is Encode with each phase timed.
+func (e *YeqownEncoder) encode(data []byte, opts EncodeOptions) (result EncodeResult, timings EncodeTimings, err error) {
+	// Recover from panics in the yeqown library
+	defer func() {
+		if r := recover(); r != nil {
+			result = EncodeResult{}
+			err = fmt.Errorf("yeqown: panic during encode: %v", r)
+		}
+	}()
+
 	if len(data) == 0 {
-		return EncodeResult{}, fmt.Errorf("yeqown: cannot encode empty data")
+		return EncodeResult{}, timings, fmt.Errorf("yeqown: cannot encode empty data")
+	}
+
+	if opts.MicroQR {
+		return EncodeResult{}, timings, fmt.Errorf("yeqown: %w", ErrMicroQRUnsupported)
 	}
 
 	// Map error correction level to qrc package constants
@@ -51,30 +77,57 @@ func (e *YeqownEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, e
 	case ErrorCorrectionH:
 		levelOption = qrc.WithErrorCorrectionLevel(qrc.ErrorCorrectionHighest)
 	default:
-		return EncodeResult{}, fmt.Errorf("yeqown: invalid error correction level %q", opts.ErrorCorrectionLevel)
+		return EncodeResult{}, timings, fmt.Errorf("yeqown: invalid error correction level %q", opts.ErrorCorrectionLevel)
+	}
+
+	encodeOptions := []qrc.EncodeOption{levelOption}
+	if opts.ForceVersion > 0 {
+		encodeOptions = append(encodeOptions, qrc.WithVersion(opts.ForceVersion))
 	}
 
 	// Create QR code with options
-	qrCode, err := qrc.NewWith(string(data), levelOption)
+	start := time.Now()
+	qrCode, err := qrc.NewWith(string(data), encodeOptions...)
+	timings.QRConstruct = time.Since(start)
 	if err != nil {
-		return EncodeResult{}, fmt.Errorf("yeqown: QR code creation failed: %w", err)
+		return EncodeResult{}, timings, fmt.Errorf("yeqown: QR code creation failed: %w", err)
 	}
 
 	// Write to buffer using standard writer
+	start = time.Now()
 	buf := &bufferCloser{Buffer: new(bytes.Buffer)}
-	writer := standard.NewWithWriter(buf,
-		standard.WithQRWidth(uint8(opts.PixelSize/qrCode.Dimension())),
+	imageOptions := []standard.ImageOption{
+		standard.WithQRWidth(uint8(opts.PixelSize / qrCode.Dimension())),
 		standard.WithBgTransparent(),
-	)
+	}
+	if opts.PixelSizeIncludesQuietZone {
+		// Render one pixel per module with no border; the symbol is scaled
+		// into the quiet-zone-inclusive box after decoding
+		imageOptions = []standard.ImageOption{
+			standard.WithQRWidth(1),
+			standard.WithBorderWidth(0),
+			standard.WithBgTransparent(),
+		}
+	}
+	writer := standard.NewWithWriter(buf, imageOptions...)
 
-	if err := qrCode.Save(writer); err != nil {
-		return EncodeResult{}, fmt.Errorf("yeqown: save failed: %w", err)
+	err = qrCode.Save(writer)
+	timings.ImageEncode = time.Since(start)
+	if err != nil {
+		return EncodeResult{}, timings, fmt.Errorf("yeqown: save failed: %w", err)
 	}
 
 	// Decode PNG bytes to image.Image
+	start = time.Now()
 	img, _, err := image.Decode(buf.Buffer)
+	timings.ImageDecode = time.Since(start)
 	if err != nil {
-		return EncodeResult{}, fmt.Errorf("yeqown: PNG decode failed: %w", err)
+		return EncodeResult{}, timings, fmt.Errorf("yeqown: PNG decode failed: %w", err)
+	}
+	if opts.PixelSizeIncludesQuietZone {
+		if img, err = fitQuietZone(img, opts.PixelSize); err != nil {
+			return EncodeResult{}, timings, fmt.Errorf("yeqown: %w", err)
+		}
 	}
 
 	// Calculate version from module dimension (not scaled pixel dimension)
@@ -87,10 +140,26 @@ func (e *YeqownEncoder) Encode(data []byte, opts EncodeOptions) (EncodeResult, e
 	return EncodeResult{
 		Image:   img,
 		Version: version,
-	}, nil
+	}, timings, nil
+}
+
+// EncodeWithTimings generates a QR code image and times yeqown's phases:
+// building the symbol, writing the PNG, and decoding it back.
+func (e *YeqownEncoder) EncodeWithTimings(data []byte, opts EncodeOptions) (image.Image, ModuleInfo, EncodeTimings, error) {
+	result, timings, err := e.encode(data, opts)
+	if err != nil {
+		return nil, ModuleInfo{}, timings, err
+	}
+	return result.Image, result.ModuleInfo(), timings, nil
 }
 
 // IsCapacityError returns true if the error indicates data exceeds QR capacity.
 func (e *YeqownEncoder) IsCapacityError(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "could not match version")
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return errors.Is(err, ErrPixelSizeTooSmall) ||
+		strings.Contains(msg, "could not match version") ||
+		strings.Contains(msg, "could not contain all bits")
 }
@@ -158,3 +158,22 @@ func TestYeqownEncoder_Encode_DifferentPixelSizes(t *testing.T) {
 		})
 	}
 }
+
+func TestYeqownEncoder_EncodeWithTimings(t *testing.T) {
+	enc := &YeqownEncoder{}
+	opts := EncodeOptions{
+		ErrorCorrectionLevel: ErrorCorrectionM,
+		PixelSize:            256,
+	}
+
+	img, info, timings, err := enc.EncodeWithTimings([]byte("Hello, QR Code!"), opts)
+	if err != nil {
+		t.Fatalf("EncodeWithTimings() failed: %v", err)
+	}
+	if img == nil || info.Version <= 0 {
+		t.Fatalf("EncodeWithTimings() image = %v, version = %d, want an image and version", img, info.Version)
+	}
+	if timings.QRConstruct <= 0 || timings.ImageEncode <= 0 || timings.ImageDecode <= 0 {
+		t.Errorf("EncodeWithTimings() phases = %+v, want all positive", timings)
+	}
+}
@@ -0,0 +1,62 @@
+package matrix
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// isCharsetMisread reports whether decoded is the expected payload read
+// with the wrong character set, rather than corrupted data. Two misreads
+// are recognized, both between UTF-8 and ISO-8859-1 (Latin-1), the charsets
+// QR byte mode is most often guessed as:
+//   - each expected byte decoded as a Latin-1 character and returned as
+//     UTF-8, the classic mojibake ("é" becomes "Ã©")
+//   - UTF-8 text whose characters all fit in Latin-1 returned as Latin-1
+//     bytes
+//
+// ASCII payloads read the same in both charsets and are never a misread.
+func isCharsetMisread(expected, decoded []byte) bool {
+	if bytes.Equal(expected, decoded) || isASCII(expected) {
+		return false
+	}
+	if bytes.Equal(decoded, latin1ToUTF8(expected)) {
+		return true
+	}
+	latin1, ok := utf8ToLatin1(expected)
+	return ok && bytes.Equal(decoded, latin1)
+}
+
+// latin1ToUTF8 returns data read as Latin-1 characters, encoded as UTF-8.
+func latin1ToUTF8(data []byte) []byte {
+	out := make([]byte, 0, len(data)*2)
+	for _, b := range data {
+		out = utf8.AppendRune(out, rune(b))
+	}
+	return out
+}
+
+// utf8ToLatin1 returns UTF-8 text as Latin-1 bytes. ok is false when data is
+// not valid UTF-8 or has a character outside Latin-1.
+func utf8ToLatin1(data []byte) (out []byte, ok bool) {
+	if !utf8.Valid(data) {
+		return nil, false
+	}
+	out = make([]byte, 0, len(data))
+	for _, r := range string(data) {
+		if r > 0xFF {
+			return nil, false
+		}
+		out = append(out, byte(r))
+	}
+	return out, true
+}
+
+// isASCII reports whether every byte of data is 7-bit ASCII.
+func isASCII(data []byte) bool {
+	for _, b := range data {
+		if b >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
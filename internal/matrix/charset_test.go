@@ -0,0 +1,109 @@
+package matrix
+
+import (
+	"image"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+func TestIsCharsetMisread(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []byte
+		decoded  []byte
+		want     bool
+	}{
+		{"identical", []byte("héllo"), []byte("héllo"), false},
+		{"UTF-8 read as Latin-1", []byte("héllo"), []byte("hÃ©llo"), true},
+		{"emoji read as Latin-1", []byte("🙂"), latin1ToUTF8([]byte("🙂")), true},
+		{"UTF-8 returned as Latin-1 bytes", []byte("héllo"), []byte("h\xe9llo"), true},
+		{"binary read as Latin-1", []byte{0x00, 0xff, 0x80}, []byte("\x00ÿ\u0080"), true},
+		{"corrupted", []byte("héllo"), []byte("hÃ©llX"), false},
+		{"ASCII mismatch", []byte("hello"), []byte("hellO"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCharsetMisread(tt.expected, tt.decoded); got != tt.want {
+				t.Errorf("isCharsetMisread(%q, %q) = %v, want %v", tt.expected, tt.decoded, got, tt.want)
+			}
+		})
+	}
+}
+
+// latin1StubDecoder decodes with gozxing and returns the payload as if its
+// UTF-8 bytes were Latin-1 characters, like a decoder guessing the wrong
+// charset for byte mode.
+type latin1StubDecoder struct{}
+
+func (d *latin1StubDecoder) Name() string { return "stub/latin1" }
+
+func (d *latin1StubDecoder) Decode(img image.Image) ([]byte, error) {
+	data, err := (&decoders.GozxingDecoder{}).Decode(img)
+	if err != nil {
+		return nil, err
+	}
+	return latin1ToUTF8(data), nil
+}
+
+// corruptingStubDecoder decodes with gozxing and flips the last byte.
+type corruptingStubDecoder struct{}
+
+func (d *corruptingStubDecoder) Name() string { return "stub/corrupting" }
+
+func (d *corruptingStubDecoder) Decode(img image.Image) ([]byte, error) {
+	data, err := (&decoders.GozxingDecoder{}).Decode(img)
+	if err != nil {
+		return nil, err
+	}
+	data[len(data)-1] ^= 0x01
+	return data, nil
+}
+
+func TestRunner_RunAll_CharsetMismatch(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+
+	data := []byte("QR 🙂 emoji 🎉")
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("utf8", len(data), 400),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            400,
+			ContentType:          testdata.ContentUTF8,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	decs := []decoders.Decoder{&latin1StubDecoder{}, &corruptingStubDecoder{}}
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, decs, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	for _, result := range results.Results {
+		if _, ok := result.Error.(DataMismatchError); !ok {
+			t.Fatalf("%s: error = %v, want DataMismatchError", result.DecoderName, result.Error)
+		}
+
+		switch result.DecoderName {
+		case "stub/latin1":
+			if !result.CharsetMismatch {
+				t.Errorf("%s: CharsetMismatch = false, want the Latin-1 misread classified as charset-sensitive", result.DecoderName)
+			}
+		case "stub/corrupting":
+			if result.CharsetMismatch {
+				t.Errorf("%s: CharsetMismatch = true, want corruption not blamed on the charset", result.DecoderName)
+			}
+		}
+	}
+}
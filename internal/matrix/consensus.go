@@ -0,0 +1,159 @@
+package matrix
+
+import (
+	"errors"
+	"sort"
+)
+
+// Agreement classifies how the decoders that read one image agree.
+type Agreement string
+
+const (
+	// AgreementAll means every decoder returned the same outcome.
+	AgreementAll Agreement = "all-agree"
+
+	// AgreementMajority means more than half the decoders share an outcome
+	// and the rest dissent. A lone dissenter most likely has a bug.
+	AgreementMajority Agreement = "majority"
+
+	// AgreementNone means no outcome is shared by more than half.
+	AgreementNone Agreement = "none"
+)
+
+// Outcome labels in CaseConsensus.Outcomes besides decoded-data digests.
+const (
+	OutcomeMatch  = "match"   // decoded the expected data
+	OutcomeNoRead = "no-read" // decode failed, timed out, or panicked
+)
+
+// CaseConsensus is how the decoders agreed on one encoded image.
+type CaseConsensus struct {
+	EncoderName          string
+	DataSize             int
+	PixelSize            int
+	ContentType          string
+	ErrorCorrectionLevel string
+	Seed                 int64
+	Repeat               int
+
+	// Outcomes maps each distinct outcome to the decoders that returned
+	// it, sorted by name: OutcomeMatch, OutcomeNoRead, or the
+	// DecodedDigest of data that did not match.
+	Outcomes map[string][]string
+
+	Agreement Agreement
+
+	// Dissenters lists the decoders outside the majority, sorted by name.
+	// Empty unless Agreement is AgreementMajority.
+	Dissenters []string
+}
+
+// DecoderConsensus compares what every decoder read from each encoded image.
+// Images the encoder did not produce and decoders that skipped the image
+// (capacity or unsupported content) are left out, and an image needs at
+// least two decoders to have a consensus. Results are sorted by encoder,
+// data size, pixel size, content type, EC level, seed, and repeat.
+func DecoderConsensus(results []TestResult) []CaseConsensus {
+	type caseKey struct {
+		encoder               string
+		dataSize, pixelSize   int
+		contentType, ecLevel  string
+		seed                  int64
+		repeat                int
+		occlusion, corruption float64
+	}
+
+	outcomes := make(map[caseKey]map[string][]string)
+	var keys []caseKey
+	for _, r := range results {
+		if !encoded(r) {
+			continue
+		}
+		ck := caseKey{r.EncoderName, r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel, r.Seed, r.Repeat, r.CenterOcclusion, r.CorruptFraction}
+		if outcomes[ck] == nil {
+			outcomes[ck] = make(map[string][]string)
+			keys = append(keys, ck)
+		}
+		outcome := decodeOutcome(r)
+		outcomes[ck][outcome] = append(outcomes[ck][outcome], r.DecoderName)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.encoder != b.encoder:
+			return a.encoder < b.encoder
+		case a.dataSize != b.dataSize:
+			return a.dataSize < b.dataSize
+		case a.pixelSize != b.pixelSize:
+			return a.pixelSize < b.pixelSize
+		case a.contentType != b.contentType:
+			return a.contentType < b.contentType
+		case a.ecLevel != b.ecLevel:
+			return a.ecLevel < b.ecLevel
+		case a.seed != b.seed:
+			return a.seed < b.seed
+		case a.repeat != b.repeat:
+			return a.repeat < b.repeat
+		case a.occlusion != b.occlusion:
+			return a.occlusion < b.occlusion
+		}
+		return a.corruption < b.corruption
+	})
+
+	consensus := make([]CaseConsensus, 0, len(keys))
+	for _, ck := range keys {
+		byOutcome := outcomes[ck]
+		decoders := 0
+		majority := ""
+		for outcome, names := range byOutcome {
+			sort.Strings(names)
+			decoders += len(names)
+			if majority == "" || len(names) > len(byOutcome[majority]) {
+				majority = outcome
+			}
+		}
+		if decoders < 2 {
+			continue
+		}
+
+		c := CaseConsensus{
+			EncoderName:          ck.encoder,
+			DataSize:             ck.dataSize,
+			PixelSize:            ck.pixelSize,
+			ContentType:          ck.contentType,
+			ErrorCorrectionLevel: ck.ecLevel,
+			Seed:                 ck.seed,
+			Repeat:               ck.repeat,
+			Outcomes:             byOutcome,
+		}
+		switch {
+		case len(byOutcome) == 1:
+			c.Agreement = AgreementAll
+		case 2*len(byOutcome[majority]) > decoders:
+			c.Agreement = AgreementMajority
+			for outcome, names := range byOutcome {
+				if outcome != majority {
+					c.Dissenters = append(c.Dissenters, names...)
+				}
+			}
+			sort.Strings(c.Dissenters)
+		default:
+			c.Agreement = AgreementNone
+		}
+		consensus = append(consensus, c)
+	}
+	return consensus
+}
+
+// decodeOutcome labels what a decoder read from an encoded image.
+func decodeOutcome(r TestResult) string {
+	if r.Error == nil {
+		return OutcomeMatch
+	}
+	var mismatch DataMismatchError
+	if errors.As(r.Error, &mismatch) && r.DecodedDigest != "" {
+		return r.DecodedDigest
+	}
+	return OutcomeNoRead
+}
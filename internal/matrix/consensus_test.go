@@ -0,0 +1,108 @@
+package matrix
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+func TestRunner_DecoderConsensus_Dissenter(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+	decs := []decoders.Decoder{
+		&decoders.GozxingDecoder{},
+		&decoders.GozxingDecoder{Binarizer: decoders.BinarizerGlobal},
+		&corruptingStubDecoder{},
+	}
+
+	data := []byte("HELLO CONSENSUS")
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("alphanumeric", len(data), 400),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            400,
+			ContentType:          testdata.ContentAlphanumeric,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, decs, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	consensus := DecoderConsensus(results.Results)
+	if len(consensus) != 1 {
+		t.Fatalf("DecoderConsensus() returned %d cases, want 1", len(consensus))
+	}
+
+	c := consensus[0]
+	if c.Agreement != AgreementMajority {
+		t.Errorf("Agreement = %q, want %q", c.Agreement, AgreementMajority)
+	}
+	if want := []string{"stub/corrupting"}; !reflect.DeepEqual(c.Dissenters, want) {
+		t.Errorf("Dissenters = %v, want %v", c.Dissenters, want)
+	}
+	if want := []string{"makiuchi-d/gozxing", "makiuchi-d/gozxing-global"}; !reflect.DeepEqual(c.Outcomes[OutcomeMatch], want) {
+		t.Errorf("Outcomes[%q] = %v, want %v", OutcomeMatch, c.Outcomes[OutcomeMatch], want)
+	}
+	if len(c.Outcomes) != 2 {
+		t.Errorf("got %d distinct outcomes, want 2 (match and the corrupted digest)", len(c.Outcomes))
+	}
+}
+
+func TestDecoderConsensus_Classification(t *testing.T) {
+	mismatch := DataMismatchError{Expected: 5, Got: 5}
+	noRead := DecodeError{Err: errors.New("not found")}
+	result := func(dataSize int, decoder string, err error, digest string) TestResult {
+		return TestResult{EncoderName: "enc", DecoderName: decoder, DataSize: dataSize, Error: err, DecodedDigest: digest}
+	}
+
+	skipped := result(4, "b", nil, "")
+	skipped.IsCapacityExceeded = true
+
+	results := []TestResult{
+		// All three fail to read: they still agree
+		result(1, "a", noRead, ""), result(1, "b", noRead, ""), result(1, "c", noRead, ""),
+		// Three different outcomes
+		result(2, "a", nil, ""), result(2, "b", mismatch, "d1"), result(2, "c", noRead, ""),
+		// Two misread identically, one read correctly
+		result(3, "a", nil, ""), result(3, "b", mismatch, "d1"), result(3, "c", mismatch, "d1"),
+		// Only one decoder took part
+		result(4, "a", nil, ""), skipped,
+		// Never encoded
+		result(5, "a", EncodeError{Err: errors.New("too large")}, ""), result(5, "b", EncodeError{Err: errors.New("too large")}, ""),
+	}
+
+	consensus := DecoderConsensus(results)
+	if len(consensus) != 3 {
+		t.Fatalf("DecoderConsensus() returned %d cases, want 3", len(consensus))
+	}
+
+	tests := []struct {
+		dataSize   int
+		agreement  Agreement
+		dissenters []string
+	}{
+		{1, AgreementAll, nil},
+		{2, AgreementNone, nil},
+		{3, AgreementMajority, []string{"a"}},
+	}
+	for i, tt := range tests {
+		c := consensus[i]
+		if c.DataSize != tt.dataSize || c.Agreement != tt.agreement || !reflect.DeepEqual(c.Dissenters, tt.dissenters) {
+			t.Errorf("case %d: got size %d %q dissenters %v, want size %d %q dissenters %v",
+				i, c.DataSize, c.Agreement, c.Dissenters, tt.dataSize, tt.agreement, tt.dissenters)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package matrix
+
+import (
+	"errors"
+	"sort"
+)
+
+// EncoderReadability is how well one decoder reads one encoder's output,
+// measured on the payloads every encoder in the run managed to encode.
+type EncoderReadability struct {
+	EncoderName string
+
+	// Tests and Successes count the decoder's results on this encoder's
+	// images of the shared payloads.
+	Tests     int
+	Successes int
+
+	// SuccessRate is Successes/Tests (0.0-1.0).
+	SuccessRate float64
+
+	// Unreadable is true when the decoder read none of this encoder's
+	// images while reading at least one from another encoder, so the
+	// problem lies with this encoder's output rather than the payloads.
+	Unreadable bool
+}
+
+// CrossEncoderReport holds decoderName fixed and compares its decode success
+// across encoders, most readable first (ties by encoder name). Only payloads
+// that every encoder encoded are counted, so each encoder is measured on
+// identical inputs and an encoder's capacity limits or encode failures do not
+// count against it. Returns nil when decoderName has no results.
+func CrossEncoderReport(results []TestResult, decoderName string) []EncoderReadability {
+	type caseKey struct {
+		dataSize, pixelSize   int
+		contentType, ecLevel  string
+		seed                  int64
+		repeat                int
+		occlusion, corruption float64
+	}
+
+	byEncoder := make(map[string]map[caseKey]TestResult)
+	for _, r := range results {
+		if r.DecoderName != decoderName {
+			continue
+		}
+		if byEncoder[r.EncoderName] == nil {
+			byEncoder[r.EncoderName] = make(map[caseKey]TestResult)
+		}
+		ck := caseKey{r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel, r.Seed, r.Repeat, r.CenterOcclusion, r.CorruptFraction}
+		byEncoder[r.EncoderName][ck] = r
+	}
+	if len(byEncoder) == 0 {
+		return nil
+	}
+
+	// A payload is shared when every encoder produced an image of it
+	shared := make(map[caseKey]int)
+	for _, cases := range byEncoder {
+		for ck, r := range cases {
+			if encoded(r) {
+				shared[ck]++
+			}
+		}
+	}
+
+	readability := make([]EncoderReadability, 0, len(byEncoder))
+	anyRead := false
+	for name, cases := range byEncoder {
+		e := EncoderReadability{EncoderName: name}
+		for ck, r := range cases {
+			if shared[ck] != len(byEncoder) {
+				continue
+			}
+			e.Tests++
+			if r.Error == nil {
+				e.Successes++
+			}
+		}
+		if e.Tests > 0 {
+			e.SuccessRate = float64(e.Successes) / float64(e.Tests)
+		}
+		anyRead = anyRead || e.Successes > 0
+		readability = append(readability, e)
+	}
+
+	for i := range readability {
+		readability[i].Unreadable = anyRead && readability[i].Tests > 0 && readability[i].Successes == 0
+	}
+
+	sort.Slice(readability, func(i, j int) bool {
+		if readability[i].SuccessRate != readability[j].SuccessRate {
+			return readability[i].SuccessRate > readability[j].SuccessRate
+		}
+		return readability[i].EncoderName < readability[j].EncoderName
+	})
+	return readability
+}
+
+// encoded reports whether r got as far as an image for the decoder and was
+// not skipped.
+func encoded(r TestResult) bool {
+	if r.IsSkipped() {
+		return false
+	}
+	var encodeErr EncodeError
+	return !errors.As(r.Error, &encodeErr)
+}
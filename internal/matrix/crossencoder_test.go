@@ -0,0 +1,76 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCrossEncoderReport(t *testing.T) {
+	decodeErr := DecodeError{Err: errors.New("not found")}
+	encodeErr := EncodeError{Err: errors.New("encode failed")}
+
+	result := func(enc, dec string, pixelSize int, err error) TestResult {
+		return TestResult{
+			EncoderName:          enc,
+			DecoderName:          dec,
+			DataSize:             100,
+			PixelSize:            pixelSize,
+			ContentType:          "binary",
+			ErrorCorrectionLevel: "M",
+			Error:                err,
+		}
+	}
+
+	results := []TestResult{
+		// enc/good is read every time
+		result("enc/good", "dec/a", 400, nil),
+		result("enc/good", "dec/a", 440, nil),
+		result("enc/good", "dec/a", 480, nil),
+
+		// enc/half is read at one of the two shared sizes
+		result("enc/half", "dec/a", 400, nil),
+		result("enc/half", "dec/a", 440, decodeErr),
+		result("enc/half", "dec/a", 480, nil),
+
+		// enc/bad is never read
+		result("enc/bad", "dec/a", 400, decodeErr),
+		result("enc/bad", "dec/a", 440, decodeErr),
+		result("enc/bad", "dec/a", 480, decodeErr),
+
+		// 480px is not shared: one encoder failed to encode it
+		result("enc/flaky", "dec/a", 400, nil),
+		result("enc/flaky", "dec/a", 440, nil),
+		result("enc/flaky", "dec/a", 480, encodeErr),
+
+		// Another decoder's results are ignored
+		result("enc/bad", "dec/b", 400, nil),
+		result("enc/bad", "dec/b", 440, nil),
+	}
+
+	report := CrossEncoderReport(results, "dec/a")
+
+	want := []struct {
+		encoder    string
+		tests      int
+		successes  int
+		unreadable bool
+	}{
+		{"enc/flaky", 2, 2, false},
+		{"enc/good", 2, 2, false},
+		{"enc/half", 2, 1, false},
+		{"enc/bad", 2, 0, true},
+	}
+	if len(report) != len(want) {
+		t.Fatalf("CrossEncoderReport() returned %d encoders, want %d: %+v", len(report), len(want), report)
+	}
+	for i, w := range want {
+		got := report[i]
+		if got.EncoderName != w.encoder || got.Tests != w.tests || got.Successes != w.successes || got.Unreadable != w.unreadable {
+			t.Errorf("report[%d] = %+v, want %s %d/%d unreadable=%v", i, got, w.encoder, w.successes, w.tests, w.unreadable)
+		}
+	}
+
+	if got := CrossEncoderReport(results, "dec/missing"); got != nil {
+		t.Errorf("CrossEncoderReport(missing decoder) = %+v, want nil", got)
+	}
+}
@@ -27,7 +27,10 @@ func TestDebug_SingleCase(t *testing.T) {
 		},
 	}
 
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
 	results, err := runner.RunAll()
 	if err != nil {
@@ -58,7 +61,10 @@ func TestDebug_BinaryData(t *testing.T) {
 	}
 
 	// Test just the first case
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases[:1])
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases[:1])
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
 	results, err := runner.RunAll()
 	if err != nil {
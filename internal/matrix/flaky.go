@@ -0,0 +1,98 @@
+package matrix
+
+import "sort"
+
+// Flakiness summarizes one encoder/decoder pair across the repeats of a
+// repeated run (Config.Repeat). Unlike a seed sweep the data is identical
+// in every repeat, so an outcome that changes means the library itself is
+// nondeterministic.
+type Flakiness struct {
+	EncoderName string
+	DecoderName string
+
+	// Repeats is the number of times the matrix ran.
+	Repeats int
+
+	// Tests and Successes count repeated results, excluding skips.
+	Tests     int
+	Successes int
+
+	// FlakyCases counts test cases that passed in some repeats and failed
+	// in others.
+	FlakyCases int
+
+	// Flaky is true when FlakyCases > 0.
+	Flaky bool
+}
+
+// AnalyzeFlakiness groups repeated results (Repeat != 0) by encoder/decoder
+// pair, sorted by encoder then decoder name. Results outside a repeated run
+// and skips are ignored.
+func AnalyzeFlakiness(results []TestResult) []Flakiness {
+	type pairKey struct{ encoder, decoder string }
+	type caseKey struct {
+		dataSize, pixelSize  int
+		contentType, ecLevel string
+		seed                 int64
+	}
+	type tally struct{ tests, successes int }
+	type pairAgg struct {
+		repeats map[int]bool
+		byCase  map[caseKey]*tally
+	}
+
+	agg := make(map[pairKey]*pairAgg)
+	var pairs []pairKey
+
+	for _, r := range results {
+		if r.Repeat == 0 || r.IsSkipped() {
+			continue
+		}
+
+		pk := pairKey{r.EncoderName, r.DecoderName}
+		a := agg[pk]
+		if a == nil {
+			a = &pairAgg{repeats: make(map[int]bool), byCase: make(map[caseKey]*tally)}
+			agg[pk] = a
+			pairs = append(pairs, pk)
+		}
+
+		a.repeats[r.Repeat] = true
+		ck := caseKey{r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel, r.Seed}
+		if a.byCase[ck] == nil {
+			a.byCase[ck] = &tally{}
+		}
+		a.byCase[ck].tests++
+		if r.Error == nil {
+			a.byCase[ck].successes++
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].encoder != pairs[j].encoder {
+			return pairs[i].encoder < pairs[j].encoder
+		}
+		return pairs[i].decoder < pairs[j].decoder
+	})
+
+	flakiness := make([]Flakiness, 0, len(pairs))
+	for _, pk := range pairs {
+		a := agg[pk]
+		f := Flakiness{
+			EncoderName: pk.encoder,
+			DecoderName: pk.decoder,
+			Repeats:     len(a.repeats),
+		}
+		for _, t := range a.byCase {
+			f.Tests += t.tests
+			f.Successes += t.successes
+			if t.successes > 0 && t.successes < t.tests {
+				f.FlakyCases++
+			}
+		}
+		f.Flaky = f.FlakyCases > 0
+
+		flakiness = append(flakiness, f)
+	}
+	return flakiness
+}
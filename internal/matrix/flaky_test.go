@@ -0,0 +1,87 @@
+package matrix
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+// everyThirdStubDecoder decodes with gozxing but fails every third call,
+// like a decoder with a race or uninitialized state.
+type everyThirdStubDecoder struct {
+	calls int
+}
+
+func (d *everyThirdStubDecoder) Name() string { return "stub/every-third" }
+
+func (d *everyThirdStubDecoder) Decode(img image.Image) ([]byte, error) {
+	d.calls++
+	if d.calls%3 == 0 {
+		return nil, errors.New("intermittent failure")
+	}
+	return (&decoders.GozxingDecoder{}).Decode(img)
+}
+
+func TestRunner_RunAll_Repeat(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Repeat = 3
+	enc := &encoders.Skip2Encoder{}
+	steady := &decoders.GozxingDecoder{}
+	flaky := &everyThirdStubDecoder{}
+
+	data := []byte("HELLO WORLD")
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("alphanumeric", len(data), 400),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            400,
+			ContentType:          testdata.ContentAlphanumeric,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{steady, flaky}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	if got := runner.TotalTests(); got != 6 {
+		t.Errorf("TotalTests() = %d, want 6 (3 repeats × 2 decoders)", got)
+	}
+
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	if len(results.Results) != 6 {
+		t.Fatalf("RunAll() returned %d results, want 6", len(results.Results))
+	}
+	for _, r := range results.Results {
+		if r.Repeat < 1 || r.Repeat > 3 {
+			t.Errorf("Result repeat = %d, want 1-3", r.Repeat)
+		}
+	}
+
+	byDecoder := make(map[string]Flakiness)
+	for _, f := range results.Flakiness {
+		byDecoder[f.DecoderName] = f
+	}
+
+	got := byDecoder[flaky.Name()]
+	if !got.Flaky || got.FlakyCases != 1 {
+		t.Errorf("every-third flakiness = %+v, want flaky with 1 flaky case", got)
+	}
+	if got.Repeats != 3 || got.Tests != 3 || got.Successes != 2 {
+		t.Errorf("every-third repeats = %d, successes = %d/%d, want 3 repeats at 2/3", got.Repeats, got.Successes, got.Tests)
+	}
+
+	if got := byDecoder[steady.Name()]; got.Flaky || got.Successes != 3 {
+		t.Errorf("gozxing flakiness = %+v, want consistent at 3/3", got)
+	}
+}
@@ -0,0 +1,70 @@
+package matrix
+
+import (
+	"image"
+	"sync"
+)
+
+// ImageKey identifies an encoded image. The image depends only on the
+// encoder and the test case, so it is shared by every decoder.
+type ImageKey struct {
+	Encoder  string
+	TestCase string
+}
+
+// ImageStore retains encoded images for re-decoding after a run.
+// It holds at most Limit images; the oldest image is evicted first.
+// Safe for concurrent use.
+type ImageStore struct {
+	// Limit caps the number of retained images. 0 means unlimited.
+	Limit int
+
+	mu     sync.Mutex
+	images map[ImageKey]image.Image
+	order  []ImageKey // insertion order, oldest first
+}
+
+// NewImageStore creates an image store that retains at most limit images.
+func NewImageStore(limit int) *ImageStore {
+	return &ImageStore{
+		Limit:  limit,
+		images: make(map[ImageKey]image.Image),
+	}
+}
+
+// Put stores img under key, evicting the oldest images beyond Limit.
+// Storing an existing key replaces the image without changing its age.
+func (s *ImageStore) Put(key ImageKey, img image.Image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.images[key]; ok {
+		s.images[key] = img
+		return
+	}
+
+	s.images[key] = img
+	s.order = append(s.order, key)
+
+	for s.Limit > 0 && len(s.order) > s.Limit {
+		delete(s.images, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// Get returns the image stored under key.
+func (s *ImageStore) Get(key ImageKey) (image.Image, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	img, ok := s.images[key]
+	return img, ok
+}
+
+// Len returns the number of retained images.
+func (s *ImageStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.images)
+}
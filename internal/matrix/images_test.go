@@ -0,0 +1,140 @@
+package matrix
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+func TestImageStore_EvictsOldest(t *testing.T) {
+	store := NewImageStore(2)
+
+	keys := []ImageKey{
+		{Encoder: "enc", TestCase: "a"},
+		{Encoder: "enc", TestCase: "b"},
+		{Encoder: "enc", TestCase: "c"},
+	}
+	for _, key := range keys {
+		store.Put(key, image.NewGray(image.Rect(0, 0, 1, 1)))
+	}
+
+	if store.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", store.Len())
+	}
+	if _, ok := store.Get(keys[0]); ok {
+		t.Error("Oldest image should be evicted beyond the limit")
+	}
+	for _, key := range keys[1:] {
+		if _, ok := store.Get(key); !ok {
+			t.Errorf("Image %s should be retained", key.TestCase)
+		}
+	}
+}
+
+func TestImageStore_ReplaceKeepsAge(t *testing.T) {
+	store := NewImageStore(2)
+	a := ImageKey{Encoder: "enc", TestCase: "a"}
+	b := ImageKey{Encoder: "enc", TestCase: "b"}
+
+	store.Put(a, image.NewGray(image.Rect(0, 0, 1, 1)))
+	store.Put(b, image.NewGray(image.Rect(0, 0, 1, 1)))
+	store.Put(a, image.NewGray(image.Rect(0, 0, 2, 2)))
+
+	if store.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", store.Len())
+	}
+	img, ok := store.Get(a)
+	if !ok || img.Bounds().Dx() != 2 {
+		t.Error("Put() with an existing key should replace the image")
+	}
+}
+
+func TestRunner_RunAll_RetainImages(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.RetainImages = true
+	enc := &encoders.Skip2Encoder{}
+
+	data := []byte("retain me")
+	tc := testdata.TestCase{
+		Name:                 "binary-9b-256px-ecM",
+		Data:                 data,
+		DataSize:             len(data),
+		PixelSize:            256,
+		ContentType:          testdata.ContentBinary,
+		ErrorCorrectionLevel: "M",
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{&decoders.GozxingDecoder{}}, []testdata.TestCase{tc})
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	if results.Images == nil {
+		t.Fatal("Images should be set when RetainImages is enabled")
+	}
+
+	retained, ok := results.Images.Get(ImageKey{Encoder: enc.Name(), TestCase: tc.Name})
+	if !ok {
+		t.Fatal("Encoded image was not retained")
+	}
+
+	fresh, err := enc.Encode(data, encoders.EncodeOptions{ErrorCorrectionLevel: encoders.ErrorCorrectionM, PixelSize: 256})
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+
+	if !bytes.Equal(grayPixels(retained), grayPixels(fresh.Image)) {
+		t.Error("Retained image differs from a freshly encoded one")
+	}
+
+	// Re-decode the retained image with a different decoder
+	decoded, err := (&decoders.TuotooDecoder{}).Decode(retained)
+	if err != nil {
+		t.Fatalf("Re-decode of retained image failed: %v", err)
+	}
+	if !bytes.HasPrefix(decoded, data) {
+		t.Errorf("Re-decoded data = %q, want prefix %q", decoded, data)
+	}
+}
+
+func TestRunner_RunAll_RetainImagesDisabled(t *testing.T) {
+	cfg := config.DefaultConfig()
+	data := []byte("discard me")
+	cases := []testdata.TestCase{
+		{Name: "binary-10b-256px-ecM", Data: data, DataSize: len(data), PixelSize: 256, ErrorCorrectionLevel: "M"},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{&encoders.Skip2Encoder{}}, []decoders.Decoder{&decoders.GozxingDecoder{}}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	if results.Images != nil {
+		t.Error("Images should be nil when RetainImages is disabled")
+	}
+}
+
+// grayPixels flattens an image to 8-bit gray values in row order.
+func grayPixels(img image.Image) []byte {
+	b := img.Bounds()
+	gray := image.NewGray(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			gray.Set(x, y, img.At(x, y))
+		}
+	}
+	return gray.Pix
+}
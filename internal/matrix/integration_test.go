@@ -23,7 +23,10 @@ func TestIntegration_Skip2Gozxing(t *testing.T) {
 	// Use a subset of the full pixel size matrix for integration testing
 	cases := testdata.GeneratePixelSizeMatrix()
 
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
 	results, err := runner.RunAll()
 	if err != nil {
@@ -88,3 +91,55 @@ func TestIntegration_Skip2Gozxing(t *testing.T) {
 		}
 	}
 }
+
+// TestIntegration_CorruptModules checks that error correction levels recover
+// the damage they promise: at a corruption fraction between L's and H's
+// thresholds, the H code decodes and the L code does not.
+func TestIntegration_CorruptModules(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.CorruptFraction = 0.02
+	enc := &encoders.Skip2Encoder{}
+	dec := &decoders.GozxingDecoder{}
+
+	data := []byte("https://example.com/error-correction")
+	var cases []testdata.TestCase
+	for _, level := range []string{"L", "H"} {
+		cases = append(cases, testdata.TestCase{
+			Name:                 formatTestName("utf8", len(data), 400) + "-" + level,
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            400,
+			ContentType:          testdata.ContentUTF8,
+			ErrorCorrectionLevel: level,
+		})
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	for _, result := range results.Results {
+		if result.CorruptFraction != 0.02 {
+			t.Errorf("EC %s: CorruptFraction = %.2f, want 0.02", result.ErrorCorrectionLevel, result.CorruptFraction)
+		}
+		switch result.ErrorCorrectionLevel {
+		case "L":
+			if result.Error == nil {
+				t.Error("EC L decoded with 2% of modules inverted, want a failure beyond its ~7% codeword recovery")
+			}
+		case "H":
+			if result.Error != nil {
+				t.Errorf("EC H with 2%% of modules inverted = %v, want success", result.Error)
+			}
+		}
+	}
+}
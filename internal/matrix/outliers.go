@@ -0,0 +1,76 @@
+package matrix
+
+import (
+	"slices"
+	"time"
+)
+
+// Decode-time outlier thresholds: a decode is an outlier when it takes
+// longer than the pair's median plus outlierMADs median absolute
+// deviations. The deviation is at least outlierMinSpread of the median so
+// a pair whose decodes all take the same time does not flag ordinary
+// jitter, and a pair needs minOutlierSamples decodes to have a median
+// worth comparing against.
+const (
+	outlierMADs       = 3
+	outlierMinSpread  = 0.1
+	minOutlierSamples = 5
+)
+
+// FlagDecodeOutliers sets IsDecodeOutlier on results whose DecodeTime is
+// far above the rest of their encoder/decoder pair: greater than the median
+// plus three median absolute deviations. Only results that reached the
+// decoder are compared; skips and encode failures have no decode time.
+// Pairs with fewer than five decodes are left unflagged.
+//
+// A decode that takes 100× the median usually means a pathological
+// binarizer or detector path, and it skews per-pair averages without
+// showing up as a failure.
+func FlagDecodeOutliers(results []TestResult) {
+	type pairKey struct{ encoder, decoder string }
+	byPair := make(map[pairKey][]int)
+	for i, r := range results {
+		if r.IsSkipped() || r.DecodeTime <= 0 {
+			continue
+		}
+		pk := pairKey{r.EncoderName, r.DecoderName}
+		byPair[pk] = append(byPair[pk], i)
+	}
+
+	for _, indices := range byPair {
+		if len(indices) < minOutlierSamples {
+			continue
+		}
+
+		times := make([]time.Duration, len(indices))
+		for j, i := range indices {
+			times[j] = results[i].DecodeTime
+		}
+		median := medianDuration(times)
+
+		deviations := make([]time.Duration, len(times))
+		for j, t := range times {
+			deviations[j] = max(t-median, median-t)
+		}
+		mad := medianDuration(deviations)
+		spread := max(mad, time.Duration(float64(median)*outlierMinSpread))
+
+		threshold := median + outlierMADs*spread
+		for _, i := range indices {
+			if results[i].DecodeTime > threshold {
+				results[i].IsDecodeOutlier = true
+			}
+		}
+	}
+}
+
+// medianDuration returns the median of times, averaging the middle pair for
+// an even count. times must not be empty; it is sorted in place.
+func medianDuration(times []time.Duration) time.Duration {
+	slices.Sort(times)
+	mid := len(times) / 2
+	if len(times)%2 == 0 {
+		return (times[mid-1] + times[mid]) / 2
+	}
+	return times[mid]
+}
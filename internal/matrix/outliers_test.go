@@ -0,0 +1,66 @@
+package matrix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlagDecodeOutliers(t *testing.T) {
+	var results []TestResult
+	for i, ms := range []int{10, 11, 9, 12, 10, 11, 9, 1000} {
+		results = append(results, TestResult{
+			EncoderName: "enc",
+			DecoderName: "dec",
+			DataSize:    i,
+			DecodeTime:  time.Duration(ms) * time.Millisecond,
+		})
+	}
+	// Another pair's times and capacity skips are compared separately
+	results = append(results,
+		TestResult{EncoderName: "enc", DecoderName: "other", DecodeTime: 900 * time.Millisecond},
+		TestResult{EncoderName: "enc", DecoderName: "dec", IsCapacityExceeded: true},
+	)
+
+	FlagDecodeOutliers(results)
+
+	for i, r := range results {
+		want := i == 7
+		if r.IsDecodeOutlier != want {
+			t.Errorf("results[%d] (%s, %v) IsDecodeOutlier = %v, want %v", i, r.DecoderName, r.DecodeTime, r.IsDecodeOutlier, want)
+		}
+	}
+}
+
+func TestFlagDecodeOutliers_Thresholds(t *testing.T) {
+	tests := []struct {
+		name string
+		ms   []int
+		want bool // whether the last result is flagged
+	}{
+		{"too few samples", []int{10, 10, 10, 1000}, false},
+		{"identical times ignore jitter", []int{10, 10, 10, 10, 10, 12}, false},
+		{"identical times flag a slow decode", []int{10, 10, 10, 10, 10, 100}, true},
+		{"within three deviations", []int{10, 20, 30, 40, 50, 60}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := make([]TestResult, len(tt.ms))
+			for i, ms := range tt.ms {
+				results[i] = TestResult{EncoderName: "enc", DecoderName: "dec", DecodeTime: time.Duration(ms) * time.Millisecond}
+			}
+
+			FlagDecodeOutliers(results)
+
+			last := results[len(results)-1]
+			if last.IsDecodeOutlier != tt.want {
+				t.Errorf("IsDecodeOutlier = %v, want %v", last.IsDecodeOutlier, tt.want)
+			}
+			for i, r := range results[:len(results)-1] {
+				if r.IsDecodeOutlier {
+					t.Errorf("results[%d] flagged, want only the last result considered", i)
+				}
+			}
+		})
+	}
+}
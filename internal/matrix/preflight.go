@@ -0,0 +1,68 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+)
+
+// The pre-flight symbol: a short payload at a size every decoder reads.
+const (
+	preflightData      = "OK"
+	preflightPixelSize = 256
+)
+
+// PreflightFailure records a decoder that could not read the pre-flight
+// symbol.
+type PreflightFailure struct {
+	DecoderName string
+
+	// Err is the TimeoutError, PanicError, DecodeError, or
+	// DataMismatchError the decode ended with.
+	Err error
+}
+
+// Preflight decodes a trivial known-good QR code ("OK" at 256px, encoded
+// with skip2) with every decoder before the matrix runs. A decoder that
+// fails it, for example one whose C library is missing at runtime, would
+// otherwise fail every test of the run. Failures are returned in decoder
+// order; when Config.DropUnhealthyDecoders is set the failing decoders are
+// also removed from r.Decoders.
+//
+// Returns an error only if the pre-flight symbol cannot be encoded.
+func (r *Runner) Preflight() ([]PreflightFailure, error) {
+	encoded, err := (&encoders.Skip2Encoder{}).Encode([]byte(preflightData), encoders.EncodeOptions{
+		ErrorCorrectionLevel: encoders.ErrorCorrectionM,
+		PixelSize:            preflightPixelSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("preflight: encode failed: %w", err)
+	}
+
+	var failures []PreflightFailure
+	healthy := make([]decoders.Decoder, 0, len(r.Decoders))
+	for _, dec := range r.Decoders {
+		var decoded []byte
+		err := r.guardDecode(func() error {
+			var decodeErr error
+			decoded, decodeErr = dec.Decode(encoded.Image)
+			return decodeErr
+		})
+		if err == nil && !bytes.Equal(decoded, []byte(preflightData)) {
+			err = DataMismatchError{Expected: len(preflightData), Got: len(decoded)}
+		}
+
+		if err != nil {
+			failures = append(failures, PreflightFailure{DecoderName: dec.Name(), Err: err})
+			continue
+		}
+		healthy = append(healthy, dec)
+	}
+
+	if r.Config.DropUnhealthyDecoders {
+		r.Decoders = healthy
+	}
+	return failures, nil
+}
@@ -0,0 +1,94 @@
+package matrix
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+// brokenStubDecoder fails every decode, like a decoder whose C library is
+// missing at runtime.
+type brokenStubDecoder struct{}
+
+func (d *brokenStubDecoder) Name() string { return "stub/broken" }
+
+func (d *brokenStubDecoder) Decode(img image.Image) ([]byte, error) {
+	return nil, errors.New("libquirc.so: cannot open shared object file")
+}
+
+func TestRunner_Preflight(t *testing.T) {
+	tests := []struct {
+		name         string
+		drop         bool
+		wantDecoders []string
+	}{
+		{"warn only", false, []string{"makiuchi-d/gozxing", "stub/broken", "stub/corrupting", "stub/panic"}},
+		{"drop unhealthy", true, []string{"makiuchi-d/gozxing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.DropUnhealthyDecoders = tt.drop
+			decs := []decoders.Decoder{
+				&decoders.GozxingDecoder{},
+				&brokenStubDecoder{},
+				&corruptingStubDecoder{},
+				&panicStubDecoder{},
+			}
+			cases := []testdata.TestCase{{Name: "numeric-1b-256px", Data: []byte("1"), DataSize: 1, PixelSize: 256}}
+
+			runner, err := NewRunner(cfg, []encoders.Encoder{&encoders.Skip2Encoder{}}, decs, cases)
+			if err != nil {
+				t.Fatalf("NewRunner() failed: %v", err)
+			}
+
+			failures, err := runner.Preflight()
+			if err != nil {
+				t.Fatalf("Preflight() failed: %v", err)
+			}
+
+			want := []string{"stub/broken", "stub/corrupting", "stub/panic"}
+			if len(failures) != len(want) {
+				t.Fatalf("Preflight() reported %d failures, want %d: %+v", len(failures), len(want), failures)
+			}
+			for i, f := range failures {
+				if f.DecoderName != want[i] {
+					t.Errorf("failures[%d] = %q, want %q", i, f.DecoderName, want[i])
+				}
+			}
+
+			var decErr DecodeError
+			if !errors.As(failures[0].Err, &decErr) {
+				t.Errorf("broken decoder error = %v, want a DecodeError", failures[0].Err)
+			}
+			var dataErr DataMismatchError
+			if !errors.As(failures[1].Err, &dataErr) {
+				t.Errorf("corrupting decoder error = %v, want a DataMismatchError", failures[1].Err)
+			}
+			var panicErr PanicError
+			if !errors.As(failures[2].Err, &panicErr) {
+				t.Errorf("panicking decoder error = %v, want a PanicError", failures[2].Err)
+			}
+
+			var names []string
+			for _, dec := range runner.Decoders {
+				names = append(names, dec.Name())
+			}
+			if len(names) != len(tt.wantDecoders) {
+				t.Fatalf("Decoders after Preflight() = %v, want %v", names, tt.wantDecoders)
+			}
+			for i := range names {
+				if names[i] != tt.wantDecoders[i] {
+					t.Errorf("Decoders after Preflight() = %v, want %v", names, tt.wantDecoders)
+					break
+				}
+			}
+		})
+	}
+}
@@ -2,10 +2,21 @@
 package matrix
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrBlankImage is wrapped in an EncodeError when an encoder reports success
+// but returns a nil or single-tone (all white or all black) image. Decoding is
+// skipped so the failure is not misattributed to the decoders.
+var ErrBlankImage = errors.New("produced blank image")
+
+// ErrEncoderPanic is wrapped in an EncodeError, with the recovered value,
+// when an encoder library panics. Unlike PanicError it counts as an encode
+// failure, so the decoders are not blamed.
+var ErrEncoderPanic = errors.New("encoder panicked")
+
 // EncodeError indicates that QR code encoding failed.
 // This typically means the data exceeds QR code capacity at the requested size.
 // Not a reflection of encoder quality - it's a physical/capacity limitation.
@@ -17,10 +28,38 @@ func (e EncodeError) Error() string {
 	return fmt.Sprintf("encode failed: %v", e.Err)
 }
 
+// Unwrap returns the library error, so errors.Is and errors.As see through it.
 func (e EncodeError) Unwrap() error {
 	return e.Err
 }
 
+// EmptyDataError indicates the encoder rejected a zero-length payload.
+// QR codes cannot meaningfully carry empty data, so this is an expected
+// rejection, reported like a capacity skip rather than an encoder bug.
+type EmptyDataError struct {
+	Err error
+}
+
+func (e EmptyDataError) Error() string {
+	return fmt.Sprintf("empty data rejected: %v", e.Err)
+}
+
+// Unwrap returns the library error, so errors.Is and errors.As see through it.
+func (e EmptyDataError) Unwrap() error {
+	return e.Err
+}
+
+// UnsupportedContentError indicates the decoder cannot return this content
+// type faithfully (see decoders.TextOnlyDecoder), so the test was skipped
+// rather than counted as a decoder failure. Sets TestResult.IsUnsupported.
+type UnsupportedContentError struct {
+	ContentType string // "binary" or "structured append"
+}
+
+func (e UnsupportedContentError) Error() string {
+	return fmt.Sprintf("skipped: %s unsupported by decoder", e.ContentType)
+}
+
 // DecodeError indicates that QR code decoding failed.
 // This reflects actual decoder limitations or bugs.
 type DecodeError struct {
@@ -31,6 +70,7 @@ func (e DecodeError) Error() string {
 	return fmt.Sprintf("decode failed: %v", e.Err)
 }
 
+// Unwrap returns the library error, so errors.Is and errors.As see through it.
 func (e DecodeError) Unwrap() error {
 	return e.Err
 }
@@ -46,6 +86,40 @@ func (e DataMismatchError) Error() string {
 	return fmt.Sprintf("data mismatch: expected %d bytes, got %d bytes", e.Expected, e.Got)
 }
 
+// UndersizedError indicates the requested pixel size leaves too few pixels per
+// module for any decoder to read. This is a configuration problem, not a decoder
+// failure, so the decode step is skipped.
+type UndersizedError struct {
+	ModulePixelSize float64 // predicted pixels per module
+	Minimum         float64 // minimum pixels per module required
+}
+
+func (e UndersizedError) Error() string {
+	return fmt.Sprintf("undersized: %.2f pixels per module, need at least %.0f", e.ModulePixelSize, e.Minimum)
+}
+
+// TimeoutError indicates a stage did not finish within Config.Timeout.
+// The stage keeps running in the background; its result is discarded.
+type TimeoutError struct {
+	Stage   string        // "decode"
+	Timeout time.Duration // limit that was exceeded
+}
+
+func (e TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %v", e.Stage, e.Timeout)
+}
+
+// PanicError indicates a library panicked and the runner recovered.
+// Decoders that recover their own panics report a DecodeError instead.
+type PanicError struct {
+	Stage string      // "decode"
+	Value interface{} // value passed to panic
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %v", e.Stage, e.Value)
+}
+
 // TestResult captures the outcome of a single encode→decode test cycle.
 // Each test uses one encoder, one decoder, one data payload, and one pixel size.
 type TestResult struct {
@@ -55,6 +129,10 @@ type TestResult struct {
 	// DecoderName identifies which decoder read the QR code.
 	DecoderName string
 
+	// TestCase is the test case name; with EncoderName it keys the
+	// retained image in CompatibilityMatrix.Images.
+	TestCase string
+
 	// DataSize is the input data length in bytes.
 	DataSize int
 
@@ -72,15 +150,56 @@ type TestResult struct {
 	// Affects QR version selection for a given data size.
 	ErrorCorrectionLevel string
 
+	// Seed is the random seed that generated a binary payload in a seed
+	// sweep (Config.SeedSweep). 0 when the test was not part of a sweep.
+	Seed int64
+
+	// Repeat is the 1-based pass of a repeated run (Config.Repeat) that
+	// produced this result. 0 when the matrix ran once.
+	Repeat int
+
+	// CenterOcclusion is the fraction of the image area blanked from the
+	// center before decoding (Config.CenterOcclusion). 0 when not occluded.
+	CenterOcclusion float64
+
+	// CorruptFraction is the fraction of modules inverted before decoding
+	// (Config.CorruptFraction). 0 when not corrupted.
+	CorruptFraction float64
+
 	// QRVersion is the QR code version number (1-40).
 	// Determined by data size and error correction level.
 	// Version determines module count: moduleCount = 17 + 4*version.
 	QRVersion int
 
+	// VersionMismatch indicates the encoder reported one version but the
+	// symbol located in the image reads as another, pointing at a bug in the
+	// encoder's reporting or in the detector. False when no symbol is located.
+	VersionMismatch bool
+
 	// ModuleCount is the number of modules (black/white squares) per side.
 	// Includes data modules and function patterns, excludes quiet zone.
 	ModuleCount int
 
+	// CapacityUtilization is the fraction (0.0-1.0) of QRVersion's data
+	// capacity the payload used. Near 1 means the payload sits right at a
+	// version boundary. 0 when the version is unknown or the symbol is
+	// Micro QR.
+	CapacityUtilization float64
+
+	// IsMicroQR indicates the encoder produced a Micro QR code.
+	// When true, QRVersion is a Micro version (1-4 for M1-M4) and
+	// moduleCount = 9 + 2*version with a 2-module quiet zone.
+	IsMicroQR bool
+
+	// Mask is the data mask pattern (0-7) the encoder chose, read from the
+	// format information bits of the encoded image. Only meaningful when
+	// MaskDetected is set.
+	Mask int
+
+	// MaskDetected indicates Mask was read from the encoded image. The
+	// format information is not read for Micro QR or unlocated symbols.
+	MaskDetected bool
+
 	// ModulePixelSize is the calculated pixel dimension per module.
 	// Computed as: PixelSize / (ModuleCount + quietZone).
 	// Fractional values indicate potential decoder compatibility issues.
@@ -94,21 +213,79 @@ type TestResult struct {
 	// EncodeTime measures encoding duration.
 	EncodeTime time.Duration
 
+	// QRConstructTime, ImageEncodeTime, and ImageDecodeTime break EncodeTime
+	// into the phases of encoders that implement encoders.PhaseTimedEncoder:
+	// building the symbol, writing the PNG, and decoding it back. Together
+	// they account for nearly all of EncodeTime. Zero for other encoders.
+	QRConstructTime time.Duration
+	ImageEncodeTime time.Duration
+	ImageDecodeTime time.Duration
+
 	// DecodeTime measures decoding duration.
 	DecodeTime time.Duration
 
+	// DecodedLength is the number of bytes the decoder returned.
+	// Set for every completed decode, including data mismatches, so size
+	// drift (e.g., tuotoo's extra bytes) can be measured against DataSize.
+	// Only meaningful when Decoded is set: a decoder can return zero bytes.
+	DecodedLength int
+
+	// Decoded indicates the decoder returned without an error, so
+	// DecodedLength holds what it returned.
+	Decoded bool
+
+	// CharsetMismatch indicates a DataMismatchError where the decoded bytes
+	// are the input read with the wrong character set (UTF-8 vs Latin-1),
+	// not corrupted: the decoder read every module correctly but
+	// interpreted the byte-mode payload differently.
+	CharsetMismatch bool
+
+	// DecodedDigest is a hex SHA-256 of the bytes the decoder returned, so
+	// decoders that misread the same image can be told apart from ones
+	// that misread it identically. Empty when nothing was decoded and for
+	// multi-symbol cases.
+	DecodedDigest string
+
 	// Error captures the test outcome.
 	// nil indicates success (encode, decode, and data validation all succeeded).
 	// Typed errors indicate failure mode:
-	//   - EncodeError: encoding failed (capacity limit)
+	//   - EncodeError: encoding failed (capacity limit, ErrBlankImage, or ErrEncoderPanic)
+	//   - EmptyDataError: encoder rejected empty data (expected, skipped)
+	//   - UnsupportedContentError: decoder cannot return binary data (skipped)
 	//   - DecodeError: decoding failed (decoder issue)
 	//   - DataMismatchError: data corrupted (validation failure)
+	//   - UndersizedError: pixel size too small for the module count (decode skipped)
+	//   - TimeoutError: decoding exceeded Config.Timeout
+	//   - PanicError: decoder panicked without recovering
 	Error error
 
 	// IsCapacityExceeded indicates the encoder correctly reported that the data
 	// exceeds QR code capacity at the requested size. This is a valid rejection,
 	// not an encoder bug, and should be treated as a skipped test.
+	// Also set for an EmptyDataError, which is an equally valid rejection.
 	IsCapacityExceeded bool
+
+	// IsUnsupported indicates the decoder cannot return the content
+	// intact (UnsupportedContentError), so the test was skipped. It is a
+	// skip, but not a capacity rejection.
+	IsUnsupported bool
+
+	// IsBlindSpot indicates every decoder in the run failed this image but the
+	// reference decoder (Runner.Reference) read it, so the image is valid and
+	// the failure is shared by all tested decoders.
+	IsBlindSpot bool
+
+	// IsDecodeOutlier indicates DecodeTime was far above the other decodes
+	// of the same encoder/decoder pair (see FlagDecodeOutliers).
+	IsDecodeOutlier bool
+}
+
+// IsSkipped reports whether the test never reached a decode verdict: a
+// capacity or empty-data rejection, content the decoder cannot return, or
+// an undersized image. Skips are left out of success rates.
+func (r TestResult) IsSkipped() bool {
+	var sizeErr UndersizedError
+	return r.IsCapacityExceeded || r.IsUnsupported || errors.As(r.Error, &sizeErr)
 }
 
 // ModuleInfo captures QR code structural metadata.
@@ -147,6 +324,23 @@ type CompatibilityMatrix struct {
 
 	// PixelSizes lists image dimensions tested (in pixels).
 	PixelSizes []int
+
+	// Images holds the encoded images by encoder and test case name, so other
+	// decoders can be run against the exact same images without re-encoding.
+	// nil unless Config.RetainImages is set.
+	Images *ImageStore
+
+	// SeedStability reports per-combination results across seeds.
+	// nil unless Config.SeedSweep is greater than 1.
+	SeedStability []SeedStability
+
+	// Flakiness reports per-combination results across repeats.
+	// nil unless Config.Repeat is greater than 1.
+	Flakiness []Flakiness
+
+	// Skipped counts the tests never started because the run reached
+	// Config.MaxDuration. Results then holds only the tests before them.
+	Skipped int
 }
 
 // IncompatibilityPattern identifies systematic failure patterns between encoder/decoder pairs.
@@ -0,0 +1,83 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// Compile-time check that every matrix error type implements error.
+var (
+	_ error = EncodeError{}
+	_ error = DecodeError{}
+	_ error = DataMismatchError{}
+	_ error = UndersizedError{}
+	_ error = TimeoutError{}
+	_ error = PanicError{}
+)
+
+var errSentinel = errors.New("lib: sentinel")
+
+func TestMatrixErrors_UnwrapSentinel(t *testing.T) {
+	// Library errors are usually wrapped once before reaching the runner
+	libErr := fmt.Errorf("stub: %w", errSentinel)
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"EncodeError", EncodeError{Err: libErr}},
+		{"DecodeError", DecodeError{Err: libErr}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, errSentinel) {
+				t.Errorf("errors.Is(%v, errSentinel) = false, want true", tt.err)
+			}
+
+			// Also through a further wrap, as downstream code sees it
+			if !errors.Is(fmt.Errorf("test: %w", tt.err), errSentinel) {
+				t.Errorf("errors.Is through an outer wrap = false, want true")
+			}
+		})
+	}
+
+	var decodeErr DecodeError
+	if !errors.As(fmt.Errorf("test: %w", DecodeError{Err: libErr}), &decodeErr) {
+		t.Error("errors.As(DecodeError) = false, want true")
+	}
+}
+
+func TestDataMismatchError_Error(t *testing.T) {
+	err := error(DataMismatchError{Expected: 10, Got: 12})
+
+	if got, want := err.Error(), "data mismatch: expected 10 bytes, got 12 bytes"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if errors.Unwrap(err) != nil {
+		t.Error("DataMismatchError should not wrap another error")
+	}
+}
+
+func TestTestResult_IsSkipped(t *testing.T) {
+	tests := []struct {
+		name   string
+		result TestResult
+		want   bool
+	}{
+		{"success", TestResult{}, false},
+		{"decode failure", TestResult{Error: DecodeError{Err: errSentinel}}, false},
+		{"capacity", TestResult{Error: EncodeError{Err: errSentinel}, IsCapacityExceeded: true}, true},
+		{"unsupported", TestResult{Error: UnsupportedContentError{ContentType: "binary"}, IsUnsupported: true}, true},
+		{"undersized", TestResult{Error: UndersizedError{ModulePixelSize: 0.5, Minimum: 2}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.IsSkipped(); got != tt.want {
+				t.Errorf("IsSkipped() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,13 +3,18 @@ package matrix
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"image"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/13rac1/qr-library-test/internal/config"
 	"github.com/13rac1/qr-library-test/internal/decoders"
 	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/raster"
 	"github.com/13rac1/qr-library-test/internal/testdata"
 )
 
@@ -21,16 +26,65 @@ type Runner struct {
 	Decoders  []decoders.Decoder
 	TestCases []testdata.TestCase
 	Config    *config.Config
+
+	// Reference optionally cross-validates failures. When every decoder
+	// fails an image and Reference reads it, the results are flagged as
+	// decoder blind spots rather than bad images. nil disables it.
+	Reference decoders.Decoder
+
+	// completed counts finished tests for progress output.
+	// Atomic so parallel workers each get a unique test number.
+	completed atomic.Int64
+
+	// printMu serializes progress output so lines from parallel workers don't interleave.
+	printMu sync.Mutex
+
+	// images retains encoded images when Config.RetainImages is set.
+	images *ImageStore
 }
 
 // NewRunner creates a test runner with the provided components.
-func NewRunner(cfg *config.Config, encs []encoders.Encoder, decs []decoders.Decoder, cases []testdata.TestCase) *Runner {
+// It returns an error if two encoders or two decoders share a name, or a
+// name is empty: results are keyed and written to files by name, so a
+// collision would silently merge or overwrite another library's results.
+func NewRunner(cfg *config.Config, encs []encoders.Encoder, decs []decoders.Decoder, cases []testdata.TestCase) (*Runner, error) {
+	encNames := make([]string, len(encs))
+	for i, enc := range encs {
+		encNames[i] = enc.Name()
+	}
+	if err := checkNames("encoder", encNames); err != nil {
+		return nil, err
+	}
+
+	decNames := make([]string, len(decs))
+	for i, dec := range decs {
+		decNames[i] = dec.Name()
+	}
+	if err := checkNames("decoder", decNames); err != nil {
+		return nil, err
+	}
+
 	return &Runner{
 		Encoders:  encs,
 		Decoders:  decs,
 		TestCases: cases,
 		Config:    cfg,
+	}, nil
+}
+
+// checkNames returns an error for the first empty or repeated name.
+func checkNames(kind string, names []string) error {
+	seen := make(map[string]int, len(names))
+	for i, name := range names {
+		if name == "" {
+			return fmt.Errorf("%s %d has an empty name", kind, i)
+		}
+		if first, ok := seen[name]; ok {
+			return fmt.Errorf("%ss %d and %d share the name %q; results are keyed by name", kind, first, i, name)
+		}
+		seen[name] = i
 	}
+	return nil
 }
 
 // RunAll executes the complete test matrix and returns aggregated results.
@@ -47,8 +101,11 @@ func (r *Runner) RunAll() (*CompatibilityMatrix, error) {
 		return nil, fmt.Errorf("no test cases provided")
 	}
 
+	// Binary cases repeat once per seed in a seed sweep
+	testCases := r.testCases()
+
 	// Calculate total number of tests
-	totalTests := len(r.Encoders) * len(r.Decoders) * len(r.TestCases)
+	totalTests := r.TotalTests()
 	results := make([]TestResult, 0, totalTests)
 
 	// Collect unique data sizes and pixel sizes for matrix metadata
@@ -64,24 +121,49 @@ func (r *Runner) RunAll() (*CompatibilityMatrix, error) {
 		decoderNames[i] = dec.Name()
 	}
 
-	// Run all test combinations
-	testNum := 0
-	for _, testCase := range r.TestCases {
-		dataSizeMap[testCase.DataSize] = true
-		pixelSizeMap[testCase.PixelSize] = true
-
-		for _, encoder := range r.Encoders {
-			for _, decoder := range r.Decoders {
-				testNum++
-				result := r.runTest(testCase, encoder, decoder)
-				results = append(results, result)
+	r.images = nil
+	if r.Config.RetainImages {
+		r.images = NewImageStore(r.Config.MaxRetainedImages)
+	}
 
-				// Print progress
-				r.printProgress(testNum, totalTests, testCase, encoder, decoder, result)
+	// Run all test combinations, the whole matrix once per repeat.
+	// Past MaxDuration no new encoder group starts, so cross-validation
+	// always sees every decoder of a group
+	r.completed.Store(0)
+	runStart := time.Now()
+	skipped := 0
+run:
+	for repeat := 1; repeat <= r.repeats(); repeat++ {
+		for _, testCase := range testCases {
+			for _, encoder := range r.Encoders {
+				if r.Config.MaxDuration > 0 && time.Since(runStart) >= r.Config.MaxDuration {
+					skipped = totalTests - len(results)
+					break run
+				}
+				dataSizeMap[testCase.DataSize] = true
+				pixelSizeMap[testCase.PixelSize] = true
+
+				start := len(results)
+				for _, decoder := range r.Decoders {
+					result := r.runTest(testCase, encoder, decoder)
+					if r.repeats() > 1 {
+						result.Repeat = repeat
+					}
+					results = append(results, result)
+
+					// Print progress
+					r.recordProgress(totalTests, testCase, encoder, decoder, result)
+				}
+
+				if r.Reference != nil {
+					r.crossValidate(testCase, encoder, results[start:])
+				}
 			}
 		}
 	}
 
+	FlagDecodeOutliers(results)
+
 	// Convert maps to sorted slices
 	dataSizes := make([]int, 0, len(dataSizeMap))
 	for size := range dataSizeMap {
@@ -93,15 +175,47 @@ func (r *Runner) RunAll() (*CompatibilityMatrix, error) {
 		pixelSizes = append(pixelSizes, size)
 	}
 
+	var stability []SeedStability
+	if r.Config.SeedSweep > 1 {
+		stability = AnalyzeSeedStability(results)
+	}
+
+	var flakiness []Flakiness
+	if r.repeats() > 1 {
+		flakiness = AnalyzeFlakiness(results)
+	}
+
 	return &CompatibilityMatrix{
-		Results:    results,
-		Encoders:   encoderNames,
-		Decoders:   decoderNames,
-		DataSizes:  dataSizes,
-		PixelSizes: pixelSizes,
+		Results:       results,
+		Encoders:      encoderNames,
+		Decoders:      decoderNames,
+		DataSizes:     dataSizes,
+		PixelSizes:    pixelSizes,
+		Images:        r.images,
+		SeedStability: stability,
+		Flakiness:     flakiness,
+		Skipped:       skipped,
 	}, nil
 }
 
+// testCases returns the test cases to run, with binary cases expanded
+// across Config.SeedSweep seeds.
+func (r *Runner) testCases() []testdata.TestCase {
+	return testdata.ExpandSeeds(r.TestCases, r.Config.SeedSweep)
+}
+
+// repeats returns how many times RunAll runs the matrix: Config.Repeat,
+// at least once.
+func (r *Runner) repeats() int {
+	return max(r.Config.Repeat, 1)
+}
+
+// TotalTests returns the number of tests RunAll will execute, counting each
+// seed of a seed sweep and each repeat separately.
+func (r *Runner) TotalTests() int {
+	return len(r.Encoders) * len(r.Decoders) * len(r.testCases()) * r.repeats()
+}
+
 // runTest executes a single encode→decode→validate cycle.
 // Returns a TestResult capturing timing, success status, and module information.
 func (r *Runner) runTest(testCase testdata.TestCase, enc encoders.Encoder, dec decoders.Decoder) TestResult {
@@ -124,65 +238,238 @@ func (r *Runner) runTest(testCase testdata.TestCase, enc encoders.Encoder, dec d
 	result := TestResult{
 		EncoderName:          enc.Name(),
 		DecoderName:          dec.Name(),
+		TestCase:             testCase.Name,
 		DataSize:             testCase.DataSize,
 		PixelSize:            testCase.PixelSize,
 		ContentType:          contentTypeToString(testCase.ContentType),
 		ErrorCorrectionLevel: testCase.ErrorCorrectionLevel,
+		Seed:                 testCase.Seed,
+		CenterOcclusion:      r.Config.CenterOcclusion,
+		CorruptFraction:      r.Config.CorruptFraction,
 		QRVersion:            -1, // Will be updated if version detection succeeds
 		ModuleCount:          0,  // Will be updated if version detection succeeds
 	}
 
+	// Text-only decoders cannot return byte-mode data intact; a failure
+	// here would measure the library's API, not its reading ability
+	if testCase.ContentType == testdata.ContentBinary && !decoders.SupportsBinary(dec) {
+		result.Error = UnsupportedContentError{ContentType: result.ContentType}
+		result.IsUnsupported = true
+		return result
+	}
+
 	// Encode QR code with timing
 	encodeOpts := encoders.EncodeOptions{
 		ErrorCorrectionLevel: ecLevel,
 		PixelSize:            testCase.PixelSize,
+		MicroQR:              r.Config.MicroQR,
+		ForceVersion:         r.Config.ForceVersion,
+
+		PixelSizeIncludesQuietZone: r.Config.PixelSizeIncludesQuietZone,
 	}
 
+	// Multi-symbol test cases encode one image per payload, and sequence
+	// encoders may split a single payload over several; the first symbol
+	// drives version, module, and mask detection
+	payloads := testCase.Payloads()
+	images := make([]image.Image, 0, len(payloads))
+
+	var encodeResult encoders.EncodeResult
+	var info encoders.ModuleInfo
+
 	encodeStart := time.Now()
-	encodeResult, err := enc.Encode(testCase.Data, encodeOpts)
+	for i, payload := range payloads {
+		symbolResults, symbolInfo, timings, err := encodeSymbols(enc, payload, encodeOpts, !testCase.IsMultiSymbol())
+		result.QRConstructTime += timings.QRConstruct
+		result.ImageEncodeTime += timings.ImageEncode
+		result.ImageDecodeTime += timings.ImageDecode
+		if err != nil {
+			result.EncodeTime = time.Since(encodeStart)
+			result.Error = EncodeError{Err: err}
+			result.IsCapacityExceeded = enc.IsCapacityError(err)
+			if len(payload) == 0 && !errors.Is(err, ErrEncoderPanic) {
+				// Rejecting empty data is correct, not an encoder failure
+				result.Error = EmptyDataError{Err: err}
+				result.IsCapacityExceeded = true
+			}
+			return result
+		}
+		for j, symbolResult := range symbolResults {
+			if symbolResult.Image == nil || testdata.IsBlank(symbolResult.Image) {
+				result.EncodeTime = time.Since(encodeStart)
+				result.Error = EncodeError{Err: ErrBlankImage}
+				return result
+			}
+			if i == 0 && j == 0 {
+				encodeResult, info = symbolResult, symbolInfo
+			}
+			images = append(images, symbolResult.Image)
+		}
+	}
 	result.EncodeTime = time.Since(encodeStart)
-
-	if err != nil {
-		result.Error = EncodeError{Err: err}
-		result.IsCapacityExceeded = enc.IsCapacityError(err)
-		return result
+	sequence := len(images) > len(payloads)
+
+	// skip2, boombuler, and gozxing render exactly PixelSize; natively yeqown
+	// renders whole pixels per module plus padding, so its output is resized
+	// to match. Padded encoders add their border outside PixelSize on
+	// purpose and are decoded as rendered
+	if !encoders.RendersOversized(enc) {
+		for i, symbolImg := range images {
+			images[i] = r.fitPixelSize(symbolImg, testCase.PixelSize)
+		}
+	}
+	img := images[0]
+
+	// Micro QR codes use a different module formula and quiet zone
+	result.IsMicroQR = encodeResult.MicroQR
+	detectVersion := testdata.LocateQRVersion
+	moduleCount := testdata.CalculateModuleCount
+	quietZone := r.Config.QuietZoneModules
+	if encodeResult.MicroQR {
+		detectVersion = testdata.DetectMicroQRVersion
+		moduleCount = testdata.CalculateMicroModuleCount
+		quietZone = testdata.MicroQuietZoneModules
 	}
 
-	img := encodeResult.Image
-
-	// Use version from encoder (or fallback to image detection)
+	// Use version from encoder (or fallback to image detection). A
+	// mismatch is only flagged when the symbol was located and read
 	version := encodeResult.Version
+	detected, detectErr := detectVersion(img)
 	if version <= 0 {
 		// Fallback to image-based detection
-		version, _ = testdata.DetectQRVersion(img)
+		version = detected
+	} else if detectErr == nil && detected != version {
+		result.VersionMismatch = true
 	}
 
 	if version > 0 {
 		result.QRVersion = version
-		result.ModuleCount = testdata.CalculateModuleCount(version)
+		result.ModuleCount = info.ModuleCount
+		if result.ModuleCount <= 0 || version != info.Version {
+			result.ModuleCount = moduleCount(version)
+		}
 
-		// Calculate module pixel size
-		modulePixelSize := testdata.CalculateModulePixelSize(testCase.PixelSize, result.ModuleCount, testdata.QuietZoneModules)
+		// Capacity tables cover standard QR only; the first payload is the
+		// one that chose the version
+		if !encodeResult.MicroQR {
+			if used, err := testdata.CapacityUtilization(len(payloads[0]), testCase.ContentType, version, ecLevel); err == nil {
+				result.CapacityUtilization = used
+			}
+		}
+
+		// Encoders render different margins; measure it when possible.
+		// The margin is measured per side and the symbol has one on each
+		if !encodeResult.MicroQR {
+			if measured, ok := testdata.MeasureQuietZoneModules(img, result.ModuleCount); ok {
+				quietZone = 2 * measured
+			}
+		}
+
+		// Calculate module pixel size from the image as decoded, which is
+		// PixelSize unless the encoder renders oversized
+		modulePixelSize := testdata.CalculateModulePixelSize(img.Bounds().Dx(), result.ModuleCount, quietZone)
 		result.ModulePixelSize = modulePixelSize
 		result.IsFractionalModule = testdata.IsFractionalModuleSize(modulePixelSize)
 	}
 
+	// Below the minimum module size every decoder fails; report the
+	// configuration problem instead of a decode failure
+	if result.ModulePixelSize > 0 && result.ModulePixelSize < testdata.MinModulePixelSize {
+		result.Error = UndersizedError{
+			ModulePixelSize: result.ModulePixelSize,
+			Minimum:         testdata.MinModulePixelSize,
+		}
+		return result
+	}
+
+	// Micro QR format information uses a different layout
+	if !encodeResult.MicroQR {
+		if mask, err := testdata.DetectMaskPattern(img); err == nil {
+			result.Mask = mask
+			result.MaskDetected = true
+		}
+	}
+
+	// Blank the center of each symbol, like a logo on a branded code
+	if r.Config.CenterOcclusion > 0 {
+		for i := range images {
+			images[i] = raster.OverlayCenterBlock(images[i], r.Config.CenterOcclusion)
+		}
+		img = images[0]
+	}
+
+	// Flip random modules to exercise error correction
+	if r.Config.CorruptFraction > 0 && !encodeResult.MicroQR && result.ModuleCount > 0 {
+		for i := range images {
+			images[i] = raster.CorruptModules(images[i], result.ModuleCount, quietZone, r.Config.CorruptFraction, r.Config.CorruptSeed)
+		}
+		img = images[0]
+	}
+
+	if testCase.IsMultiSymbol() {
+		img = testdata.CompositeSideBySide(images...)
+	}
+
+	// Place the symbol off-center in a larger frame, like a photo
+	if r.Config.EmbedScale > 1 && len(images) == 1 {
+		b := img.Bounds()
+		slack := b.Size().Mul(r.Config.EmbedScale - 1)
+		at := image.Point{X: slack.X / 3, Y: slack.Y * 2 / 3}
+		img = testdata.EmbedInCanvas(img, b.Dx()*r.Config.EmbedScale, b.Dy()*r.Config.EmbedScale, at)
+	}
+
+	// Crop back to the symbol; decode the whole image if none is found
+	if r.Config.LocateCrop && len(images) == 1 && !encodeResult.MicroQR {
+		if cropped, err := testdata.CropToQR(img); err == nil {
+			img = cropped
+		}
+	}
+
+	// Sequences are decoded one image per symbol and not retained
+	if sequence {
+		return r.decodeSequence(result, dec, images, testCase.Data)
+	}
+
+	img = r.applyColorModel(img)
+
+	// Keep the exact image the decoder sees for post-run re-decoding
+	if r.images != nil {
+		r.images.Put(ImageKey{Encoder: enc.Name(), TestCase: testCase.Name}, img)
+	}
+
+	if testCase.IsMultiSymbol() {
+		return r.decodeMultiSymbol(result, dec, img, payloads)
+	}
+
 	// Decode QR code with timing
 	decodeStart := time.Now()
-	decodedData, err := dec.Decode(img)
+	var decodedData []byte
+	err := r.guardDecode(func() (decodeErr error) {
+		if ed, ok := dec.(decoders.ExpectedLenDecoder); ok {
+			decodedData, decodeErr = ed.DecodeWithExpectedLen(img, len(testCase.Data))
+			return decodeErr
+		}
+		decodedData, decodeErr = dec.Decode(img)
+		return decodeErr
+	})
 	result.DecodeTime = time.Since(decodeStart)
 
 	if err != nil {
-		result.Error = DecodeError{Err: err}
+		result.Error = err
 		return result
 	}
 
+	result.Decoded = true
+	result.DecodedLength = len(decodedData)
+	result.DecodedDigest = fmt.Sprintf("%x", sha256.Sum256(decodedData))
+
 	// Validate decoded data matches original
 	if !bytes.Equal(testCase.Data, decodedData) {
 		result.Error = DataMismatchError{
 			Expected: len(testCase.Data),
 			Got:      len(decodedData),
 		}
+		result.CharsetMismatch = isCharsetMisread(testCase.Data, decodedData)
 	} else {
 		result.Error = nil
 	}
@@ -190,6 +477,265 @@ func (r *Runner) runTest(testCase testdata.TestCase, enc encoders.Encoder, dec d
 	return result
 }
 
+// crossValidate decodes the image with the reference decoder when every
+// other decoder failed to read it. If the reference succeeds, the image is
+// readable and each failure is marked as a decoder blind spot.
+// Results are updated in place.
+func (r *Runner) crossValidate(testCase testdata.TestCase, enc encoders.Encoder, group []TestResult) {
+	refName := r.Reference.Name()
+
+	failures := 0
+	for _, result := range group {
+		if result.DecoderName == refName {
+			continue
+		}
+		if !isReadFailure(result.Error) {
+			return
+		}
+		failures++
+	}
+	if failures == 0 {
+		return
+	}
+
+	if ref := r.runTest(testCase, enc, r.Reference); ref.Error != nil {
+		return
+	}
+
+	for i := range group {
+		if group[i].DecoderName != refName {
+			group[i].IsBlindSpot = true
+		}
+	}
+}
+
+// isReadFailure reports whether err means a decoder could not read a valid
+// encoded image, as opposed to encoding failing or the decode being skipped.
+func isReadFailure(err error) bool {
+	var decErr DecodeError
+	var dataErr DataMismatchError
+	var timeoutErr TimeoutError
+	var panicErr PanicError
+	return errors.As(err, &decErr) || errors.As(err, &dataErr) ||
+		errors.As(err, &timeoutErr) || errors.As(err, &panicErr)
+}
+
+// encodeSymbols encodes one payload with encodeSymbol or, when
+// allowSequence is set and enc is a SequenceEncoder, as a sequence of one or
+// more symbols. A panic in EncodeSequence is returned as ErrEncoderPanic.
+func encodeSymbols(enc encoders.Encoder, data []byte, opts encoders.EncodeOptions, allowSequence bool) (results []encoders.EncodeResult, info encoders.ModuleInfo, timings encoders.EncodeTimings, err error) {
+	se, ok := enc.(encoders.SequenceEncoder)
+	if !ok || !allowSequence {
+		result, info, timings, err := encodeSymbol(enc, data, opts)
+		return []encoders.EncodeResult{result}, info, timings, err
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			results = nil
+			err = fmt.Errorf("%w: %v", ErrEncoderPanic, v)
+		}
+	}()
+	results, err = se.EncodeSequence(data, opts)
+	return results, encoders.ModuleInfo{}, encoders.EncodeTimings{}, err
+}
+
+// encodeSymbol encodes one payload and describes the symbol from the
+// library-reported version. Phase timings are zero unless the encoder is a
+// PhaseTimedEncoder. A panic in the encoder library is returned as
+// ErrEncoderPanic so one bad encode does not abort the matrix.
+func encodeSymbol(enc encoders.Encoder, data []byte, opts encoders.EncodeOptions) (result encoders.EncodeResult, info encoders.ModuleInfo, timings encoders.EncodeTimings, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			result, info, timings = encoders.EncodeResult{}, encoders.ModuleInfo{}, encoders.EncodeTimings{}
+			err = fmt.Errorf("%w: %v", ErrEncoderPanic, v)
+		}
+	}()
+
+	if pt, ok := enc.(encoders.PhaseTimedEncoder); ok {
+		img, info, timings, err := pt.EncodeWithTimings(data, opts)
+		return encoders.EncodeResult{Image: img, Version: info.Version, MicroQR: info.MicroQR}, info, timings, err
+	}
+
+	encodeResult, err := enc.Encode(data, opts)
+	return encodeResult, encodeResult.ModuleInfo(), encoders.EncodeTimings{}, err
+}
+
+// fitPixelSize resizes img to size×size when the encoder rendered a different size.
+func (r *Runner) fitPixelSize(img image.Image, size int) image.Image {
+	if b := img.Bounds(); b.Dx() == size && b.Dy() == size {
+		return img
+	}
+	if r.Config.DisableAntialiasing {
+		return raster.ScaleNearest(img, size)
+	}
+	return raster.ScaleSmooth(img, size)
+}
+
+// decodeMultiSymbol decodes every symbol in a composited image and checks that
+// each payload was recovered exactly once, in any order.
+// Decoders that return only the first symbol fail with ErrMultiSymbolUnsupported.
+func (r *Runner) decodeMultiSymbol(result TestResult, dec decoders.Decoder, img image.Image, payloads [][]byte) TestResult {
+	md, ok := dec.(decoders.MultiDecoder)
+	if !ok {
+		result.Error = DecodeError{Err: decoders.ErrMultiSymbolUnsupported}
+		return result
+	}
+
+	decodeStart := time.Now()
+	var decoded [][]byte
+	err := r.guardDecode(func() (decodeErr error) {
+		decoded, decodeErr = md.DecodeAll(img)
+		return decodeErr
+	})
+	result.DecodeTime = time.Since(decodeStart)
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	expectedLength := 0
+	for _, payload := range payloads {
+		expectedLength += len(payload)
+	}
+	result.Decoded = true
+	for _, data := range decoded {
+		result.DecodedLength += len(data)
+	}
+
+	if !samePayloads(payloads, decoded) {
+		result.Error = DataMismatchError{
+			Expected: expectedLength,
+			Got:      result.DecodedLength,
+		}
+	}
+
+	return result
+}
+
+// decodeSequence decodes a structured-append sequence, one image per
+// symbol, and checks the reassembled payload. Decoders that cannot
+// reassemble a sequence are skipped, like binary data on a text-only
+// decoder.
+func (r *Runner) decodeSequence(result TestResult, dec decoders.Decoder, imgs []image.Image, data []byte) TestResult {
+	sd, ok := dec.(decoders.SequenceDecoder)
+	if !ok {
+		result.Error = UnsupportedContentError{ContentType: "structured append"}
+		result.IsUnsupported = true
+		return result
+	}
+
+	for i := range imgs {
+		imgs[i] = r.applyColorModel(imgs[i])
+	}
+
+	decodeStart := time.Now()
+	var decoded []byte
+	err := r.guardDecode(func() (decodeErr error) {
+		decoded, decodeErr = sd.DecodeSequence(imgs)
+		return decodeErr
+	})
+	result.DecodeTime = time.Since(decodeStart)
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Decoded = true
+	result.DecodedLength = len(decoded)
+	result.DecodedDigest = fmt.Sprintf("%x", sha256.Sum256(decoded))
+	if !bytes.Equal(data, decoded) {
+		result.Error = DataMismatchError{
+			Expected: len(data),
+			Got:      len(decoded),
+		}
+	}
+
+	return result
+}
+
+// applyColorModel converts img to Config.ColorModel, so binarizers see
+// like input from every encoder.
+func (r *Runner) applyColorModel(img image.Image) image.Image {
+	switch r.Config.ColorModel {
+	case "gray":
+		return raster.ToGray(img)
+	case "rgba":
+		return raster.ToRGBA(img)
+	}
+	return img
+}
+
+// guardDecode runs decode with Config.Timeout and panic recovery.
+// Returns TimeoutError when the limit passes first, PanicError when decode
+// panics, and DecodeError wrapping any error decode returns.
+// A timed-out decode keeps running in its goroutine; its result is discarded.
+func (r *Runner) guardDecode(decode func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				done <- PanicError{Stage: "decode", Value: v}
+			}
+		}()
+		if err := decode(); err != nil {
+			done <- DecodeError{Err: err}
+			return
+		}
+		done <- nil
+	}()
+
+	if r.Config.Timeout <= 0 {
+		return <-done
+	}
+
+	timer := time.NewTimer(r.Config.Timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return TimeoutError{Stage: "decode", Timeout: r.Config.Timeout}
+	}
+}
+
+// samePayloads reports whether got holds exactly the payloads in want, in any order.
+func samePayloads(want, got [][]byte) bool {
+	if len(want) != len(got) {
+		return false
+	}
+
+	used := make([]bool, len(got))
+	for _, w := range want {
+		found := false
+		for i, g := range got {
+			if !used[i] && bytes.Equal(w, g) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// recordProgress counts a finished test and prints its progress line.
+// Safe to call from concurrent workers: each call gets a unique test number.
+// Nothing is printed when Config.Quiet is set.
+func (r *Runner) recordProgress(totalTests int, testCase testdata.TestCase, enc encoders.Encoder, dec decoders.Decoder, result TestResult) {
+	testNum := int(r.completed.Add(1))
+	if r.Config.Quiet {
+		return
+	}
+	r.printProgress(testNum, totalTests, testCase, enc, dec, result)
+}
+
 // printProgress outputs real-time test progress to stdout.
 // Shows test number, status (✓/✗), data type, dimensions, encoder, and timing.
 func (r *Runner) printProgress(testNum, totalTests int, testCase testdata.TestCase, enc encoders.Encoder, dec decoders.Decoder, result TestResult) {
@@ -200,8 +746,13 @@ func (r *Runner) printProgress(testNum, totalTests int, testCase testdata.TestCa
 	if result.Error != nil {
 		// Set status based on error type
 		var encErr EncodeError
+		var emptyErr EmptyDataError
+		var unsupportedErr UnsupportedContentError
 		var decErr DecodeError
 		var dataErr DataMismatchError
+		var sizeErr UndersizedError
+		var timeoutErr TimeoutError
+		var panicErr PanicError
 
 		if errors.As(result.Error, &encErr) {
 			if result.IsCapacityExceeded {
@@ -211,12 +762,27 @@ func (r *Runner) printProgress(testNum, totalTests int, testCase testdata.TestCa
 				status = "✗ (encode)"
 				statusColor = "\033[31m" // Red
 			}
+		} else if errors.As(result.Error, &emptyErr) {
+			status = "⊘ (empty)"
+			statusColor = "\033[33m" // Yellow
+		} else if errors.As(result.Error, &unsupportedErr) {
+			status = "⊘ (unsupported)"
+			statusColor = "\033[33m" // Yellow
 		} else if errors.As(result.Error, &decErr) {
 			status = "✗ (decode)"
 			statusColor = "\033[31m" // Red
 		} else if errors.As(result.Error, &dataErr) {
 			status = "✗ (data)"
 			statusColor = "\033[31m" // Red
+		} else if errors.As(result.Error, &sizeErr) {
+			status = "⊘ (undersized)"
+			statusColor = "\033[33m" // Yellow
+		} else if errors.As(result.Error, &timeoutErr) {
+			status = "✗ (timeout)"
+			statusColor = "\033[31m" // Red
+		} else if errors.As(result.Error, &panicErr) {
+			status = "✗ (panic)"
+			statusColor = "\033[31m" // Red
 		} else {
 			status = "✗"
 			statusColor = "\033[31m" // Red
@@ -227,6 +793,9 @@ func (r *Runner) printProgress(testNum, totalTests int, testCase testdata.TestCa
 	// Content type label
 	contentLabel := contentTypeToString(testCase.ContentType)
 
+	r.printMu.Lock()
+	defer r.printMu.Unlock()
+
 	// Print test result
 	fmt.Printf("[%d/%d] %s%s%s %s %d bytes @ %dpx EC:%s (%s+%s) - %.1fms encode, %.1fms decode\n",
 		testNum, totalTests,
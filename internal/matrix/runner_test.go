@@ -1,7 +1,19 @@
 package matrix
 
 import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/13rac1/qr-library-test/internal/config"
 	"github.com/13rac1/qr-library-test/internal/decoders"
@@ -23,7 +35,10 @@ func TestNewRunner(t *testing.T) {
 		},
 	}
 
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
 	if runner == nil {
 		t.Fatal("NewRunner() returned nil")
@@ -46,6 +61,56 @@ func TestNewRunner(t *testing.T) {
 	}
 }
 
+func TestNewRunner_InvalidNames(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	tests := []struct {
+		name    string
+		encs    []encoders.Encoder
+		decs    []decoders.Decoder
+		wantErr string
+	}{
+		{
+			name:    "duplicate encoder",
+			encs:    []encoders.Encoder{&encoders.Skip2Encoder{}, &encoders.Skip2Encoder{}},
+			decs:    []decoders.Decoder{&decoders.GozxingDecoder{}},
+			wantErr: `encoders 0 and 1 share the name "skip2/go-qrcode"`,
+		},
+		{
+			name:    "duplicate decoder",
+			encs:    []encoders.Encoder{&encoders.Skip2Encoder{}},
+			decs:    []decoders.Decoder{&decoders.GozxingDecoder{}, &panicStubDecoder{}, &decoders.GozxingDecoder{}},
+			wantErr: `decoders 0 and 2 share the name "makiuchi-d/gozxing"`,
+		},
+		{
+			name:    "empty decoder name",
+			encs:    []encoders.Encoder{&encoders.Skip2Encoder{}},
+			decs:    []decoders.Decoder{&namelessStubDecoder{}},
+			wantErr: "decoder 0 has an empty name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, err := NewRunner(cfg, tt.encs, tt.decs, nil)
+			if err == nil {
+				t.Fatal("NewRunner() succeeded, want error")
+			}
+			if runner != nil {
+				t.Error("NewRunner() returned a runner alongside an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewRunner() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// namelessStubDecoder is a decoder that reports an empty name.
+type namelessStubDecoder struct{ panicStubDecoder }
+
+func (d *namelessStubDecoder) Name() string { return "" }
+
 func TestRunner_RunAll_NoEncoders(t *testing.T) {
 	cfg := config.DefaultConfig()
 	dec := &decoders.GozxingDecoder{}
@@ -59,9 +124,12 @@ func TestRunner_RunAll_NoEncoders(t *testing.T) {
 		},
 	}
 
-	runner := NewRunner(cfg, []encoders.Encoder{}, []decoders.Decoder{dec}, cases)
+	runner, err := NewRunner(cfg, []encoders.Encoder{}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
-	_, err := runner.RunAll()
+	_, err = runner.RunAll()
 	if err == nil {
 		t.Error("RunAll() with no encoders should fail")
 	}
@@ -80,9 +148,12 @@ func TestRunner_RunAll_NoDecoders(t *testing.T) {
 		},
 	}
 
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{}, cases)
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
-	_, err := runner.RunAll()
+	_, err = runner.RunAll()
 	if err == nil {
 		t.Error("RunAll() with no decoders should fail")
 	}
@@ -93,9 +164,12 @@ func TestRunner_RunAll_NoTestCases(t *testing.T) {
 	enc := &encoders.Skip2Encoder{}
 	dec := &decoders.GozxingDecoder{}
 
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, []testdata.TestCase{})
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, []testdata.TestCase{})
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
-	_, err := runner.RunAll()
+	_, err = runner.RunAll()
 	if err == nil {
 		t.Error("RunAll() with no test cases should fail")
 	}
@@ -118,7 +192,10 @@ func TestRunner_RunAll_SingleTest(t *testing.T) {
 		},
 	}
 
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
 	results, err := runner.RunAll()
 	if err != nil {
@@ -161,6 +238,10 @@ func TestRunner_RunAll_SingleTest(t *testing.T) {
 		t.Error("Result decode time not recorded")
 	}
 
+	if !result.MaskDetected || result.Mask < 0 || result.Mask > 7 {
+		t.Errorf("Result mask = %d (detected %v), want 0..7", result.Mask, result.MaskDetected)
+	}
+
 	// This simple test should succeed
 	if result.Error != nil {
 		t.Errorf("Result should succeed, got error: %v", result.Error)
@@ -197,7 +278,10 @@ func TestRunner_RunAll_MultipleTests(t *testing.T) {
 		},
 	}
 
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
 	results, err := runner.RunAll()
 	if err != nil {
@@ -257,7 +341,10 @@ func TestRunner_RunAll_WithPixelSizeMatrix(t *testing.T) {
 		}
 	}
 
-	runner := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
 
 	results, err := runner.RunAll()
 	if err != nil {
@@ -290,6 +377,326 @@ func TestRunner_RunAll_WithPixelSizeMatrix(t *testing.T) {
 	}
 }
 
+func TestRunner_RunAll_MicroQR(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.MicroQR = true
+	enc := &microStubEncoder{}
+	dec := &decoders.GozxingDecoder{}
+
+	data := []byte("12345")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "numeric-5b-17px-ecL",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            17,
+			ContentType:          testdata.ContentNumeric,
+			ErrorCorrectionLevel: "L",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	result := results.Results[0]
+	if !enc.gotMicroQR {
+		t.Error("Encoder did not receive MicroQR option from config")
+	}
+
+	if !result.IsMicroQR {
+		t.Error("Result should be marked as Micro QR")
+	}
+
+	// Version detection falls back to the Micro formula: 17 modules = M4
+	if result.QRVersion != 4 {
+		t.Errorf("Result QR version = %d, want 4", result.QRVersion)
+	}
+
+	switch result.ModuleCount {
+	case 11, 13, 15, 17:
+	default:
+		t.Errorf("Result module count = %d, want one of 11/13/15/17", result.ModuleCount)
+	}
+
+	// Micro QR uses a 2-module quiet zone: 17 / (17 + 2)
+	expected := 17.0 / 19.0
+	if result.ModulePixelSize != expected {
+		t.Errorf("Result module pixel size = %f, want %f", result.ModulePixelSize, expected)
+	}
+}
+
+func TestRunner_RunAll_DecodedLength(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+
+	data := []byte("Hello, QR Code!")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-15b-320px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            320,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{&decoders.GozxingDecoder{}}, cases)
+		if err != nil {
+			t.Fatalf("NewRunner() failed: %v", err)
+		}
+		results, err := runner.RunAll()
+		if err != nil {
+			t.Fatalf("RunAll() failed: %v", err)
+		}
+
+		result := results.Results[0]
+		if result.Error != nil {
+			t.Fatalf("Result should succeed, got error: %v", result.Error)
+		}
+		if !result.Decoded || result.DecodedLength != len(data) {
+			t.Errorf("Result decoded length = %d, want %d", result.DecodedLength, len(data))
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		dec := &paddingStubDecoder{padding: 11}
+		runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+		if err != nil {
+			t.Fatalf("NewRunner() failed: %v", err)
+		}
+		results, err := runner.RunAll()
+		if err != nil {
+			t.Fatalf("RunAll() failed: %v", err)
+		}
+
+		result := results.Results[0]
+		var dataErr DataMismatchError
+		if !errors.As(result.Error, &dataErr) {
+			t.Fatalf("Result error = %v, want DataMismatchError", result.Error)
+		}
+		if result.DecodedLength != len(data)+11 {
+			t.Errorf("Result decoded length = %d, want %d", result.DecodedLength, len(data)+11)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{&emptyStubDecoder{}}, cases)
+		if err != nil {
+			t.Fatalf("NewRunner() failed: %v", err)
+		}
+		results, err := runner.RunAll()
+		if err != nil {
+			t.Fatalf("RunAll() failed: %v", err)
+		}
+
+		// Zero bytes is a completed decode, not a missing one
+		result := results.Results[0]
+		if !result.Decoded || result.DecodedLength != 0 {
+			t.Errorf("Result decoded = %v with %d bytes, want a completed decode of 0 bytes", result.Decoded, result.DecodedLength)
+		}
+	})
+}
+
+// emptyStubDecoder returns no bytes without an error.
+type emptyStubDecoder struct{}
+
+func (d *emptyStubDecoder) Name() string { return "stub/empty" }
+
+func (d *emptyStubDecoder) Decode(img image.Image) ([]byte, error) { return []byte{}, nil }
+
+func TestRunner_RecordProgress_Parallel(t *testing.T) {
+	runner, err := NewRunner(config.DefaultConfig(), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	enc := &encoders.Skip2Encoder{}
+	dec := &decoders.GozxingDecoder{}
+	testCase := testdata.TestCase{DataSize: 100, PixelSize: 320, ContentType: testdata.ContentNumeric, ErrorCorrectionLevel: "M"}
+
+	const total = 200
+
+	output := captureStdout(t, func() {
+		var wg sync.WaitGroup
+		for i := 0; i < total; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				runner.recordProgress(total, testCase, enc, dec, TestResult{})
+			}()
+		}
+		wg.Wait()
+	})
+
+	seen := make(map[int]bool)
+	counter := regexp.MustCompile(`^\[(\d+)/(\d+)\] `)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		m := counter.FindStringSubmatch(line)
+		if m == nil {
+			t.Fatalf("malformed progress line: %q", line)
+		}
+		n, _ := strconv.Atoi(m[1])
+		if m[2] != strconv.Itoa(total) {
+			t.Errorf("line %q total = %s, want %d", line, m[2], total)
+		}
+		if n < 1 || n > total {
+			t.Errorf("counter %d outside [1,%d]", n, total)
+		}
+		if seen[n] {
+			t.Errorf("counter %d printed more than once", n)
+		}
+		seen[n] = true
+	}
+
+	if len(seen) != total {
+		t.Errorf("got %d unique counters, want %d", len(seen), total)
+	}
+}
+
+func TestRunner_PrintProgress_Unsupported(t *testing.T) {
+	runner, err := NewRunner(config.DefaultConfig(), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	testCase := testdata.TestCase{DataSize: 50, PixelSize: 400, ContentType: testdata.ContentBinary, ErrorCorrectionLevel: "M"}
+	result := TestResult{Error: UnsupportedContentError{ContentType: "binary"}, IsUnsupported: true}
+
+	output := captureStdout(t, func() {
+		runner.printProgress(1, 1, testCase, &encoders.Skip2Encoder{}, &decoders.TuotooDecoder{}, result)
+	})
+	if !strings.Contains(output, "⊘ (unsupported)") {
+		t.Errorf("progress line = %q, want it marked \"⊘ (unsupported)\"", output)
+	}
+}
+
+// captureStdout returns everything fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	fn()
+	w.Close()
+	return <-done
+}
+
+// paddingStubDecoder decodes with gozxing and appends padding bytes,
+// mimicking decoders that return more bytes than were encoded.
+type paddingStubDecoder struct {
+	padding int
+}
+
+func (d *paddingStubDecoder) Name() string { return "stub/padding" }
+
+func (d *paddingStubDecoder) Decode(img image.Image) ([]byte, error) {
+	data, err := (&decoders.GozxingDecoder{}).Decode(img)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, make([]byte, d.padding)...), nil
+}
+
+func TestRunner_RunAll_PrefersReportedVersion(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &versionStubEncoder{version: 7}
+	dec := &decoders.GozxingDecoder{}
+
+	data := []byte("12345")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "numeric-5b-320px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            320,
+			ContentType:          testdata.ContentNumeric,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	// A placeholder 320px image cannot be version-detected, so this must come from Encode
+	result := results.Results[0]
+	if result.QRVersion != 7 {
+		t.Errorf("Result QR version = %d, want 7", result.QRVersion)
+	}
+	if result.ModuleCount != 45 {
+		t.Errorf("Result module count = %d, want 45", result.ModuleCount)
+	}
+}
+
+// stubImage returns a size×size dark image with one light pixel: not a QR
+// symbol, but not blank, so the runner does not reject it as ErrBlankImage.
+func stubImage(size int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	img.Pix[0] = 0xFF
+	return img
+}
+
+// versionStubEncoder returns a placeholder image whose version is only known
+// from the version it reports.
+type versionStubEncoder struct {
+	version int
+}
+
+func (e *versionStubEncoder) Name() string { return "stub/version" }
+
+func (e *versionStubEncoder) Encode(data []byte, opts encoders.EncodeOptions) (encoders.EncodeResult, error) {
+	return encoders.EncodeResult{
+		Image:   stubImage(opts.PixelSize),
+		Version: e.version,
+	}, nil
+}
+
+func (e *versionStubEncoder) IsCapacityError(err error) bool { return false }
+
+// microStubEncoder returns a placeholder one-pixel-per-module Micro QR image
+// without a reported version, forcing image-based version detection.
+type microStubEncoder struct {
+	gotMicroQR bool
+}
+
+func (e *microStubEncoder) Name() string { return "stub/micro" }
+
+func (e *microStubEncoder) Encode(data []byte, opts encoders.EncodeOptions) (encoders.EncodeResult, error) {
+	e.gotMicroQR = opts.MicroQR
+	return encoders.EncodeResult{
+		Image:   stubImage(opts.PixelSize),
+		Version: -1,
+		MicroQR: opts.MicroQR,
+	}, nil
+}
+
+func (e *microStubEncoder) IsCapacityError(err error) bool { return false }
+
 // generateTestData creates deterministic test data for testing.
 func generateTestData(size int) []byte {
 	data := make([]byte, size)
@@ -333,3 +740,1098 @@ func formatInt(n int) string {
 	}
 	return string(result)
 }
+
+func TestRunner_RunAll_Undersized(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+	dec := &decoders.GozxingDecoder{}
+
+	// 800 bytes needs a version 20+ symbol: well under 1 pixel per module at 50px
+	data := generateTestData(800)
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("binary", len(data), 50),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            50,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "L",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	result := results.Results[0]
+	var sizeErr UndersizedError
+	if !errors.As(result.Error, &sizeErr) {
+		t.Fatalf("Result error = %v, want UndersizedError", result.Error)
+	}
+
+	if sizeErr.ModulePixelSize >= testdata.MinModulePixelSize {
+		t.Errorf("UndersizedError module pixel size = %.2f, want < %.0f", sizeErr.ModulePixelSize, testdata.MinModulePixelSize)
+	}
+
+	// Decode is skipped for undersized results
+	if result.DecodeTime != 0 {
+		t.Errorf("Result decode time = %v, want 0", result.DecodeTime)
+	}
+
+	// A configuration problem, not a decoder failure: counted with the
+	// skips, but not as a capacity rejection
+	if !result.IsSkipped() || result.IsCapacityExceeded {
+		t.Errorf("Result skipped = %v, capacity exceeded = %v; want a skip that is not a capacity rejection",
+			result.IsSkipped(), result.IsCapacityExceeded)
+	}
+}
+
+func TestRunner_RunAll_ModulePixelSizeMatchesRender(t *testing.T) {
+	cfg := config.DefaultConfig()
+	dec := &decoders.GozxingDecoder{}
+	data := []byte("HELLO WORLD 12345")
+
+	for _, enc := range encoders.GetAllEncoders() {
+		for _, pixelSize := range []int{200, 333, 440} {
+			cases := []testdata.TestCase{{
+				Name:        formatTestName("binary", len(data), pixelSize),
+				Data:        data,
+				DataSize:    len(data),
+				PixelSize:   pixelSize,
+				ContentType: testdata.ContentBinary,
+			}}
+			runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+			if err != nil {
+				t.Fatalf("NewRunner() failed: %v", err)
+			}
+			results, err := runner.RunAll()
+			if err != nil {
+				t.Fatalf("RunAll() failed: %v", err)
+			}
+			result := results.Results[0]
+
+			// Encoders are deterministic, so a second encode renders the
+			// same image the runner measured
+			encoded, err := enc.Encode(data, encoders.EncodeOptions{
+				ErrorCorrectionLevel:       encoders.ErrorCorrectionM,
+				PixelSize:                  pixelSize,
+				PixelSizeIncludesQuietZone: cfg.PixelSizeIncludesQuietZone,
+			})
+			if err != nil {
+				t.Fatalf("%s at %dpx: Encode() failed: %v", enc.Name(), pixelSize, err)
+			}
+			rendered := renderedModulePixelSize(encoded.Image, result.ModuleCount)
+
+			// The runner rounds each margin to whole modules, so the two
+			// together can be off by up to one module of the total width
+			tolerance := rendered / float64(result.ModuleCount+2*testdata.QuietZoneModules)
+			if math.Abs(result.ModulePixelSize-rendered) > tolerance {
+				t.Errorf("%s at %dpx: recorded module size %.3fpx, rendered %.3fpx",
+					enc.Name(), pixelSize, result.ModulePixelSize, rendered)
+			}
+		}
+	}
+}
+
+// sizeRecordingDecoder decodes with gozxing and records the width of the
+// last image it was given.
+type sizeRecordingDecoder struct {
+	mu    sync.Mutex
+	width int
+}
+
+func (d *sizeRecordingDecoder) Name() string { return "stub/size-recording" }
+
+func (d *sizeRecordingDecoder) Decode(img image.Image) ([]byte, error) {
+	d.mu.Lock()
+	d.width = img.Bounds().Dx()
+	d.mu.Unlock()
+	return (&decoders.GozxingDecoder{}).Decode(img)
+}
+
+func TestRunner_RunAll_PaddedEncoderNotResized(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := encoders.WithPadding(&encoders.Skip2Encoder{}, 4)
+	dec := &sizeRecordingDecoder{}
+
+	data := []byte("HELLO WORLD 12345")
+	cases := []testdata.TestCase{{
+		Name:        formatTestName("binary", len(data), 256),
+		Data:        data,
+		DataSize:    len(data),
+		PixelSize:   256,
+		ContentType: testdata.ContentBinary,
+	}}
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		t.Fatalf("Result should succeed, got error: %v", result.Error)
+	}
+
+	encoded, err := enc.Encode(data, encoders.EncodeOptions{
+		ErrorCorrectionLevel:       encoders.ErrorCorrectionM,
+		PixelSize:                  256,
+		PixelSizeIncludesQuietZone: cfg.PixelSizeIncludesQuietZone,
+	})
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+
+	// The decoder sees the padded image, border and all
+	padded := encoded.Image.Bounds().Dx()
+	if padded <= 256 || dec.width != padded {
+		t.Errorf("decoder got a %dpx image, want the %dpx padded image", dec.width, padded)
+	}
+
+	// ...and the module size is the one the inner encoder rendered
+	rendered := renderedModulePixelSize(encoded.Image, result.ModuleCount)
+	if math.Abs(result.ModulePixelSize-rendered) > rendered/float64(result.ModuleCount+2*testdata.QuietZoneModules) {
+		t.Errorf("recorded module size %.3fpx, rendered %.3fpx", result.ModulePixelSize, rendered)
+	}
+}
+
+// renderedModulePixelSize returns the width of the dark bounding box in img
+// divided by moduleCount: the module size the encoder actually drew.
+func renderedModulePixelSize(img image.Image, moduleCount int) float64 {
+	b := img.Bounds()
+	minX, maxX := b.Max.X, b.Min.X
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if gray := color.GrayModel.Convert(img.At(x, y)).(color.Gray); gray.Y < 128 {
+				minX, maxX = min(minX, x), max(maxX, x+1)
+			}
+		}
+	}
+	return float64(maxX-minX) / float64(moduleCount)
+}
+
+func TestRunner_RunAll_MultiSymbol(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+
+	symbols := [][]byte{[]byte("first symbol"), []byte("second symbol")}
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-multi-256px-ecM",
+			Data:                 symbols[0],
+			DataSize:             len(symbols[0]),
+			PixelSize:            256,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+			Symbols:              symbols,
+		},
+	}
+
+	decs := []decoders.Decoder{&decoders.GoqrDecoder{}, &decoders.GozxingDecoder{}}
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, decs, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	for _, result := range results.Results {
+		switch result.DecoderName {
+		case "liyue201/goqr":
+			// goqr returns every symbol, so both payloads must be recovered
+			if result.Error != nil {
+				t.Errorf("goqr multi-symbol result error = %v, want nil", result.Error)
+			}
+			if want := len(symbols[0]) + len(symbols[1]); result.DecodedLength != want {
+				t.Errorf("goqr decoded length = %d, want %d", result.DecodedLength, want)
+			}
+		case "makiuchi-d/gozxing":
+			if !errors.Is(result.Error, decoders.ErrMultiSymbolUnsupported) {
+				t.Errorf("gozxing multi-symbol result error = %v, want ErrMultiSymbolUnsupported", result.Error)
+			}
+		}
+	}
+}
+
+func TestSamePayloads(t *testing.T) {
+	a, b := []byte("a"), []byte("b")
+
+	tests := []struct {
+		name      string
+		want, got [][]byte
+		same      bool
+	}{
+		{"same order", [][]byte{a, b}, [][]byte{a, b}, true},
+		{"reversed order", [][]byte{a, b}, [][]byte{b, a}, true},
+		{"missing symbol", [][]byte{a, b}, [][]byte{a}, false},
+		{"duplicate symbol", [][]byte{a, b}, [][]byte{a, a}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := samePayloads(tt.want, tt.got); got != tt.same {
+				t.Errorf("samePayloads() = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
+
+func TestRunner_RunAll_MaxDuration(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.MaxDuration = 50 * time.Millisecond
+	enc := &encoders.Skip2Encoder{}
+	dec := &slowStubDecoder{delay: 30 * time.Millisecond}
+
+	var cases []testdata.TestCase
+	for _, size := range []int{10, 20, 30, 40, 50, 60} {
+		data := []byte(strings.Repeat("A", size))
+		cases = append(cases, testdata.TestCase{
+			Name:                 formatTestName("alphanumeric", size, 320),
+			Data:                 data,
+			DataSize:             size,
+			PixelSize:            320,
+			ContentType:          testdata.ContentAlphanumeric,
+			ErrorCorrectionLevel: "M",
+		})
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	ran := len(results.Results)
+	if ran == 0 || ran == len(cases) {
+		t.Fatalf("ran %d of %d tests, want a partial run", ran, len(cases))
+	}
+	if results.Skipped != len(cases)-ran {
+		t.Errorf("Skipped = %d, want %d", results.Skipped, len(cases)-ran)
+	}
+
+	// The matrix order is fixed, so the tests that ran are the leading ones
+	for i, r := range results.Results {
+		if r.DataSize != cases[i].DataSize {
+			t.Errorf("result %d has data size %d, want %d", i, r.DataSize, cases[i].DataSize)
+		}
+	}
+	if got := results.DataSizes; len(got) != ran {
+		t.Errorf("DataSizes = %v, want only the %d sizes that ran", got, ran)
+	}
+}
+
+func TestRunner_RunAll_DecodeTimeoutAndPanic(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Timeout = 20 * time.Millisecond
+	enc := &encoders.Skip2Encoder{}
+
+	data := []byte("guarded decode")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-14b-256px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            256,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	decs := []decoders.Decoder{&slowStubDecoder{delay: time.Second}, &panicStubDecoder{}}
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, decs, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	for _, result := range results.Results {
+		switch result.DecoderName {
+		case "stub/slow":
+			var timeoutErr TimeoutError
+			if !errors.As(result.Error, &timeoutErr) {
+				t.Errorf("Slow decoder result error = %v, want TimeoutError", result.Error)
+			}
+			if result.DecodeTime >= time.Second {
+				t.Errorf("Slow decoder decode time = %v, want stopped near %v", result.DecodeTime, cfg.Timeout)
+			}
+		case "stub/panic":
+			var panicErr PanicError
+			if !errors.As(result.Error, &panicErr) {
+				t.Errorf("Panicking decoder result error = %v, want PanicError", result.Error)
+			}
+		}
+	}
+}
+
+// slowStubDecoder blocks for delay before failing.
+type slowStubDecoder struct {
+	delay time.Duration
+}
+
+func (d *slowStubDecoder) Name() string { return "stub/slow" }
+
+func (d *slowStubDecoder) Decode(img image.Image) ([]byte, error) {
+	time.Sleep(d.delay)
+	return nil, errors.New("too slow")
+}
+
+// panicStubDecoder panics without recovering.
+type panicStubDecoder struct{}
+
+func (d *panicStubDecoder) Name() string { return "stub/panic" }
+
+func (d *panicStubDecoder) Decode(img image.Image) ([]byte, error) {
+	panic("decoder bug")
+}
+
+func TestRunner_RunAll_CrossValidateBlindSpot(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+
+	data := []byte("blind spot")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "binary-10b-256px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            256,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		decoders      []decoders.Decoder
+		wantBlindSpot bool
+	}{
+		{
+			name:          "all decoders fail",
+			decoders:      []decoders.Decoder{&slowStubDecoder{}, &panicStubDecoder{}},
+			wantBlindSpot: true,
+		},
+		{
+			name:          "one decoder succeeds",
+			decoders:      []decoders.Decoder{&slowStubDecoder{}, &decoders.GozxingDecoder{}},
+			wantBlindSpot: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref := &referenceStubDecoder{data: data}
+			runner, err := NewRunner(cfg, []encoders.Encoder{enc}, tt.decoders, cases)
+			if err != nil {
+				t.Fatalf("NewRunner() failed: %v", err)
+			}
+			runner.Reference = ref
+
+			results, err := runner.RunAll()
+			if err != nil {
+				t.Fatalf("RunAll() failed: %v", err)
+			}
+
+			if ref.calls > 0 != tt.wantBlindSpot {
+				t.Errorf("Reference decoder called %d times, want called = %v", ref.calls, tt.wantBlindSpot)
+			}
+
+			for _, result := range results.Results {
+				if result.Error != nil && result.IsBlindSpot != tt.wantBlindSpot {
+					t.Errorf("%s IsBlindSpot = %v, want %v", result.DecoderName, result.IsBlindSpot, tt.wantBlindSpot)
+				}
+				if result.Error == nil && result.IsBlindSpot {
+					t.Errorf("%s succeeded but is flagged as a blind spot", result.DecoderName)
+				}
+			}
+		})
+	}
+}
+
+// referenceStubDecoder stands in for an external reference decoder.
+type referenceStubDecoder struct {
+	data  []byte
+	calls int
+}
+
+func (d *referenceStubDecoder) Name() string { return "stub/reference" }
+
+func (d *referenceStubDecoder) Decode(img image.Image) ([]byte, error) {
+	d.calls++
+	return d.data, nil
+}
+
+// blankStubEncoder reports success but returns an all-white image.
+type blankStubEncoder struct{}
+
+func (e *blankStubEncoder) Name() string { return "stub/blank" }
+
+func (e *blankStubEncoder) Encode(data []byte, opts encoders.EncodeOptions) (encoders.EncodeResult, error) {
+	img := image.NewGray(image.Rect(0, 0, opts.PixelSize, opts.PixelSize))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	return encoders.EncodeResult{Image: img, Version: 7}, nil
+}
+
+func (e *blankStubEncoder) IsCapacityError(err error) bool { return false }
+
+func TestRunner_RunAll_BlankImage(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &blankStubEncoder{}
+	dec := &slowStubDecoder{delay: time.Hour}
+
+	data := []byte("12345")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "numeric-5b-320px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            320,
+			ContentType:          testdata.ContentNumeric,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	result := results.Results[0]
+	var encErr EncodeError
+	if !errors.As(result.Error, &encErr) || !errors.Is(result.Error, ErrBlankImage) {
+		t.Fatalf("Result error = %v, want EncodeError wrapping ErrBlankImage", result.Error)
+	}
+	if result.IsCapacityExceeded {
+		t.Error("Blank image should not count as a capacity skip")
+	}
+
+	// The decoder would block for an hour; it must not run
+	if result.DecodeTime != 0 {
+		t.Errorf("Result decode time = %v, want 0", result.DecodeTime)
+	}
+}
+
+// panicStubEncoder panics on the payload "PANIC" and otherwise encodes with skip2.
+type panicStubEncoder struct {
+	inner encoders.Skip2Encoder
+}
+
+func (e *panicStubEncoder) Name() string { return "stub/panic" }
+
+func (e *panicStubEncoder) Encode(data []byte, opts encoders.EncodeOptions) (encoders.EncodeResult, error) {
+	if string(data) == "PANIC" {
+		panic("index out of range in version table")
+	}
+	return e.inner.Encode(data, opts)
+}
+
+func (e *panicStubEncoder) IsCapacityError(err error) bool { return e.inner.IsCapacityError(err) }
+
+func TestRunner_RunAll_EncoderPanic(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &panicStubEncoder{}
+	dec := &decoders.GozxingDecoder{}
+
+	var cases []testdata.TestCase
+	for _, data := range []string{"PANIC", "HELLO"} {
+		cases = append(cases, testdata.TestCase{
+			Name:                 formatTestName("alphanumeric", len(data), 320) + "-" + data,
+			Data:                 []byte(data),
+			DataSize:             len(data),
+			PixelSize:            320,
+			ContentType:          testdata.ContentAlphanumeric,
+			ErrorCorrectionLevel: "M",
+		})
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+	if len(results.Results) != 2 {
+		t.Fatalf("RunAll() returned %d results, want 2", len(results.Results))
+	}
+
+	panicked := results.Results[0]
+	var encErr EncodeError
+	if !errors.As(panicked.Error, &encErr) || !errors.Is(panicked.Error, ErrEncoderPanic) {
+		t.Fatalf("Result error = %v, want EncodeError wrapping ErrEncoderPanic", panicked.Error)
+	}
+	if !strings.Contains(panicked.Error.Error(), "index out of range in version table") {
+		t.Errorf("Result error = %q, want the recovered value", panicked.Error)
+	}
+	if panicked.IsCapacityExceeded {
+		t.Error("Encoder panic should not count as a capacity skip")
+	}
+
+	// The run continues past the panic
+	if results.Results[1].Error != nil {
+		t.Errorf("Test after the panic failed: %v", results.Results[1].Error)
+	}
+}
+
+// dotStubEncoder returns a version 1 image holding a single dark pixel, so
+// the rendered quiet zone cannot be measured.
+type dotStubEncoder struct{}
+
+func (e *dotStubEncoder) Name() string { return "stub/dot" }
+
+func (e *dotStubEncoder) Encode(data []byte, opts encoders.EncodeOptions) (encoders.EncodeResult, error) {
+	img := image.NewGray(image.Rect(0, 0, opts.PixelSize, opts.PixelSize))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	img.Pix[0] = 0
+	return encoders.EncodeResult{Image: img, Version: 1}, nil
+}
+
+func (e *dotStubEncoder) IsCapacityError(err error) bool { return false }
+
+func TestRunner_RunAll_QuietZoneModules(t *testing.T) {
+	data := []byte("12345")
+	cases := []testdata.TestCase{
+		{
+			Name:                 "numeric-5b-250px-ecM",
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            250,
+			ContentType:          testdata.ContentNumeric,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	tests := []struct {
+		quietZone      int
+		wantPixelSize  float64
+		wantFractional bool
+	}{
+		{4, 250.0 / 25.0, false}, // 21 + 4 modules: exactly 10px
+		{2, 250.0 / 23.0, true},  // 21 + 2 modules: 10.87px
+	}
+
+	for _, tt := range tests {
+		cfg := config.DefaultConfig()
+		cfg.QuietZoneModules = tt.quietZone
+
+		runner, err := NewRunner(cfg, []encoders.Encoder{&dotStubEncoder{}}, []decoders.Decoder{&decoders.GozxingDecoder{}}, cases)
+		if err != nil {
+			t.Fatalf("NewRunner() failed: %v", err)
+		}
+		results, err := runner.RunAll()
+		if err != nil {
+			t.Fatalf("RunAll() failed: %v", err)
+		}
+
+		result := results.Results[0]
+		if result.ModulePixelSize != tt.wantPixelSize {
+			t.Errorf("Quiet zone %d: module pixel size = %f, want %f", tt.quietZone, result.ModulePixelSize, tt.wantPixelSize)
+		}
+		if result.IsFractionalModule != tt.wantFractional {
+			t.Errorf("Quiet zone %d: fractional = %v, want %v", tt.quietZone, result.IsFractionalModule, tt.wantFractional)
+		}
+	}
+}
+
+func TestRunner_RunAll_EmptyData(t *testing.T) {
+	cfg := config.DefaultConfig()
+	encs := encoders.GetAvailableEncoders(cfg)
+	dec := &slowStubDecoder{delay: time.Hour}
+
+	var cases []testdata.TestCase
+	for _, tc := range testdata.GenerateEdgeCases() {
+		if tc.DataSize == 0 {
+			cases = append(cases, tc)
+		}
+	}
+	if len(cases) != 1 {
+		t.Fatalf("GenerateEdgeCases() has %d empty cases, want 1", len(cases))
+	}
+
+	runner, err := NewRunner(cfg, encs, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	if len(results.Results) != len(encs) {
+		t.Fatalf("RunAll() returned %d results, want %d", len(results.Results), len(encs))
+	}
+	for _, result := range results.Results {
+		var emptyErr EmptyDataError
+		if !errors.As(result.Error, &emptyErr) {
+			t.Errorf("%s: error = %v, want EmptyDataError", result.EncoderName, result.Error)
+			continue
+		}
+		var encErr EncodeError
+		if errors.As(result.Error, &encErr) {
+			t.Errorf("%s: empty data counted as an EncodeError", result.EncoderName)
+		}
+		if !result.IsCapacityExceeded {
+			t.Errorf("%s: empty data rejection should be skipped like a capacity error", result.EncoderName)
+		}
+	}
+}
+
+func TestRunner_RunAll_BinaryUnsupportedByDecoder(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+	textOnly := &decoders.TuotooDecoder{}
+	binary := &decoders.GozxingDecoder{}
+
+	data := generateTestData(50)
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("binary", len(data), 400),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            400,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{textOnly, binary}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+	if len(results.Results) != 2 {
+		t.Fatalf("RunAll() returned %d results, want 2", len(results.Results))
+	}
+
+	for _, result := range results.Results {
+		var unsupportedErr UnsupportedContentError
+		skipped := errors.As(result.Error, &unsupportedErr)
+
+		switch result.DecoderName {
+		case textOnly.Name():
+			if !skipped {
+				t.Errorf("%s: error = %v, want UnsupportedContentError", result.DecoderName, result.Error)
+			}
+			if !result.IsUnsupported || result.IsCapacityExceeded || !result.IsSkipped() {
+				t.Errorf("%s: unsupported binary should be skipped apart from capacity errors", result.DecoderName)
+			}
+			if want := "skipped: binary unsupported by decoder"; result.Error != nil && result.Error.Error() != want {
+				t.Errorf("%s: error = %q, want %q", result.DecoderName, result.Error, want)
+			}
+		case binary.Name():
+			if skipped || result.IsSkipped() {
+				t.Errorf("%s: binary case skipped (error = %v), want it run", result.DecoderName, result.Error)
+			}
+			if result.QRVersion <= 0 {
+				t.Errorf("%s: QRVersion = %d, want the case encoded", result.DecoderName, result.QRVersion)
+			}
+		}
+	}
+}
+
+func TestRunner_RunAll_StructuredAppend(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.StructuredAppendEncoder{}
+	sequence := &decoders.GozxingDecoder{}
+	single := &decoders.ReadqrDecoder{}
+
+	// 3000 bytes exceed the 2331-byte capacity of version 40-M
+	data := testdata.BinaryData(3000, testdata.DefaultBinarySeed)
+	cases := []testdata.TestCase{{
+		Name:                 formatTestName("binary", len(data), 740),
+		Data:                 data,
+		DataSize:             len(data),
+		PixelSize:            740,
+		ContentType:          testdata.ContentBinary,
+		ErrorCorrectionLevel: "M",
+	}}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{sequence, single}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	for _, result := range results.Results {
+		switch result.DecoderName {
+		case sequence.Name():
+			if result.Error != nil {
+				t.Errorf("%s: error = %v, want the sequence reassembled", result.DecoderName, result.Error)
+			}
+			if result.DecodedLength != len(data) {
+				t.Errorf("%s: decoded %d bytes, want %d", result.DecoderName, result.DecodedLength, len(data))
+			}
+		case single.Name():
+			var unsupportedErr UnsupportedContentError
+			if !errors.As(result.Error, &unsupportedErr) || !result.IsUnsupported || result.IsCapacityExceeded {
+				t.Errorf("%s: error = %v, want an unsupported skip", result.DecoderName, result.Error)
+			}
+		}
+	}
+}
+
+// misreportingStubEncoder renders skip2's version 1 symbol but reports
+// version 10. With blank set it renders no symbol at all.
+type misreportingStubEncoder struct {
+	blank bool
+}
+
+func (e *misreportingStubEncoder) Name() string { return "stub/misreporting" }
+
+func (e *misreportingStubEncoder) Encode(data []byte, opts encoders.EncodeOptions) (encoders.EncodeResult, error) {
+	if e.blank {
+		return encoders.EncodeResult{Image: stubImage(opts.PixelSize), Version: 10}, nil
+	}
+	result, err := (&encoders.Skip2Encoder{}).Encode(data, opts)
+	if err != nil {
+		return encoders.EncodeResult{}, err
+	}
+	result.Version = 10
+	return result, nil
+}
+
+func (e *misreportingStubEncoder) IsCapacityError(err error) bool { return false }
+
+func TestRunner_RunAll_VersionMismatch(t *testing.T) {
+	cfg := config.DefaultConfig()
+	dec := &decoders.GozxingDecoder{}
+
+	data := []byte("12345")
+	cases := []testdata.TestCase{{
+		Name:                 formatTestName("numeric", len(data), 320),
+		Data:                 data,
+		DataSize:             len(data),
+		PixelSize:            320,
+		ContentType:          testdata.ContentNumeric,
+		ErrorCorrectionLevel: "M",
+	}}
+
+	run := func(enc encoders.Encoder) TestResult {
+		t.Helper()
+		runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+		if err != nil {
+			t.Fatalf("NewRunner() failed: %v", err)
+		}
+		results, err := runner.RunAll()
+		if err != nil {
+			t.Fatalf("RunAll() failed: %v", err)
+		}
+		return results.Results[0]
+	}
+
+	// The located symbol is version 1 at 320px, several pixels per module
+	if got := run(&misreportingStubEncoder{}); !got.VersionMismatch || got.QRVersion != 10 {
+		t.Errorf("located symbol: mismatch = %v, version = %d, want mismatch flagged with reported version 10", got.VersionMismatch, got.QRVersion)
+	}
+
+	// No symbol can be located, so there is nothing to disagree with
+	if got := run(&misreportingStubEncoder{blank: true}); got.VersionMismatch {
+		t.Error("no symbol: mismatch flagged without a located version")
+	}
+
+	// A correctly reported version is not a mismatch
+	if got := run(&encoders.Skip2Encoder{}); got.VersionMismatch {
+		t.Errorf("skip2: mismatch flagged for version %d", got.QRVersion)
+	}
+}
+
+func TestRunner_RunAll_CapacityUtilization(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+	dec := &decoders.GozxingDecoder{}
+
+	// Version 1-M holds exactly 14 bytes
+	full, err := testdata.ByteCapacity(1, "M")
+	if err != nil {
+		t.Fatalf("ByteCapacity() failed: %v", err)
+	}
+	cases := []testdata.TestCase{
+		{Name: "full", Data: make([]byte, full), DataSize: full, PixelSize: 200, ContentType: testdata.ContentBinary, ErrorCorrectionLevel: "M"},
+		{Name: "small", Data: make([]byte, 1), DataSize: 1, PixelSize: 200, ContentType: testdata.ContentBinary, ErrorCorrectionLevel: "M"},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	byName := make(map[string]TestResult)
+	for _, r := range results.Results {
+		if r.CapacityUtilization < 0 || r.CapacityUtilization > 1 {
+			t.Errorf("%s: CapacityUtilization = %v, want in [0, 1]", r.TestCase, r.CapacityUtilization)
+		}
+		byName[r.TestCase] = r
+	}
+
+	if got := byName["full"]; got.QRVersion != 1 || got.CapacityUtilization < 0.95 {
+		t.Errorf("full: version %d, CapacityUtilization = %v, want version 1 near 1", got.QRVersion, got.CapacityUtilization)
+	}
+	if got := byName["small"].CapacityUtilization; got <= 0 || got > 0.25 {
+		t.Errorf("small: CapacityUtilization = %v, want a small nonzero fraction", got)
+	}
+}
+
+func TestRunner_RunAll_EncodePhaseTimings(t *testing.T) {
+	cfg := config.DefaultConfig()
+	dec := &decoders.GozxingDecoder{}
+
+	data := []byte(strings.Repeat("PHASE TIMING ", 40))
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("alphanumeric", len(data), 1024),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            1024,
+			ContentType:          testdata.ContentAlphanumeric,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	encs := []encoders.Encoder{&encoders.Skip2Encoder{}, &encoders.BoombulerEncoder{}}
+	runner, err := NewRunner(cfg, encs, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	for _, r := range results.Results {
+		phases := r.QRConstructTime + r.ImageEncodeTime + r.ImageDecodeTime
+
+		if r.EncoderName != "skip2/go-qrcode" {
+			if phases != 0 {
+				t.Errorf("%s: phase timings = %v, want 0 for an uninstrumented encoder", r.EncoderName, phases)
+			}
+			continue
+		}
+
+		if r.QRConstructTime <= 0 || r.ImageEncodeTime <= 0 || r.ImageDecodeTime <= 0 {
+			t.Errorf("skip2 phases = %v/%v/%v, want all positive", r.QRConstructTime, r.ImageEncodeTime, r.ImageDecodeTime)
+		}
+		// The phases cover the whole encode except the runner's bookkeeping
+		if phases > r.EncodeTime || phases < r.EncodeTime/2 {
+			t.Errorf("skip2 phases sum to %v, want approximately EncodeTime %v", phases, r.EncodeTime)
+		}
+	}
+}
+
+// nulPaddingStubDecoder decodes with gozxing and appends NUL padding.
+type nulPaddingStubDecoder struct{}
+
+func (d *nulPaddingStubDecoder) Name() string { return "stub/nul-padding" }
+
+func (d *nulPaddingStubDecoder) Decode(img image.Image) ([]byte, error) {
+	data, err := (&decoders.GozxingDecoder{}).Decode(img)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, 0, 0, 0), nil
+}
+
+func TestRunner_RunAll_PaddingTrim(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+
+	data := []byte("HELLO WORLD")
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("alphanumeric", len(data), 400),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            400,
+			ContentType:          testdata.ContentAlphanumeric,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	padded := &nulPaddingStubDecoder{}
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{padded}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+	var mismatch DataMismatchError
+	if got := results.Results[0]; !errors.As(got.Error, &mismatch) || got.DecodedLength != len(data)+3 {
+		t.Errorf("Unwrapped result = %v with %d bytes, want a data mismatch with %d bytes", got.Error, got.DecodedLength, len(data)+3)
+	}
+
+	trimmed := decoders.WithPaddingTrim(padded)
+	runner, err = NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{trimmed}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err = runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+	if got := results.Results[0]; got.Error != nil || got.DecoderName != padded.Name() {
+		t.Errorf("Wrapped result = %v from %q, want success from %q", got.Error, got.DecoderName, padded.Name())
+	}
+}
+
+func TestRunner_RunAll_CenterOcclusion(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.CenterOcclusion = 0.10
+	cfg.RetainImages = true
+	enc := &encoders.Skip2Encoder{}
+	dec := &decoders.GozxingDecoder{}
+
+	data := []byte("https://example.com/branded")
+	tc := testdata.TestCase{
+		Name:                 formatTestName("utf8", len(data), 400),
+		Data:                 data,
+		DataSize:             len(data),
+		PixelSize:            400,
+		ContentType:          testdata.ContentUTF8,
+		ErrorCorrectionLevel: "H",
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, []testdata.TestCase{tc})
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	got := results.Results[0]
+	if got.Error != nil {
+		t.Errorf("10%% occluded High EC result = %v, want success", got.Error)
+	}
+	if got.CenterOcclusion != 0.10 {
+		t.Errorf("CenterOcclusion = %.2f, want 0.10", got.CenterOcclusion)
+	}
+
+	// The decoder saw the blanked center
+	img, ok := results.Images.Get(ImageKey{Encoder: enc.Name(), TestCase: tc.Name})
+	if !ok {
+		t.Fatal("Occluded image was not retained")
+	}
+	for _, p := range []image.Point{{200, 200}, {180, 220}} {
+		if r, _, _, _ := img.At(p.X, p.Y).RGBA(); r != 0xFFFF {
+			t.Errorf("Pixel %v is not blanked", p)
+		}
+	}
+}
+
+func TestRunner_RunAll_ColorModelGray(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ColorModel = "gray"
+	cfg.RetainImages = true
+	encs := encoders.GetAvailableEncoders(cfg)
+	dec := &decoders.GozxingDecoder{}
+
+	data := []byte("https://example.com/gray")
+	tc := testdata.TestCase{
+		Name:                 formatTestName("utf8", len(data), 400),
+		Data:                 data,
+		DataSize:             len(data),
+		PixelSize:            400,
+		ContentType:          testdata.ContentUTF8,
+		ErrorCorrectionLevel: "M",
+	}
+
+	runner, err := NewRunner(cfg, encs, []decoders.Decoder{dec}, []testdata.TestCase{tc})
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	for _, result := range results.Results {
+		if result.Error != nil {
+			t.Errorf("%s: result = %v, want success after gray conversion", result.EncoderName, result.Error)
+		}
+		img, ok := results.Images.Get(ImageKey{Encoder: result.EncoderName, TestCase: tc.Name})
+		if !ok {
+			t.Errorf("%s: image was not retained", result.EncoderName)
+			continue
+		}
+		if _, ok := img.(*image.Gray); !ok {
+			t.Errorf("%s: decoder saw %T, want *image.Gray", result.EncoderName, img)
+		}
+	}
+}
+
+func TestRunner_RunAll_EmbedLocateCrop(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.EmbedScale = 2
+	cfg.LocateCrop = true
+	cfg.RetainImages = true
+	enc := &encoders.Skip2Encoder{}
+	dec := &decoders.GozxingDecoder{}
+
+	data := []byte("https://example.com/embedded")
+	tc := testdata.TestCase{
+		Name:                 formatTestName("utf8", len(data), 320),
+		Data:                 data,
+		DataSize:             len(data),
+		PixelSize:            320,
+		ContentType:          testdata.ContentUTF8,
+		ErrorCorrectionLevel: "M",
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, []testdata.TestCase{tc})
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	result := results.Results[0]
+	if result.Error != nil {
+		t.Fatalf("result = %v, want success after locate-and-crop", result.Error)
+	}
+
+	// The decoder saw the cropped symbol, not the 640px canvas
+	img, ok := results.Images.Get(ImageKey{Encoder: enc.Name(), TestCase: tc.Name})
+	if !ok {
+		t.Fatal("image was not retained")
+	}
+	if size := img.Bounds().Dx(); size >= 2*tc.PixelSize || size < tc.PixelSize*3/4 {
+		t.Errorf("decoded image is %dpx wide, want about the %dpx symbol", size, tc.PixelSize)
+	}
+}
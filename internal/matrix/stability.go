@@ -0,0 +1,135 @@
+package matrix
+
+import "sort"
+
+// SeedStability summarizes one encoder/decoder pair across the seeds of a
+// seed sweep. A pair is unstable when a test case passes with some random
+// payloads and fails with others, so its success depends on the data rather
+// than the data size or pixel size.
+type SeedStability struct {
+	EncoderName string
+	DecoderName string
+
+	// Seeds is the number of distinct seeds tested.
+	Seeds int
+
+	// Tests and Successes count swept results, excluding skips.
+	Tests     int
+	Successes int
+
+	// SuccessRate is Successes / Tests (0.0-1.0).
+	SuccessRate float64
+
+	// RateVariance is the population variance of the per-seed success rates.
+	// 0 when every seed produced the same success rate.
+	RateVariance float64
+
+	// UnstableCases counts test cases (data size, pixel size, and EC level)
+	// that passed for some seeds and failed for others.
+	UnstableCases int
+
+	// Unstable is true when UnstableCases > 0.
+	Unstable bool
+}
+
+// AnalyzeSeedStability groups seed sweep results (Seed != 0) by
+// encoder/decoder pair, sorted by encoder then decoder name.
+// Results outside a sweep and skips are ignored.
+func AnalyzeSeedStability(results []TestResult) []SeedStability {
+	type pairKey struct{ encoder, decoder string }
+	type caseKey struct {
+		dataSize, pixelSize int
+		ecLevel             string
+	}
+	type tally struct{ tests, successes int }
+	type pairAgg struct {
+		bySeed map[int64]*tally
+		byCase map[caseKey]*tally
+	}
+
+	agg := make(map[pairKey]*pairAgg)
+	var pairs []pairKey
+
+	for _, r := range results {
+		if r.Seed == 0 || r.IsSkipped() {
+			continue
+		}
+
+		pk := pairKey{r.EncoderName, r.DecoderName}
+		a := agg[pk]
+		if a == nil {
+			a = &pairAgg{bySeed: make(map[int64]*tally), byCase: make(map[caseKey]*tally)}
+			agg[pk] = a
+			pairs = append(pairs, pk)
+		}
+
+		ck := caseKey{r.DataSize, r.PixelSize, r.ErrorCorrectionLevel}
+		if a.bySeed[r.Seed] == nil {
+			a.bySeed[r.Seed] = &tally{}
+		}
+		if a.byCase[ck] == nil {
+			a.byCase[ck] = &tally{}
+		}
+		for _, t := range []*tally{a.bySeed[r.Seed], a.byCase[ck]} {
+			t.tests++
+			if r.Error == nil {
+				t.successes++
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].encoder != pairs[j].encoder {
+			return pairs[i].encoder < pairs[j].encoder
+		}
+		return pairs[i].decoder < pairs[j].decoder
+	})
+
+	stability := make([]SeedStability, 0, len(pairs))
+	for _, pk := range pairs {
+		a := agg[pk]
+		s := SeedStability{
+			EncoderName: pk.encoder,
+			DecoderName: pk.decoder,
+			Seeds:       len(a.bySeed),
+		}
+
+		rates := make([]float64, 0, len(a.bySeed))
+		for _, t := range a.bySeed {
+			s.Tests += t.tests
+			s.Successes += t.successes
+			rates = append(rates, float64(t.successes)/float64(t.tests))
+		}
+		s.SuccessRate = float64(s.Successes) / float64(s.Tests)
+		s.RateVariance = variance(rates)
+
+		for _, t := range a.byCase {
+			if t.successes > 0 && t.successes < t.tests {
+				s.UnstableCases++
+			}
+		}
+		s.Unstable = s.UnstableCases > 0
+
+		stability = append(stability, s)
+	}
+	return stability
+}
+
+// variance returns the population variance of values.
+func variance(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return sum / float64(len(values))
+}
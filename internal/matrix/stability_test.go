@@ -0,0 +1,131 @@
+package matrix
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/config"
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+// oddSeedStubDecoder reads raw bytes with the segment decoder but corrupts
+// payloads generated from an odd seed, so its success depends on the data.
+type oddSeedStubDecoder struct {
+	seeds int
+}
+
+func (d *oddSeedStubDecoder) Name() string { return "stub/odd-seed" }
+
+func (d *oddSeedStubDecoder) Decode(img image.Image) ([]byte, error) {
+	data, err := (&decoders.SegmentDecoder{}).Decode(img)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < d.seeds; i++ {
+		seed := int64(testdata.DefaultBinarySeed + i)
+		if seed%2 == 1 && bytes.Equal(data, testdata.BinaryData(len(data), seed)) {
+			data[0] ^= 0xFF
+		}
+	}
+	return data, nil
+}
+
+func TestRunner_RunAll_SeedSweep(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SeedSweep = 4
+	enc := &encoders.Skip2Encoder{}
+	// Segment decoder returns byte segments without charset conversion
+	stable := &decoders.SegmentDecoder{}
+	unstable := &oddSeedStubDecoder{seeds: cfg.SeedSweep}
+
+	data := generateTestData(50)
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("binary", len(data), 400),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            400,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{stable, unstable}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	if got := runner.TotalTests(); got != 8 {
+		t.Errorf("TotalTests() = %d, want 8 (4 seeds × 2 decoders)", got)
+	}
+
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	if len(results.Results) != 8 {
+		t.Fatalf("RunAll() returned %d results, want 8", len(results.Results))
+	}
+
+	if len(results.SeedStability) != 2 {
+		t.Fatalf("SeedStability has %d pairs, want 2", len(results.SeedStability))
+	}
+
+	byDecoder := make(map[string]SeedStability)
+	for _, s := range results.SeedStability {
+		byDecoder[s.DecoderName] = s
+	}
+
+	got := byDecoder["stub/odd-seed"]
+	if !got.Unstable || got.UnstableCases != 1 {
+		t.Errorf("odd-seed stability = %+v, want unstable with 1 unstable case", got)
+	}
+	if got.Seeds != 4 || got.SuccessRate != 0.5 {
+		t.Errorf("odd-seed seeds = %d, success rate = %.2f, want 4 seeds at 0.50", got.Seeds, got.SuccessRate)
+	}
+	if got.RateVariance != 0.25 {
+		t.Errorf("odd-seed rate variance = %.2f, want 0.25", got.RateVariance)
+	}
+
+	if got := byDecoder[stable.Name()]; got.Unstable || got.SuccessRate != 1 {
+		t.Errorf("segments stability = %+v, want stable at 1.00", got)
+	}
+}
+
+func TestRunner_RunAll_NoSeedSweep(t *testing.T) {
+	cfg := config.DefaultConfig()
+	enc := &encoders.Skip2Encoder{}
+	dec := &decoders.GozxingDecoder{}
+
+	data := generateTestData(50)
+	cases := []testdata.TestCase{
+		{
+			Name:                 formatTestName("binary", len(data), 400),
+			Data:                 data,
+			DataSize:             len(data),
+			PixelSize:            400,
+			ContentType:          testdata.ContentBinary,
+			ErrorCorrectionLevel: "M",
+		},
+	}
+
+	runner, err := NewRunner(cfg, []encoders.Encoder{enc}, []decoders.Decoder{dec}, cases)
+	if err != nil {
+		t.Fatalf("NewRunner() failed: %v", err)
+	}
+	results, err := runner.RunAll()
+	if err != nil {
+		t.Fatalf("RunAll() failed: %v", err)
+	}
+
+	if len(results.Results) != 1 || results.Results[0].Seed != 0 {
+		t.Errorf("Without a sweep, want 1 unseeded result, got %d", len(results.Results))
+	}
+	if results.SeedStability != nil {
+		t.Errorf("SeedStability = %+v, want nil without a sweep", results.SeedStability)
+	}
+}
@@ -0,0 +1,67 @@
+package matrix
+
+import (
+	"image"
+	"time"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+)
+
+// Throughput is how many codes one library processed in a time budget,
+// for capacity planning where codes/sec matters more than per-op time.
+type Throughput struct {
+	Name string
+
+	// Ops counts successful encodes or decodes; Errors counts failed ones.
+	Ops    int
+	Errors int
+
+	// Elapsed is the time actually spent, at least the budget.
+	Elapsed time.Duration
+
+	// CodesPerSec is Ops / Elapsed.
+	CodesPerSec float64
+}
+
+// MeasureDecodeThroughput decodes img with dec repeatedly until budget has
+// elapsed and reports successful decodes per second. It always decodes at
+// least once, so a slow decoder still reports a rate.
+func MeasureDecodeThroughput(dec decoders.Decoder, img image.Image, budget time.Duration) Throughput {
+	return measureThroughput(dec.Name(), budget, func() error {
+		_, err := dec.Decode(img)
+		return err
+	})
+}
+
+// MeasureEncodeThroughput encodes data with enc repeatedly until budget has
+// elapsed and reports successful encodes per second.
+func MeasureEncodeThroughput(enc encoders.Encoder, data []byte, opts encoders.EncodeOptions, budget time.Duration) Throughput {
+	return measureThroughput(enc.Name(), budget, func() error {
+		_, err := enc.Encode(data, opts)
+		return err
+	})
+}
+
+// measureThroughput runs op until budget has elapsed.
+func measureThroughput(name string, budget time.Duration, op func() error) Throughput {
+	t := Throughput{Name: name}
+
+	start := time.Now()
+	for {
+		if err := op(); err != nil {
+			t.Errors++
+		} else {
+			t.Ops++
+		}
+		t.Elapsed = time.Since(start)
+		if t.Elapsed >= budget {
+			break
+		}
+	}
+
+	if t.Elapsed > 0 {
+		t.CodesPerSec = float64(t.Ops) / t.Elapsed.Seconds()
+	}
+	return t
+}
@@ -0,0 +1,37 @@
+package matrix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/13rac1/qr-library-test/internal/encoders"
+)
+
+func TestMeasureThroughput_Gozxing(t *testing.T) {
+	data := []byte("https://example.com/throughput")
+	opts := encoders.EncodeOptions{ErrorCorrectionLevel: "M", PixelSize: 256}
+	budget := 50 * time.Millisecond
+
+	enc := &encoders.GozxingEncoder{}
+	encoded := MeasureEncodeThroughput(enc, data, opts, budget)
+	if encoded.CodesPerSec <= 0 || encoded.Errors != 0 {
+		t.Errorf("Encode throughput = %+v, want positive codes/sec and no errors", encoded)
+	}
+
+	result, err := enc.Encode(data, opts)
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+
+	decoded := MeasureDecodeThroughput(&decoders.GozxingDecoder{}, result.Image, budget)
+	if decoded.Name != "makiuchi-d/gozxing" {
+		t.Errorf("Name = %q, want %q", decoded.Name, "makiuchi-d/gozxing")
+	}
+	if decoded.CodesPerSec <= 0 || decoded.Errors != 0 {
+		t.Errorf("Decode throughput = %+v, want positive codes/sec and no errors", decoded)
+	}
+	if decoded.Elapsed < budget {
+		t.Errorf("Elapsed = %v, want at least the %v budget", decoded.Elapsed, budget)
+	}
+}
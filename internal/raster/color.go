@@ -0,0 +1,26 @@
+package raster
+
+import (
+	"image"
+	"image/draw"
+)
+
+// ToGray returns img as an *image.Gray with its bounds moved to the origin.
+// Transparent pixels are flattened onto a white background.
+func ToGray(img image.Image) *image.Gray {
+	bounds := img.Bounds()
+	out := image.NewGray(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(out, out.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), img, bounds.Min, draw.Over)
+	return out
+}
+
+// ToRGBA returns img as an opaque *image.RGBA with its bounds moved to the
+// origin. Transparent pixels are flattened onto a white background.
+func ToRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(out, out.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), img, bounds.Min, draw.Over)
+	return out
+}
@@ -0,0 +1,47 @@
+package raster
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/skip2/go-qrcode"
+)
+
+func TestToGray_PreservesReadability(t *testing.T) {
+	data := "https://example.com/color-model"
+	pngBytes, err := qrcode.Encode(data, qrcode.Medium, 300)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+	if _, ok := img.(*image.Gray); ok {
+		t.Fatal("skip2 PNG decoded as *image.Gray; the test needs another model to convert from")
+	}
+
+	for _, converted := range []image.Image{ToGray(img), ToRGBA(img)} {
+		if converted.Bounds() != img.Bounds() {
+			t.Errorf("%T bounds = %v, want %v", converted, converted.Bounds(), img.Bounds())
+		}
+		decoded, err := (&decoders.GozxingDecoder{}).Decode(converted)
+		if err != nil {
+			t.Fatalf("Decode() of %T failed: %v", converted, err)
+		}
+		if string(decoded) != data {
+			t.Errorf("Decode() of %T = %q, want %q", converted, decoded, data)
+		}
+	}
+}
+
+func TestToGray_FlattensTransparency(t *testing.T) {
+	if v := ToGray(image.NewNRGBA(image.Rect(0, 0, 2, 2))).GrayAt(1, 1).Y; v != 255 {
+		t.Errorf("transparent pixel = %d, want 255", v)
+	}
+	if _, _, _, a := ToRGBA(image.NewNRGBA(image.Rect(0, 0, 2, 2))).At(1, 1).RGBA(); a != 0xFFFF {
+		t.Errorf("transparent pixel alpha = %#x, want opaque", a)
+	}
+}
@@ -0,0 +1,83 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"math/rand"
+)
+
+// finderRegionModules is the side of the square each finder pattern
+// occupies with its separator and the adjacent format information.
+const finderRegionModules = 9
+
+// timingModule is the row and column index of the timing patterns.
+const timingModule = 6
+
+// CorruptModules returns a grayscale copy of img with a fraction of its
+// modules inverted, chosen pseudo-randomly from seed so runs are repeatable.
+// img is a standard QR code of moduleCount modules per side surrounded by a
+// quietZone-module margin. The finder patterns, their format information,
+// and the timing patterns are never flipped: losing them stops a decoder
+// from locating the symbol at all, while every other module is covered by
+// error correction. This measures how much damage each EC level recovers.
+// A fraction of 0 or less returns an unmodified copy.
+func CorruptModules(img image.Image, moduleCount, quietZone int, fraction float64, seed int64) *image.Gray {
+	b := img.Bounds()
+	out := image.NewGray(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(out, out.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), img, b.Min, draw.Over)
+
+	if fraction <= 0 || moduleCount <= 0 {
+		return out
+	}
+	fraction = math.Min(fraction, 1)
+
+	var cells []image.Point
+	for row := 0; row < moduleCount; row++ {
+		for col := 0; col < moduleCount; col++ {
+			if !isFunctionModule(row, col, moduleCount) {
+				cells = append(cells, image.Point{X: col, Y: row})
+			}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(cells), func(i, j int) { cells[i], cells[j] = cells[j], cells[i] })
+	flips := int(math.Round(fraction * float64(len(cells))))
+
+	modulePx := float64(b.Dx()) / float64(moduleCount+2*quietZone)
+	edge := func(module int) int {
+		return int(math.Round(float64(quietZone+module) * modulePx))
+	}
+
+	for _, cell := range cells[:flips] {
+		rect := image.Rect(edge(cell.X), edge(cell.Y), edge(cell.X+1), edge(cell.Y+1))
+		center := image.Point{X: (rect.Min.X + rect.Max.X) / 2, Y: (rect.Min.Y + rect.Max.Y) / 2}
+
+		inverted := color.Gray{Y: 0}
+		if out.GrayAt(center.X, center.Y).Y < 128 {
+			inverted = color.Gray{Y: 255}
+		}
+		draw.Draw(out, rect, image.NewUniform(inverted), image.Point{}, draw.Src)
+	}
+
+	return out
+}
+
+// isFunctionModule reports whether the module at row, col is part of a
+// finder region (finder pattern, separator, format information) or a timing
+// pattern.
+func isFunctionModule(row, col, moduleCount int) bool {
+	far := moduleCount - finderRegionModules
+	switch {
+	case row < finderRegionModules && col < finderRegionModules:
+		return true
+	case row < finderRegionModules && col > far:
+		return true
+	case row > far && col < finderRegionModules:
+		return true
+	}
+	return row == timingModule || col == timingModule
+}
@@ -0,0 +1,75 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// moduleGrid renders an all-black moduleCount grid with a quietZone margin
+// at pixelsPerModule.
+func moduleGrid(moduleCount, quietZone, pixelsPerModule int) *image.Gray {
+	size := (moduleCount + 2*quietZone) * pixelsPerModule
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	lo, hi := quietZone*pixelsPerModule, (quietZone+moduleCount)*pixelsPerModule
+	for y := lo; y < hi; y++ {
+		for x := lo; x < hi; x++ {
+			img.SetGray(x, y, color.Gray{Y: 0})
+		}
+	}
+	return img
+}
+
+func TestCorruptModules(t *testing.T) {
+	const moduleCount, quietZone, px = 21, 4, 10
+	img := moduleGrid(moduleCount, quietZone, px)
+
+	corrupted := CorruptModules(img, moduleCount, quietZone, 0.25, 7)
+
+	flipped := 0
+	for row := 0; row < moduleCount; row++ {
+		for col := 0; col < moduleCount; col++ {
+			center := corrupted.GrayAt((quietZone+col)*px+px/2, (quietZone+row)*px+px/2).Y
+			if center == 0 {
+				continue
+			}
+			flipped++
+			if isFunctionModule(row, col, moduleCount) {
+				t.Errorf("Function module (%d,%d) was inverted", row, col)
+			}
+		}
+	}
+
+	// 21×21 minus the three finder corners and the timing lines leaves 208
+	eligible := 0
+	for row := 0; row < moduleCount; row++ {
+		for col := 0; col < moduleCount; col++ {
+			if !isFunctionModule(row, col, moduleCount) {
+				eligible++
+			}
+		}
+	}
+	if eligible != 208 {
+		t.Fatalf("%d eligible modules, want 208", eligible)
+	}
+	if want := eligible / 4; flipped != want {
+		t.Errorf("Inverted %d of %d modules, want %d", flipped, eligible, want)
+	}
+
+	// Same seed, same modules
+	again := CorruptModules(img, moduleCount, quietZone, 0.25, 7)
+	for i := range corrupted.Pix {
+		if corrupted.Pix[i] != again.Pix[i] {
+			t.Fatal("CorruptModules() with the same seed inverted different modules")
+		}
+	}
+
+	if clean := CorruptModules(img, moduleCount, quietZone, 0, 7); clean.GrayAt(quietZone*px+5, quietZone*px+5).Y != 0 {
+		t.Error("Zero corruption should leave the image unchanged")
+	}
+}
@@ -0,0 +1,35 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// OverlayCenterBlock returns a grayscale copy of img with a white square
+// covering fractionOfArea of the image area blanked out of its center, like
+// a logo placed on a branded QR code. The finder patterns sit in the corners,
+// so a small block only destroys data modules that error correction must
+// recover. A fraction of 0 or less returns an unmodified copy; 1 or more
+// blanks the whole image.
+func OverlayCenterBlock(img image.Image, fractionOfArea float64) *image.Gray {
+	b := img.Bounds()
+	out := image.NewGray(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(out, out.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), img, b.Min, draw.Over)
+
+	if fractionOfArea <= 0 {
+		return out
+	}
+	fractionOfArea = math.Min(fractionOfArea, 1)
+
+	width := int(math.Round(float64(b.Dx()) * math.Sqrt(fractionOfArea)))
+	height := int(math.Round(float64(b.Dy()) * math.Sqrt(fractionOfArea)))
+	x0 := (b.Dx() - width) / 2
+	y0 := (b.Dy() - height) / 2
+	block := image.Rect(x0, y0, x0+width, y0+height)
+	draw.Draw(out, block, image.NewUniform(color.Gray{Y: 255}), image.Point{}, draw.Src)
+
+	return out
+}
@@ -0,0 +1,53 @@
+package raster
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+	"github.com/skip2/go-qrcode"
+)
+
+func TestOverlayCenterBlock_Geometry(t *testing.T) {
+	img := OverlayCenterBlock(checkerboard(100, 1), 0.25)
+
+	if img.Bounds().Dx() != 100 || img.Bounds().Dy() != 100 {
+		t.Fatalf("Occluded size = %v, want 100x100", img.Bounds())
+	}
+
+	// 25% of the area is a 50×50 block from (25,25)
+	for _, p := range []image.Point{{25, 25}, {50, 50}, {74, 74}} {
+		if v := img.GrayAt(p.X, p.Y).Y; v != 255 {
+			t.Errorf("Pixel %v = %d, want blanked", p, v)
+		}
+	}
+	if v := img.GrayAt(24, 24).Y; v != 0 {
+		t.Errorf("Pixel (24,24) = %d, want untouched black", v)
+	}
+
+	if img := OverlayCenterBlock(checkerboard(100, 1), 0); img.GrayAt(50, 50).Y != 0 {
+		t.Error("Zero occlusion should leave the image unchanged")
+	}
+}
+
+func TestOverlayCenterBlock_HighECDecodes(t *testing.T) {
+	// High EC recovers ~30% of codewords; a 10% block is well within it
+	data := "https://example.com/branded"
+	pngBytes, err := qrcode.Encode(data, qrcode.Highest, 400)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	decoded, err := (&decoders.GozxingDecoder{}).Decode(OverlayCenterBlock(img, 0.10))
+	if err != nil {
+		t.Fatalf("Decode() of a 10%% occluded High EC code failed: %v", err)
+	}
+	if string(decoded) != data {
+		t.Errorf("Decode() = %q, want %q", decoded, data)
+	}
+}
@@ -0,0 +1,35 @@
+package raster
+
+import (
+	"image"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// ScaleNearest resizes img to size×size using nearest-neighbor sampling.
+// Every output pixel copies one source pixel, so pure black and white
+// modules stay pure black and white with crisp edges.
+// Transparent pixels are flattened onto a white background.
+func ScaleNearest(img image.Image, size int) *image.Gray {
+	return scale(img, size, xdraw.NearestNeighbor)
+}
+
+// ScaleSmooth resizes img to size×size using bilinear interpolation.
+// Module edges that fall between pixels blend to gray, like a display
+// pipeline scaling an image. Transparent pixels are flattened onto white.
+func ScaleSmooth(img image.Image, size int) *image.Gray {
+	return scale(img, size, xdraw.BiLinear)
+}
+
+// scale flattens img onto white and resizes it with the given interpolator.
+func scale(img image.Image, size int, interp xdraw.Interpolator) *image.Gray {
+	bounds := img.Bounds()
+	flat := image.NewGray(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, bounds.Min, draw.Over)
+
+	dst := image.NewGray(image.Rect(0, 0, size, size))
+	interp.Scale(dst, dst.Bounds(), flat, flat.Bounds(), draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,65 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// checkerboard returns a size×size image of cell×cell black and white squares.
+func checkerboard(size, cell int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if (x/cell+y/cell)%2 == 0 {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	return img
+}
+
+func TestScaleNearest_PreservesPureBlackAndWhite(t *testing.T) {
+	// 29px → 440px is a fractional 15.17x scale
+	img := ScaleNearest(checkerboard(29, 1), 440)
+
+	if img.Bounds().Dx() != 440 || img.Bounds().Dy() != 440 {
+		t.Fatalf("Scaled size = %v, want 440x440", img.Bounds())
+	}
+
+	for y := 0; y < 440; y++ {
+		for x := 0; x < 440; x++ {
+			if v := img.GrayAt(x, y).Y; v != 0 && v != 255 {
+				t.Fatalf("pixel (%d,%d) = %d, want pure black or white", x, y, v)
+			}
+		}
+	}
+}
+
+func TestScaleSmooth_Antialiases(t *testing.T) {
+	img := ScaleSmooth(checkerboard(29, 1), 440)
+
+	gray := 0
+	for y := 0; y < 440; y++ {
+		for x := 0; x < 440; x++ {
+			if v := img.GrayAt(x, y).Y; v != 0 && v != 255 {
+				gray++
+			}
+		}
+	}
+
+	if gray == 0 {
+		t.Error("ScaleSmooth() produced no intermediate gray pixels")
+	}
+}
+
+func TestScaleNearest_FlattensTransparency(t *testing.T) {
+	// Fully transparent source should become white, not black
+	img := ScaleNearest(image.NewNRGBA(image.Rect(0, 0, 4, 4)), 8)
+
+	if v := img.GrayAt(3, 3).Y; v != 255 {
+		t.Errorf("transparent pixel = %d, want 255", v)
+	}
+}
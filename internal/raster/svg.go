@@ -0,0 +1,217 @@
+// Package raster renders vector QR code output to images.
+//
+// It supports the SVG subset emitted by QR code generators: a root <svg>
+// element with a viewBox and filled <rect> children. Rendering is
+// antialiased, like a browser rasterizing an SVG at display time, so module
+// edges that fall between pixels become gray.
+package raster
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/vector"
+)
+
+// rect is a filled rectangle in viewBox coordinates.
+type rect struct {
+	x, y, w, h float32
+	fill       color.Gray
+}
+
+// RasterizeSVG renders an SVG document to a size×size grayscale image.
+// The viewBox is scaled to fill the image; the background is white.
+// Rectangles are antialiased at pixel boundaries.
+func RasterizeSVG(svg []byte, size int) (*image.Gray, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("raster: invalid size %d", size)
+	}
+
+	viewW, viewH, rects, err := parseSVG(svg)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewGray(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	scaleX := float32(size) / viewW
+	scaleY := float32(size) / viewH
+
+	// Consecutive rects with the same fill share one coverage mask, so adjacent
+	// modules blend at their shared edge instead of leaving seams.
+	var r vector.Rasterizer
+	for start := 0; start < len(rects); {
+		end := start
+		r.Reset(size, size)
+		for end < len(rects) && rects[end].fill == rects[start].fill {
+			rc := rects[end]
+			x0, y0 := rc.x*scaleX, rc.y*scaleY
+			x1, y1 := (rc.x+rc.w)*scaleX, (rc.y+rc.h)*scaleY
+			r.MoveTo(x0, y0)
+			r.LineTo(x1, y0)
+			r.LineTo(x1, y1)
+			r.LineTo(x0, y1)
+			r.ClosePath()
+			end++
+		}
+		r.Draw(img, img.Bounds(), image.NewUniform(rects[start].fill), image.Point{})
+		start = end
+	}
+
+	return img, nil
+}
+
+// parseSVG extracts the viewBox dimensions and filled rects from an SVG document.
+func parseSVG(svg []byte) (float32, float32, []rect, error) {
+	dec := xml.NewDecoder(bytes.NewReader(svg))
+
+	var viewW, viewH float32
+	var rects []rect
+	sawRoot := false
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("raster: invalid SVG: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "svg":
+			sawRoot = true
+			viewW, viewH, err = parseViewBox(attr(start, "viewBox"))
+			if err != nil {
+				return 0, 0, nil, err
+			}
+		case "rect":
+			rc, err := parseRect(start)
+			if err != nil {
+				return 0, 0, nil, err
+			}
+			rects = append(rects, rc)
+		}
+	}
+
+	if !sawRoot {
+		return 0, 0, nil, fmt.Errorf("raster: missing <svg> element")
+	}
+
+	return viewW, viewH, rects, nil
+}
+
+// parseViewBox parses "minX minY width height". The origin must be 0 0.
+func parseViewBox(s string) (float32, float32, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if len(fields) != 4 {
+		return 0, 0, fmt.Errorf("raster: invalid viewBox %q", s)
+	}
+
+	var vals [4]float32
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("raster: invalid viewBox %q: %w", s, err)
+		}
+		vals[i] = float32(v)
+	}
+
+	if vals[0] != 0 || vals[1] != 0 {
+		return 0, 0, fmt.Errorf("raster: unsupported viewBox origin %q", s)
+	}
+	if vals[2] <= 0 || vals[3] <= 0 {
+		return 0, 0, fmt.Errorf("raster: invalid viewBox size %q", s)
+	}
+
+	return vals[2], vals[3], nil
+}
+
+// parseRect reads the geometry and fill of a <rect> element.
+// Missing x and y default to 0; a missing fill defaults to black per SVG.
+func parseRect(el xml.StartElement) (rect, error) {
+	var rc rect
+	fields := []struct {
+		name     string
+		dst      *float32
+		required bool
+	}{
+		{"x", &rc.x, false},
+		{"y", &rc.y, false},
+		{"width", &rc.w, true},
+		{"height", &rc.h, true},
+	}
+
+	for _, f := range fields {
+		s := attr(el, f.name)
+		if s == "" {
+			if f.required {
+				return rect{}, fmt.Errorf("raster: rect missing %s", f.name)
+			}
+			continue
+		}
+		v, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return rect{}, fmt.Errorf("raster: invalid rect %s %q: %w", f.name, s, err)
+		}
+		*f.dst = float32(v)
+	}
+
+	fill, err := parseFill(attr(el, "fill"))
+	if err != nil {
+		return rect{}, err
+	}
+	rc.fill = fill
+
+	return rc, nil
+}
+
+// parseFill converts an SVG fill color to gray.
+// Supports black, white, and #rgb/#rrggbb hex colors.
+func parseFill(s string) (color.Gray, error) {
+	switch strings.ToLower(s) {
+	case "", "black":
+		return color.Gray{Y: 0}, nil
+	case "white":
+		return color.Gray{Y: 255}, nil
+	}
+
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 || hex == s {
+		return color.Gray{}, fmt.Errorf("raster: unsupported fill %q", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.Gray{}, fmt.Errorf("raster: unsupported fill %q: %w", s, err)
+	}
+
+	rgb := color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 255}
+	return color.GrayModel.Convert(rgb).(color.Gray), nil
+}
+
+// attr returns the value of the named attribute, or "" if absent.
+func attr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package raster
+
+import (
+	"testing"
+)
+
+func TestRasterizeSVG_IntegerScale(t *testing.T) {
+	// 2×2 viewBox with the top-left cell dark, rendered at 4px per unit
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 2 2">
+<rect width="2" height="2" fill="#ffffff"/>
+<rect x="0" y="0" width="1" height="1" fill="#000000"/>
+</svg>`)
+
+	img, err := RasterizeSVG(svg, 8)
+	if err != nil {
+		t.Fatalf("RasterizeSVG() failed: %v", err)
+	}
+
+	// Edges align with pixels, so every pixel is pure black or white
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			got := img.GrayAt(x, y).Y
+			want := uint8(255)
+			if x < 4 && y < 4 {
+				want = 0
+			}
+			if got != want {
+				t.Errorf("pixel (%d,%d) = %d, want %d", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestRasterizeSVG_FractionalScaleAntialiased(t *testing.T) {
+	// One dark unit in a 3-unit viewBox at 10px: the edge falls at 3.33px
+	svg := []byte(`<svg viewBox="0 0 3 3"><rect x="0" y="0" width="1" height="3" fill="black"/></svg>`)
+
+	img, err := RasterizeSVG(svg, 10)
+	if err != nil {
+		t.Fatalf("RasterizeSVG() failed: %v", err)
+	}
+
+	if got := img.GrayAt(0, 5).Y; got != 0 {
+		t.Errorf("interior pixel = %d, want 0", got)
+	}
+
+	edge := img.GrayAt(3, 5).Y
+	if edge == 0 || edge == 255 {
+		t.Errorf("edge pixel = %d, want antialiased gray", edge)
+	}
+
+	if got := img.GrayAt(9, 5).Y; got != 255 {
+		t.Errorf("background pixel = %d, want 255", got)
+	}
+}
+
+func TestRasterizeSVG_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		svg  string
+		size int
+	}{
+		{"zero size", `<svg viewBox="0 0 1 1"></svg>`, 0},
+		{"missing svg", `<rect width="1" height="1"/>`, 10},
+		{"bad viewBox", `<svg viewBox="0 0 1"></svg>`, 10},
+		{"rect missing width", `<svg viewBox="0 0 1 1"><rect height="1"/></svg>`, 10},
+		{"unsupported fill", `<svg viewBox="0 0 1 1"><rect width="1" height="1" fill="url(#g)"/></svg>`, 10},
+		{"malformed", `<svg viewBox="0 0 1 1"><rect`, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RasterizeSVG([]byte(tt.svg), tt.size); err == nil {
+				t.Errorf("RasterizeSVG() should fail for %s", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package testdata
+
+import (
+	"fmt"
+
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
+)
+
+// MaxQRVersion is the largest standard QR version.
+const MaxQRVersion = 40
+
+// capacityModulePixels is the module size used for capacity boundary cases.
+// An integer size keeps fractional-module failures out of boundary results.
+const capacityModulePixels = 4
+
+// EncodingMode is a QR data encoding mode: a column group of CapacityTable.
+type EncodingMode int
+
+const (
+	ModeNumeric EncodingMode = iota
+	ModeAlphanumeric
+	ModeByte
+	ModeKanji
+)
+
+// capacityModes is the number of encoding modes in CapacityTable.
+const capacityModes = 4
+
+// capacityLevels lists the error correction levels in CapacityTable order.
+var capacityLevels = [...]string{"L", "M", "Q", "H"}
+
+// CapacityTable is the ISO/IEC 18004 Table 7 data capacity of every QR
+// version, encoding mode, and error correction level, in characters (bytes
+// for ModeByte): CapacityTable[version-1][mode][level], with levels in
+// L, M, Q, H order. Use Capacity for checked lookups.
+//
+// Built from the standard's codeword and error correction block tables:
+// data codewords are the total codewords minus error correction codewords,
+// less the 4-bit mode indicator and the mode's character count field.
+var CapacityTable = buildCapacityTable()
+
+func buildCapacityTable() [MaxQRVersion][capacityModes][len(capacityLevels)]int {
+	var table [MaxQRVersion][capacityModes][len(capacityLevels)]int
+
+	modes := [capacityModes]*decoder.Mode{decoder.Mode_NUMERIC, decoder.Mode_ALPHANUMERIC, decoder.Mode_BYTE, decoder.Mode_KANJI}
+	for version := 1; version <= MaxQRVersion; version++ {
+		qrVersion, err := decoder.Version_GetVersionForNumber(version)
+		if err != nil {
+			panic(fmt.Sprintf("capacity table: %v", err))
+		}
+
+		for li, level := range capacityLevels {
+			ecLevel, err := decoder.ErrorCorrectionLevel_ValueOf(level)
+			if err != nil {
+				panic(fmt.Sprintf("capacity table: %v", err))
+			}
+			dataBits := (qrVersion.GetTotalCodewords() - qrVersion.GetECBlocksForLevel(ecLevel).GetTotalECCodewords()) * 8
+
+			for mode, m := range modes {
+				bits := dataBits - 4 - m.GetCharacterCountBits(qrVersion)
+				table[version-1][mode][li] = charsInBits(EncodingMode(mode), bits)
+			}
+		}
+	}
+
+	return table
+}
+
+// charsInBits returns how many characters of mode fit in bits.
+func charsInBits(mode EncodingMode, bits int) int {
+	switch mode {
+	case ModeNumeric:
+		// 10 bits per 3 digits; a trailing 1 or 2 digits take 4 or 7 bits
+		n, rem := bits/10*3, bits%10
+		switch {
+		case rem >= 7:
+			n += 2
+		case rem >= 4:
+			n++
+		}
+		return n
+	case ModeAlphanumeric:
+		// 11 bits per 2 characters; a trailing character takes 6 bits
+		n := bits / 11 * 2
+		if bits%11 >= 6 {
+			n++
+		}
+		return n
+	case ModeKanji:
+		return bits / 13
+	}
+	return bits / 8
+}
+
+// Capacity returns the number of characters (bytes for ModeByte) a QR
+// version holds in mode at the given error correction level ("L", "M",
+// "Q", or "H"), from CapacityTable.
+func Capacity(version int, mode EncodingMode, level string) (int, error) {
+	if version < 1 || version > MaxQRVersion {
+		return 0, fmt.Errorf("invalid QR version %d", version)
+	}
+	if mode < ModeNumeric || mode > ModeKanji {
+		return 0, fmt.Errorf("invalid encoding mode %d", mode)
+	}
+	for li, l := range capacityLevels {
+		if l == level {
+			return CapacityTable[version-1][mode][li], nil
+		}
+	}
+	return 0, fmt.Errorf("invalid error correction level %q", level)
+}
+
+// ModeForContent returns the encoding mode a single-segment encoder uses
+// for contentType: the compact modes for numeric and alphanumeric content,
+// byte mode for binary and UTF-8.
+func ModeForContent(contentType ContentType) EncodingMode {
+	switch contentType {
+	case ContentNumeric:
+		return ModeNumeric
+	case ContentAlphanumeric:
+		return ModeAlphanumeric
+	}
+	return ModeByte
+}
+
+// ByteCapacity returns the number of bytes a QR version holds in byte mode
+// at the given error correction level ("L", "M", "Q", or "H").
+func ByteCapacity(version int, level string) (int, error) {
+	return Capacity(version, ModeByte, level)
+}
+
+// EstimateVersion predicts the smallest QR version an encoder would choose
+// for a single-segment payload of dataSize characters at the given error
+// correction level, using the ModeForContent mode.
+//
+// Encoders that split payloads into several segments may pick a smaller
+// version, so this is an estimate. Returns an error when the payload exceeds
+// version 40 capacity.
+func EstimateVersion(dataSize int, contentType ContentType, level string) (int, error) {
+	mode := ModeForContent(contentType)
+	for version := 1; version <= MaxQRVersion; version++ {
+		capacity, err := Capacity(version, mode, level)
+		if err != nil {
+			return 0, err
+		}
+		if dataSize <= capacity {
+			return version, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%d bytes exceed QR version %d capacity at level %s", dataSize, MaxQRVersion, level)
+}
+
+// CapacityUtilization returns the fraction (0.0-1.0) of a QR version's data
+// capacity that a single-segment payload of dataSize characters uses at the
+// given error correction level, counting the mode indicator and character
+// count field. A payload that exactly fills the version returns close to 1;
+// only the unused bits of the last codeword keep it below.
+//
+// Returns an error for an invalid version or level, or when the payload does
+// not fit the version.
+func CapacityUtilization(dataSize int, contentType ContentType, version int, level string) (float64, error) {
+	qrVersion, err := decoder.Version_GetVersionForNumber(version)
+	if err != nil {
+		return 0, fmt.Errorf("invalid QR version %d: %w", version, err)
+	}
+
+	ecLevel, err := decoder.ErrorCorrectionLevel_ValueOf(level)
+	if err != nil {
+		return 0, fmt.Errorf("invalid error correction level %q: %w", level, err)
+	}
+
+	mode, dataBits := segmentBits(dataSize, contentType)
+	usedBits := 4 + mode.GetCharacterCountBits(qrVersion) + dataBits
+	capacityBits := (qrVersion.GetTotalCodewords() - qrVersion.GetECBlocksForLevel(ecLevel).GetTotalECCodewords()) * 8
+	if usedBits > capacityBits {
+		return 0, fmt.Errorf("%d bytes exceed QR version %d capacity at level %s", dataSize, version, level)
+	}
+
+	return float64(usedBits) / float64(capacityBits), nil
+}
+
+// segmentBits returns the encoding mode for contentType and the number of
+// data bits dataSize characters take in it, excluding the segment header.
+// Numeric and alphanumeric content use their compact modes; binary and
+// UTF-8 use byte mode.
+func segmentBits(dataSize int, contentType ContentType) (*decoder.Mode, int) {
+	switch contentType {
+	case ContentNumeric:
+		// 10 bits per 3 digits; a trailing 1 or 2 digits take 4 or 7 bits
+		return decoder.Mode_NUMERIC, dataSize/3*10 + []int{0, 4, 7}[dataSize%3]
+	case ContentAlphanumeric:
+		// 11 bits per 2 characters; a trailing character takes 6 bits
+		return decoder.Mode_ALPHANUMERIC, dataSize/2*11 + dataSize%2*6
+	}
+	return decoder.Mode_BYTE, dataSize * 8
+}
+
+// GenerateCapacityBoundaryCases generates binary payloads that exactly fill
+// each QR version 1-40 at the given error correction level, plus one byte
+// over to force the next version. Version transitions are where a symbol is
+// completely full, which is most likely to expose module-placement bugs.
+//
+// Each pair uses a pixel size giving 4 pixels per module for the exactly-full
+// version. The one-over case for version 40 exceeds QR capacity entirely
+// and is not generated.
+//
+// Returns nil for an invalid error correction level.
+func GenerateCapacityBoundaryCases(level string) []TestCase {
+	cases := make([]TestCase, 0, 2*MaxQRVersion-1)
+
+	for version := 1; version <= MaxQRVersion; version++ {
+		capacity, err := ByteCapacity(version, level)
+		if err != nil {
+			return nil
+		}
+
+		pixelSize := (CalculateModuleCount(version) + QuietZoneModules) * capacityModulePixels
+
+		sizes := []int{capacity, capacity + 1}
+		if version == MaxQRVersion {
+			sizes = sizes[:1]
+		}
+
+		for _, size := range sizes {
+			cases = append(cases, TestCase{
+				Name:                 formatTestNameWithEC("binary", size, pixelSize, level) + "-v" + formatInt(version),
+				Data:                 generateBinary(size),
+				DataSize:             size,
+				PixelSize:            pixelSize,
+				ContentType:          ContentBinary,
+				ErrorCorrectionLevel: level,
+			})
+		}
+	}
+
+	return cases
+}
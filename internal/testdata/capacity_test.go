@@ -0,0 +1,247 @@
+package testdata
+
+import "testing"
+
+func TestByteCapacity(t *testing.T) {
+	// Byte mode capacities from ISO 18004 Table 7
+	tests := []struct {
+		version int
+		level   string
+		want    int
+	}{
+		{1, "M", 14},
+		{2, "M", 26},
+		{3, "M", 42},
+		{4, "M", 62},
+		{5, "M", 84},
+		{7, "M", 122},
+		{9, "M", 180},
+		{10, "M", 213},
+		{20, "M", 666},
+		{40, "M", 2331},
+		{1, "L", 17},
+		{40, "L", 2953},
+		{1, "H", 7},
+		{40, "H", 1273},
+	}
+
+	for _, tt := range tests {
+		got, err := ByteCapacity(tt.version, tt.level)
+		if err != nil {
+			t.Errorf("ByteCapacity(%d, %q) failed: %v", tt.version, tt.level, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ByteCapacity(%d, %q) = %d, want %d", tt.version, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	// Well-known ISO/IEC 18004 Table 7 entries
+	tests := []struct {
+		version int
+		mode    EncodingMode
+		level   string
+		want    int
+	}{
+		{1, ModeNumeric, "L", 41},
+		{1, ModeAlphanumeric, "L", 25},
+		{1, ModeByte, "L", 17},
+		{1, ModeKanji, "L", 10},
+		{1, ModeNumeric, "H", 17},
+		{1, ModeAlphanumeric, "H", 10},
+		{10, ModeNumeric, "M", 513},
+		{10, ModeAlphanumeric, "Q", 221},
+		{25, ModeByte, "Q", 715},
+		{40, ModeNumeric, "L", 7089},
+		{40, ModeAlphanumeric, "L", 4296},
+		{40, ModeByte, "L", 2953},
+		{40, ModeKanji, "L", 1817},
+		{40, ModeNumeric, "H", 3057},
+	}
+
+	for _, tt := range tests {
+		got, err := Capacity(tt.version, tt.mode, tt.level)
+		if err != nil {
+			t.Errorf("Capacity(%d, %d, %q) failed: %v", tt.version, tt.mode, tt.level, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Capacity(%d, %d, %q) = %d, want %d", tt.version, tt.mode, tt.level, got, tt.want)
+		}
+	}
+
+	// Levels are indexed L, M, Q, H
+	if got := CapacityTable[0][ModeNumeric]; got != [4]int{41, 34, 27, 17} {
+		t.Errorf("CapacityTable version 1 numeric = %v, want [41 34 27 17]", got)
+	}
+
+	for _, bad := range []struct {
+		version int
+		mode    EncodingMode
+		level   string
+	}{
+		{0, ModeByte, "L"},
+		{41, ModeByte, "L"},
+		{1, EncodingMode(4), "L"},
+		{1, ModeByte, "X"},
+	} {
+		if _, err := Capacity(bad.version, bad.mode, bad.level); err == nil {
+			t.Errorf("Capacity(%d, %d, %q) should fail", bad.version, bad.mode, bad.level)
+		}
+	}
+}
+
+func TestByteCapacity_Invalid(t *testing.T) {
+	if _, err := ByteCapacity(41, "M"); err == nil {
+		t.Error("ByteCapacity(41, M) should fail")
+	}
+	if _, err := ByteCapacity(1, "X"); err == nil {
+		t.Error("ByteCapacity(1, X) should fail")
+	}
+}
+
+func TestGenerateCapacityBoundaryCases(t *testing.T) {
+	cases := GenerateCapacityBoundaryCases("M")
+
+	// Exactly-full and one-over for versions 1-39, exactly-full for 40
+	if want := 2*MaxQRVersion - 1; len(cases) != want {
+		t.Fatalf("GenerateCapacityBoundaryCases(M) returned %d cases, want %d", len(cases), want)
+	}
+
+	known := map[int]int{1: 14, 5: 84, 10: 213, 20: 666, 40: 2331}
+	for version, capacity := range known {
+		full := cases[2*(version-1)]
+		if full.DataSize != capacity || len(full.Data) != capacity {
+			t.Errorf("Version %d full case size = %d (data %d), want %d", version, full.DataSize, len(full.Data), capacity)
+		}
+
+		wantPixels := (CalculateModuleCount(version) + QuietZoneModules) * 4
+		if full.PixelSize != wantPixels {
+			t.Errorf("Version %d pixel size = %d, want %d", version, full.PixelSize, wantPixels)
+		}
+
+		if version < MaxQRVersion {
+			over := cases[2*(version-1)+1]
+			if over.DataSize != capacity+1 {
+				t.Errorf("Version %d one-over case size = %d, want %d", version, over.DataSize, capacity+1)
+			}
+		}
+	}
+
+	for _, tc := range cases {
+		if tc.ErrorCorrectionLevel != "M" || tc.ContentType != ContentBinary {
+			t.Errorf("Case %s has EC %q content %v, want M binary", tc.Name, tc.ErrorCorrectionLevel, tc.ContentType)
+		}
+	}
+}
+
+func TestGenerateCapacityBoundaryCases_InvalidLevel(t *testing.T) {
+	if cases := GenerateCapacityBoundaryCases("X"); cases != nil {
+		t.Errorf("GenerateCapacityBoundaryCases(X) = %d cases, want nil", len(cases))
+	}
+}
+
+func TestEstimateVersion(t *testing.T) {
+	tests := []struct {
+		dataSize    int
+		contentType ContentType
+		level       string
+		want        int
+	}{
+		// Byte mode: version 1-L holds 17 bytes
+		{17, ContentBinary, "L", 1},
+		{18, ContentBinary, "L", 2},
+		{500, ContentUTF8, "L", 15},
+		// Compact modes fit more characters per version
+		{41, ContentNumeric, "L", 1},
+		{42, ContentNumeric, "L", 2},
+		{25, ContentAlphanumeric, "L", 1},
+		{26, ContentAlphanumeric, "L", 2},
+		{100, ContentAlphanumeric, "H", 8},
+		// Version 40-L byte capacity
+		{2953, ContentBinary, "L", 40},
+	}
+
+	for _, tt := range tests {
+		got, err := EstimateVersion(tt.dataSize, tt.contentType, tt.level)
+		if err != nil {
+			t.Errorf("EstimateVersion(%d, %v, %s) failed: %v", tt.dataSize, tt.contentType, tt.level, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("EstimateVersion(%d, %v, %s) = %d, want %d", tt.dataSize, tt.contentType, tt.level, got, tt.want)
+		}
+	}
+
+	if _, err := EstimateVersion(2954, ContentBinary, "L"); err == nil {
+		t.Error("EstimateVersion() over version 40 capacity should fail")
+	}
+	if _, err := EstimateVersion(10, ContentBinary, "X"); err == nil {
+		t.Error("EstimateVersion() with an invalid level should fail")
+	}
+}
+
+func TestCapacityUtilization(t *testing.T) {
+	tests := []struct {
+		dataSize    int
+		contentType ContentType
+		version     int
+		level       string
+		want        float64
+	}{
+		// Version 1-L has 19 data codewords (152 bits); a byte segment
+		// header takes 12 bits
+		{17, ContentBinary, 1, "L", 148.0 / 152},
+		{1, ContentBinary, 1, "L", 20.0 / 152},
+		// 41 digits take 137 bits plus a 14-bit header
+		{41, ContentNumeric, 1, "L", 151.0 / 152},
+		// A small payload in a large version uses little of it
+		{17, ContentBinary, 10, "L", 156.0 / 2192},
+	}
+
+	for _, tt := range tests {
+		got, err := CapacityUtilization(tt.dataSize, tt.contentType, tt.version, tt.level)
+		if err != nil {
+			t.Errorf("CapacityUtilization(%d, %v, %d, %s) failed: %v", tt.dataSize, tt.contentType, tt.version, tt.level, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CapacityUtilization(%d, %v, %d, %s) = %v, want %v", tt.dataSize, tt.contentType, tt.version, tt.level, got, tt.want)
+		}
+	}
+
+	if _, err := CapacityUtilization(18, ContentBinary, 1, "L"); err == nil {
+		t.Error("CapacityUtilization() over the version's capacity should fail")
+	}
+	if _, err := CapacityUtilization(10, ContentBinary, 41, "L"); err == nil {
+		t.Error("CapacityUtilization() with an invalid version should fail")
+	}
+	if _, err := CapacityUtilization(10, ContentBinary, 1, "X"); err == nil {
+		t.Error("CapacityUtilization() with an invalid level should fail")
+	}
+}
+
+func TestCapacityUtilization_BoundaryPayloads(t *testing.T) {
+	// A payload of exactly ByteCapacity bytes leaves less than one
+	// codeword unused
+	for _, level := range []string{"L", "M", "Q", "H"} {
+		for version := 1; version <= MaxQRVersion; version++ {
+			capacity, err := ByteCapacity(version, level)
+			if err != nil {
+				t.Fatalf("ByteCapacity(%d, %s) failed: %v", version, level, err)
+			}
+
+			got, err := CapacityUtilization(capacity, ContentBinary, version, level)
+			if err != nil {
+				t.Fatalf("CapacityUtilization(%d, binary, %d, %s) failed: %v", capacity, version, level, err)
+			}
+
+			minUsed := 1 - 8/float64(capacity*8+12)
+			if got < minUsed || got > 1 {
+				t.Errorf("CapacityUtilization() for full version %d-%s = %v, want in [%v, 1]", version, level, got, minUsed)
+			}
+		}
+	}
+}
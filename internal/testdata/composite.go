@@ -0,0 +1,49 @@
+package testdata
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// CompositeSideBySide places images left to right on a white canvas,
+// top-aligned. The canvas is as wide as all images together and as tall
+// as the tallest one. Used to build multi-symbol test images; each encoder
+// output already carries its own quiet zone, so no gap is added.
+func CompositeSideBySide(imgs ...image.Image) *image.RGBA {
+	width, height := 0, 0
+	for _, img := range imgs {
+		b := img.Bounds()
+		width += b.Dx()
+		if b.Dy() > height {
+			height = b.Dy()
+		}
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+
+	x := 0
+	for _, img := range imgs {
+		b := img.Bounds()
+		dst := image.Rect(x, 0, x+b.Dx(), b.Dy())
+		draw.Draw(canvas, dst, img, b.Min, draw.Over)
+		x += b.Dx()
+	}
+
+	return canvas
+}
+
+// EmbedInCanvas pastes img onto a width × height white canvas with its
+// top-left corner at at, like a QR code captured inside a larger photo.
+// Parts of img falling outside the canvas are clipped.
+func EmbedInCanvas(img image.Image, width, height int, at image.Point) *image.RGBA {
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+
+	b := img.Bounds()
+	dst := image.Rectangle{Min: at, Max: at.Add(b.Size())}
+	draw.Draw(canvas, dst, img, b.Min, draw.Over)
+
+	return canvas
+}
@@ -0,0 +1,37 @@
+package testdata
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestCompositeSideBySide(t *testing.T) {
+	left := image.NewGray(image.Rect(0, 0, 10, 10))
+	right := image.NewGray(image.Rect(0, 0, 20, 15))
+	for i := range right.Pix {
+		right.Pix[i] = 0xFF
+	}
+
+	canvas := CompositeSideBySide(left, right)
+
+	if got := canvas.Bounds(); got != image.Rect(0, 0, 30, 15) {
+		t.Fatalf("CompositeSideBySide() bounds = %v, want (0,0)-(30,15)", got)
+	}
+
+	tests := []struct {
+		x, y int
+		want uint8
+	}{
+		{5, 5, 0x00},   // inside left image (black)
+		{5, 12, 0xFF},  // below the shorter left image: white background
+		{15, 12, 0xFF}, // inside right image (white)
+	}
+
+	for _, tt := range tests {
+		got := color.GrayModel.Convert(canvas.At(tt.x, tt.y)).(color.Gray).Y
+		if got != tt.want {
+			t.Errorf("pixel (%d,%d) = 0x%02X, want 0x%02X", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package testdata
+
+import (
+	"fmt"
+
+	"github.com/13rac1/qr-library-test/internal/encoders"
+)
+
+// fractionalModuleTarget is the module pixel size FractionalPixelSize aims
+// for: halfway between integers and well above MinModulePixelSize.
+const fractionalModuleTarget = 4.5
+
+// FractionalPixelSize returns a pixel size that renders the given QR version
+// at a fractional module pixel size near 4.5 pixels per module, with a
+// standard quiet zone. Returns 0 for invalid versions.
+func FractionalPixelSize(version int) int {
+	moduleCount := CalculateModuleCount(version)
+	if moduleCount == 0 {
+		return 0
+	}
+
+	totalModules := moduleCount + QuietZoneModules
+	pixelSize := int(fractionalModuleTarget * float64(totalModules))
+	for !IsFractionalModuleSize(CalculateModulePixelSize(pixelSize, moduleCount, QuietZoneModules)) {
+		pixelSize++
+	}
+	return pixelSize
+}
+
+// EncodeAtFractional encodes data at targetVersion, forced with
+// EncodeOptions.ForceVersion, at the pixel size FractionalPixelSize picks,
+// so the symbol has a fractional module size by construction rather than by
+// the happenstance of the default matrix. Use it to build deterministic
+// fractional-module fixtures for decoder tests.
+//
+// Returns an error when the encoder fails, cannot force the version, or
+// reports a version other than targetVersion.
+func EncodeAtFractional(enc encoders.Encoder, data []byte, targetVersion int) (encoders.EncodeResult, error) {
+	pixelSize := FractionalPixelSize(targetVersion)
+	if pixelSize == 0 {
+		return encoders.EncodeResult{}, fmt.Errorf("invalid QR version %d: must be 1-%d", targetVersion, MaxQRVersion)
+	}
+
+	result, err := enc.Encode(data, encoders.EncodeOptions{
+		ErrorCorrectionLevel: encoders.ErrorCorrectionM,
+		PixelSize:            pixelSize,
+		ForceVersion:         targetVersion,
+	})
+	if err != nil {
+		return encoders.EncodeResult{}, fmt.Errorf("%s at version %d, %dpx: %w", enc.Name(), targetVersion, pixelSize, err)
+	}
+	if result.Version != targetVersion {
+		return encoders.EncodeResult{}, fmt.Errorf("%s produced version %d, want %d", enc.Name(), result.Version, targetVersion)
+	}
+	return result, nil
+}
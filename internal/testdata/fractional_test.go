@@ -0,0 +1,42 @@
+package testdata
+
+import (
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/encoders"
+)
+
+func TestEncodeAtFractional(t *testing.T) {
+	enc := &encoders.Skip2Encoder{}
+	data := []byte("HELLO")
+
+	for _, version := range []int{1, 5, 10, 25} {
+		result, err := EncodeAtFractional(enc, data, version)
+		if err != nil {
+			t.Fatalf("EncodeAtFractional(version %d) failed: %v", version, err)
+		}
+		if result.Version != version {
+			t.Errorf("Version = %d, want %d", result.Version, version)
+		}
+
+		size := result.Image.Bounds().Dx()
+		if size != FractionalPixelSize(version) {
+			t.Errorf("Version %d image is %dpx, want %dpx", version, size, FractionalPixelSize(version))
+		}
+
+		px := CalculateModulePixelSize(size, CalculateModuleCount(version), QuietZoneModules)
+		if !IsFractionalModuleSize(px) || px < MinModulePixelSize {
+			t.Errorf("Version %d at %dpx has module size %.3f, want fractional and decodable", version, size, px)
+		}
+	}
+}
+
+func TestEncodeAtFractional_Errors(t *testing.T) {
+	if _, err := EncodeAtFractional(&encoders.Skip2Encoder{}, []byte("HELLO"), 41); err == nil {
+		t.Error("EncodeAtFractional(version 41) should fail")
+	}
+	// 100 bytes do not fit version 1
+	if _, err := EncodeAtFractional(&encoders.Skip2Encoder{}, make([]byte, 100), 1); err == nil {
+		t.Error("EncodeAtFractional() with data over the version's capacity should fail")
+	}
+}
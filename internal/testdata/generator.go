@@ -54,6 +54,29 @@ type TestCase struct {
 	// Valid values: "L" (Low ~7%), "M" (Medium ~15%), "Q" (Quartile ~25%), "H" (High ~30%).
 	// This affects QR version selection and capacity.
 	ErrorCorrectionLevel string
+
+	// Symbols holds one payload per QR symbol for multi-symbol test cases.
+	// Each payload is encoded at PixelSize and the symbols are composited
+	// side by side into one image. Data and DataSize describe the first symbol.
+	// Nil for ordinary single-symbol test cases.
+	Symbols [][]byte
+
+	// Seed is the random seed that generated binary Data in a seed sweep
+	// (see ExpandSeeds). 0 for cases using the default fixed seed.
+	Seed int64
+}
+
+// IsMultiSymbol reports whether the test case renders more than one QR symbol.
+func (tc TestCase) IsMultiSymbol() bool {
+	return len(tc.Symbols) > 1
+}
+
+// Payloads returns the payload of each QR symbol in the test case.
+func (tc TestCase) Payloads() [][]byte {
+	if tc.IsMultiSymbol() {
+		return tc.Symbols
+	}
+	return [][]byte{tc.Data}
 }
 
 // GeneratePixelSizeMatrix generates the primary test matrix for pixel size testing.
@@ -446,12 +469,21 @@ func generateUTF8(size int) []byte {
 // Binary data typically requires higher QR versions than numeric/alphanumeric
 // of the same byte length.
 func generateBinary(size int) []byte {
+	// Use fixed seed for deterministic output
+	return BinaryData(size, DefaultBinarySeed)
+}
+
+// DefaultBinarySeed is the fixed seed used for generated binary payloads.
+const DefaultBinarySeed = 42
+
+// BinaryData returns size pseudo-random bytes generated from seed.
+// The same size and seed always produce the same bytes.
+func BinaryData(size int, seed int64) []byte {
 	if size <= 0 {
 		return []byte{}
 	}
 
-	// Use fixed seed for deterministic output
-	src := rand.NewSource(42)
+	src := rand.NewSource(seed)
 	rng := rand.New(src)
 
 	data := make([]byte, size)
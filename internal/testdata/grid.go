@@ -0,0 +1,146 @@
+package testdata
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// darkThreshold is the 8-bit luminance below which a pixel counts as dark.
+const darkThreshold = 128
+
+// ExtractModuleGrid samples the center of each module cell and returns the
+// module grid as grid[row][col], true for dark modules.
+//
+// The symbol is located by the bounding box of dark pixels, which the three
+// finder patterns pin to the symbol corners, so the result does not depend
+// on each encoder's quiet zone or padding. moduleCount is the number of
+// modules per side (17 + 4*version).
+func ExtractModuleGrid(img image.Image, moduleCount int) ([][]bool, error) {
+	if img == nil {
+		return nil, fmt.Errorf("image is nil")
+	}
+	if moduleCount <= 0 {
+		return nil, fmt.Errorf("invalid module count %d", moduleCount)
+	}
+
+	bounds, ok := darkBounds(img)
+	if !ok {
+		return nil, fmt.Errorf("no dark pixels found")
+	}
+
+	moduleWidth := float64(bounds.Dx()) / float64(moduleCount)
+	moduleHeight := float64(bounds.Dy()) / float64(moduleCount)
+	if moduleWidth < 1 || moduleHeight < 1 {
+		return nil, fmt.Errorf("symbol %dx%d px is smaller than %d modules", bounds.Dx(), bounds.Dy(), moduleCount)
+	}
+
+	grid := make([][]bool, moduleCount)
+	for row := range grid {
+		grid[row] = make([]bool, moduleCount)
+		y := bounds.Min.Y + int((float64(row)+0.5)*moduleHeight)
+		for col := range grid[row] {
+			x := bounds.Min.X + int((float64(col)+0.5)*moduleWidth)
+			grid[row][col] = isDark(img.At(x, y))
+		}
+	}
+
+	return grid, nil
+}
+
+// ModuleHammingDistance counts modules that differ between two grids of the same size.
+func ModuleHammingDistance(a, b [][]bool) (int, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("grid sizes differ: %d vs %d rows", len(a), len(b))
+	}
+
+	distance := 0
+	for row := range a {
+		if len(a[row]) != len(b[row]) {
+			return 0, fmt.Errorf("grid sizes differ at row %d: %d vs %d columns", row, len(a[row]), len(b[row]))
+		}
+		for col := range a[row] {
+			if a[row][col] != b[row][col] {
+				distance++
+			}
+		}
+	}
+
+	return distance, nil
+}
+
+// darkBounds returns the bounding box of all dark pixels in img.
+func darkBounds(img image.Image) (image.Rectangle, bool) {
+	b := img.Bounds()
+	minX, minY := b.Max.X, b.Max.Y
+	maxX, maxY := b.Min.X-1, b.Min.Y-1
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if !isDark(img.At(x, y)) {
+				continue
+			}
+			minX = min(minX, x)
+			minY = min(minY, y)
+			maxX = max(maxX, x)
+			maxY = max(maxY, y)
+		}
+	}
+
+	if maxX < minX {
+		return image.Rectangle{}, false
+	}
+	return image.Rect(minX, minY, maxX+1, maxY+1), true
+}
+
+// MeasureQuietZoneModules measures the light margin an encoder rendered
+// around a symbol of moduleCount modules per side, in whole modules. The
+// symbol is the bounding box of dark pixels; its finder patterns reach the
+// corners, so the box edges are the symbol edges.
+//
+// ok is false when the box is not a plausible symbol: not square to within a
+// module, or less than one pixel per module.
+func MeasureQuietZoneModules(img image.Image, moduleCount int) (modules int, ok bool) {
+	if img == nil || moduleCount <= 0 {
+		return 0, false
+	}
+
+	symbol, found := darkBounds(img)
+	if !found {
+		return 0, false
+	}
+
+	modulePx := float64(symbol.Dx()) / float64(moduleCount)
+	if modulePx < 1 || math.Abs(float64(symbol.Dx()-symbol.Dy())) > modulePx {
+		return 0, false
+	}
+
+	margin := float64(symbol.Min.X - img.Bounds().Min.X)
+	return int(math.Round(margin / modulePx)), true
+}
+
+// IsBlank reports whether img is a single tone: every pixel dark or every
+// pixel light. A blank image cannot contain a QR symbol, so an encoder that
+// returns one has failed even if it reported no error. Empty images are blank.
+func IsBlank(img image.Image) bool {
+	b := img.Bounds()
+	if b.Empty() {
+		return true
+	}
+
+	first := isDark(img.At(b.Min.X, b.Min.Y))
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if isDark(img.At(x, y)) != first {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isDark reports whether c is darker than darkThreshold.
+func isDark(c color.Color) bool {
+	return color.GrayModel.Convert(c).(color.Gray).Y < darkThreshold
+}
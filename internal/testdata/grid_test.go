@@ -0,0 +1,162 @@
+package testdata
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+)
+
+func TestExtractModuleGrid_FinderPatterns(t *testing.T) {
+	// "HELLO" fits version 1 (21 modules) at every EC level
+	pngBytes, err := qrcode.Encode("HELLO", qrcode.Medium, 250)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	grid, err := ExtractModuleGrid(img, 21)
+	if err != nil {
+		t.Fatalf("ExtractModuleGrid() failed: %v", err)
+	}
+
+	if len(grid) != 21 || len(grid[0]) != 21 {
+		t.Fatalf("ExtractModuleGrid() size = %dx%d, want 21x21", len(grid), len(grid[0]))
+	}
+
+	// Top-left corner of each 7x7 finder pattern
+	finders := []struct {
+		name     string
+		row, col int
+	}{
+		{"top-left", 0, 0},
+		{"top-right", 0, 14},
+		{"bottom-left", 14, 0},
+	}
+
+	for _, f := range finders {
+		// Outer ring corners are dark
+		for _, corner := range [][2]int{{0, 0}, {0, 6}, {6, 0}, {6, 6}} {
+			if !grid[f.row+corner[0]][f.col+corner[1]] {
+				t.Errorf("%s finder corner (%d,%d) should be dark", f.name, corner[0], corner[1])
+			}
+		}
+
+		// Inner light ring
+		if grid[f.row+1][f.col+1] {
+			t.Errorf("%s finder light ring (1,1) should be light", f.name)
+		}
+
+		// 3x3 dark center
+		for r := 2; r <= 4; r++ {
+			for c := 2; c <= 4; c++ {
+				if !grid[f.row+r][f.col+c] {
+					t.Errorf("%s finder center (%d,%d) should be dark", f.name, r, c)
+				}
+			}
+		}
+	}
+}
+
+func TestModuleHammingDistance(t *testing.T) {
+	a := [][]bool{{true, false}, {false, true}}
+	b := [][]bool{{true, true}, {false, false}}
+
+	got, err := ModuleHammingDistance(a, b)
+	if err != nil {
+		t.Fatalf("ModuleHammingDistance() failed: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("ModuleHammingDistance() = %d, want 2", got)
+	}
+
+	if _, err := ModuleHammingDistance(a, [][]bool{{true}}); err == nil {
+		t.Error("ModuleHammingDistance() with mismatched sizes should fail")
+	}
+}
+
+func TestExtractModuleGrid_Invalid(t *testing.T) {
+	if _, err := ExtractModuleGrid(nil, 21); err == nil {
+		t.Error("ExtractModuleGrid(nil) should fail")
+	}
+
+	blank := image.NewGray(image.Rect(0, 0, 100, 100))
+	for i := range blank.Pix {
+		blank.Pix[i] = 0xFF
+	}
+	if _, err := ExtractModuleGrid(blank, 21); err == nil {
+		t.Error("ExtractModuleGrid() on a blank image should fail")
+	}
+}
+
+func TestIsBlank(t *testing.T) {
+	white := image.NewGray(image.Rect(0, 0, 8, 8))
+	for i := range white.Pix {
+		white.Pix[i] = 0xFF
+	}
+	black := image.NewGray(image.Rect(0, 0, 8, 8))
+
+	mixed := image.NewGray(image.Rect(0, 0, 8, 8))
+	mixed.Pix[len(mixed.Pix)-1] = 0xFF
+
+	tests := []struct {
+		name string
+		img  image.Image
+		want bool
+	}{
+		{"all white", white, true},
+		{"all black", black, true},
+		{"one light pixel", mixed, false},
+		{"empty", image.NewGray(image.Rect(0, 0, 0, 0)), true},
+	}
+
+	for _, tt := range tests {
+		if got := IsBlank(tt.img); got != tt.want {
+			t.Errorf("IsBlank(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMeasureQuietZoneModules(t *testing.T) {
+	// skip2 renders a 4-module quiet zone
+	pngBytes, err := qrcode.Encode("HELLO", qrcode.Medium, 250)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	if got, ok := MeasureQuietZoneModules(img, 21); !ok || got != 4 {
+		t.Errorf("MeasureQuietZoneModules(skip2) = %d, %v, want 4, true", got, ok)
+	}
+
+	// A 2-module margin at 10 pixels per module
+	two := image.NewGray(image.Rect(0, 0, 250, 250))
+	for i := range two.Pix {
+		two.Pix[i] = 0xFF
+	}
+	for y := 20; y < 230; y++ {
+		for x := 20; x < 230; x++ {
+			two.Pix[y*two.Stride+x] = 0
+		}
+	}
+	if got, ok := MeasureQuietZoneModules(two, 21); !ok || got != 2 {
+		t.Errorf("MeasureQuietZoneModules(2-module margin) = %d, %v, want 2, true", got, ok)
+	}
+
+	// A single dark pixel is too small to be a 21-module symbol
+	dot := image.NewGray(image.Rect(0, 0, 250, 250))
+	for i := range dot.Pix {
+		dot.Pix[i] = 0xFF
+	}
+	dot.Pix[0] = 0
+	if _, ok := MeasureQuietZoneModules(dot, 21); ok {
+		t.Error("MeasureQuietZoneModules(single pixel) should not be measurable")
+	}
+}
@@ -0,0 +1,206 @@
+package testdata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	// Image formats real-world inputs arrive in; image.Decode needs each
+	// registered to read it
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+// imageExtFormats maps image file extensions to the format name
+// image.Decode reports for their content.
+var imageExtFormats = map[string]string{
+	".png":  "png",
+	".jpg":  "jpeg",
+	".jpeg": "jpeg",
+	".gif":  "gif",
+	".webp": "webp",
+}
+
+// FrameBest selects the first frame of an animated image in which LocateQR
+// finds a QR code, for captures where only one frame shows the symbol.
+const FrameBest = -1
+
+// ImageCase is an existing QR code image with its expected payload, for
+// testing decoders on images not produced by the encoders.
+type ImageCase struct {
+	// Name is the file name without its extension.
+	Name string
+
+	// Format is the detected image format: "png", "jpeg", "gif", or "webp".
+	Format string
+
+	Image image.Image
+
+	// Frame is the index of the animation frame Image was taken from; 0
+	// for still images.
+	Frame int
+
+	// Expected is the payload the image encodes, read from <name>.txt.
+	Expected []byte
+}
+
+// LoadImageCases reads every PNG, JPEG, GIF, and WEBP image in dir, in name
+// order, with its expected payload from a sidecar <name>.txt file. The format
+// is detected from the file content; an extension that disagrees with the
+// content is an error, since it usually means a mislabeled export.
+func LoadImageCases(dir string) ([]ImageCase, error) {
+	return LoadImageCasesFrame(dir, 0)
+}
+
+// LoadImageCasesFrame is LoadImageCases taking each image from the given
+// animation frame: an index from 0, or FrameBest. Animated GIFs are
+// composited frame by frame, so a partial frame shows over the ones before
+// it. Other formats decode to a single frame; Go reads only the default
+// image of an APNG.
+func LoadImageCasesFrame(dir string, frame int) ([]ImageCase, error) {
+	if frame < FrameBest {
+		return nil, fmt.Errorf("images: invalid frame %d", frame)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("images: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var cases []ImageCase
+	for _, name := range names {
+		ext := strings.ToLower(filepath.Ext(name))
+		wantFormat, ok := imageExtFormats[ext]
+		if !ok {
+			continue
+		}
+
+		tc, err := loadImageCase(dir, name, wantFormat, frame)
+		if err != nil {
+			return nil, fmt.Errorf("images: %s: %w", filepath.Join(dir, name), err)
+		}
+		cases = append(cases, tc)
+	}
+
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("images: %s has no images", dir)
+	}
+	return cases, nil
+}
+
+// loadImageCase decodes one image, selects its frame, and reads its
+// sidecar payload.
+func loadImageCase(dir, name, wantFormat string, frame int) (ImageCase, error) {
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ImageCase{}, err
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return ImageCase{}, fmt.Errorf("decoding image: %w", err)
+	}
+	if format != wantFormat {
+		return ImageCase{}, fmt.Errorf("extension %s but content is %s", filepath.Ext(name), format)
+	}
+
+	frames := []image.Image{img}
+	if format == "gif" {
+		all, err := gif.DecodeAll(bytes.NewReader(content))
+		if err != nil {
+			return ImageCase{}, fmt.Errorf("decoding frames: %w", err)
+		}
+		frames = compositeGIF(all)
+	}
+
+	index, err := selectFrame(frames, frame)
+	if err != nil {
+		return ImageCase{}, err
+	}
+
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	expected, err := os.ReadFile(filepath.Join(dir, base+".txt"))
+	if err != nil {
+		return ImageCase{}, fmt.Errorf("expected payload: %w", err)
+	}
+
+	return ImageCase{
+		Name:     base,
+		Format:   format,
+		Image:    frames[index],
+		Frame:    index,
+		Expected: expected,
+	}, nil
+}
+
+// selectFrame returns the index of the requested frame, or with FrameBest
+// the first frame LocateQR finds a QR code in.
+func selectFrame(frames []image.Image, frame int) (int, error) {
+	if frame != FrameBest {
+		if frame >= len(frames) {
+			return 0, fmt.Errorf("frame %d out of range (%d frames)", frame, len(frames))
+		}
+		return frame, nil
+	}
+
+	for i, f := range frames {
+		if _, err := LocateQR(f); err == nil {
+			return i, nil
+		}
+	}
+	return 0, errors.New("no frame contains a QR code")
+}
+
+// compositeGIF renders each frame of an animated GIF as it is displayed:
+// drawn over the frames before it, which are then cleared or restored as
+// the frame's disposal method asks.
+func compositeGIF(g *gif.GIF) []image.Image {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() && len(g.Image) > 0 {
+		bounds = g.Image[0].Bounds()
+	}
+
+	canvas := image.NewRGBA(bounds)
+	frames := make([]image.Image, len(g.Image))
+	for i, frame := range g.Image {
+		disposal := byte(gif.DisposalNone)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			draw.Draw(previous, bounds, canvas, bounds.Min, draw.Src)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		rendered := image.NewRGBA(bounds)
+		draw.Draw(rendered, bounds, canvas, bounds.Min, draw.Src)
+		frames[i] = rendered
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return frames
+}
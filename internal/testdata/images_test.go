@@ -0,0 +1,141 @@
+package testdata
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goqrcode "github.com/skip2/go-qrcode"
+
+	"github.com/13rac1/qr-library-test/internal/decoders"
+)
+
+func TestLoadImageCases_GIF(t *testing.T) {
+	cases, err := LoadImageCases("testdata/images")
+	if err != nil {
+		t.Fatalf("LoadImageCases() failed: %v", err)
+	}
+	if len(cases) != 1 {
+		t.Fatalf("LoadImageCases() returned %d cases, want 1", len(cases))
+	}
+
+	tc := cases[0]
+	if tc.Name != "url" || tc.Format != "gif" {
+		t.Errorf("Case = %q (%s), want url (gif)", tc.Name, tc.Format)
+	}
+
+	decoded, err := (&decoders.GozxingDecoder{}).Decode(tc.Image)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if !bytes.Equal(decoded, tc.Expected) {
+		t.Errorf("Decoded %q, want %q", decoded, tc.Expected)
+	}
+}
+
+func TestLoadImageCases_Invalid(t *testing.T) {
+	var pngBytes bytes.Buffer
+	if err := png.Encode(&pngBytes, image.NewGray(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		files   map[string][]byte
+		wantErr string
+	}{
+		{"mislabeled format", map[string][]byte{"a.gif": pngBytes.Bytes(), "a.txt": []byte("x")}, "extension .gif but content is png"},
+		{"missing payload", map[string][]byte{"a.png": pngBytes.Bytes()}, "expected payload"},
+		{"corrupt image", map[string][]byte{"a.webp": []byte("RIFF"), "a.txt": []byte("x")}, "decoding image"},
+		{"no images", map[string][]byte{"notes.txt": []byte("x")}, "has no images"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := LoadImageCases(dir)
+			if err == nil {
+				t.Fatal("LoadImageCases() should fail")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadImageCases() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadImageCasesFrame_AnimatedGIF(t *testing.T) {
+	pngBytes, err := goqrcode.Encode("second frame", goqrcode.Medium, 256)
+	if err != nil {
+		t.Fatalf("failed to generate test QR code: %v", err)
+	}
+	symbol, err := png.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("failed to decode PNG: %v", err)
+	}
+
+	// Frame 1 is blank; the QR code only appears on frame 2
+	bounds := symbol.Bounds()
+	palette := color.Palette{color.White, color.Black}
+	blank := image.NewPaletted(bounds, palette)
+	qr := image.NewPaletted(bounds, palette)
+	draw.Draw(qr, bounds, symbol, bounds.Min, draw.Src)
+
+	var gifBytes bytes.Buffer
+	anim := &gif.GIF{Image: []*image.Paletted{blank, qr}, Delay: []int{50, 50}}
+	if err := gif.EncodeAll(&gifBytes, anim); err != nil {
+		t.Fatalf("failed to encode GIF: %v", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string][]byte{"capture.gif": gifBytes.Bytes(), "capture.txt": []byte("second frame")}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases, err := LoadImageCasesFrame(dir, FrameBest)
+	if err != nil {
+		t.Fatalf("LoadImageCasesFrame(FrameBest) failed: %v", err)
+	}
+	if cases[0].Frame != 1 {
+		t.Errorf("FrameBest selected frame %d, want 1", cases[0].Frame)
+	}
+	decoded, err := (&decoders.GozxingDecoder{}).Decode(cases[0].Image)
+	if err != nil {
+		t.Fatalf("Decode() of the selected frame failed: %v", err)
+	}
+	if !bytes.Equal(decoded, cases[0].Expected) {
+		t.Errorf("Decoded %q, want %q", decoded, cases[0].Expected)
+	}
+
+	// The default first frame has no code
+	cases, err = LoadImageCases(dir)
+	if err != nil {
+		t.Fatalf("LoadImageCases() failed: %v", err)
+	}
+	if cases[0].Frame != 0 {
+		t.Errorf("LoadImageCases() took frame %d, want 0", cases[0].Frame)
+	}
+	if _, err := (&decoders.GozxingDecoder{}).Decode(cases[0].Image); err == nil {
+		t.Error("Decode() of the blank first frame succeeded")
+	}
+
+	if _, err := LoadImageCasesFrame(dir, 2); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("LoadImageCasesFrame(2) error = %v, want out of range", err)
+	}
+}
@@ -0,0 +1,83 @@
+package testdata
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode/detector"
+)
+
+// LocateQR finds a QR code inside a larger image and returns its bounds,
+// including a QuietZoneModules margin, clipped to the image.
+//
+// It uses the finder-pattern search DetectMaskPattern relies on: the three
+// finder centers sit 3.5 modules in from the symbol edges, and their spacing
+// over the detected dimension gives the module size. The bounds cover all
+// four corners, so a slightly rotated symbol is still enclosed.
+//
+// Returns an error if no QR code is found.
+func LocateQR(img image.Image) (image.Rectangle, error) {
+	if img == nil {
+		return image.Rectangle{}, errors.New("image is nil")
+	}
+
+	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return image.Rectangle{}, fmt.Errorf("failed to create binary bitmap: %w", err)
+	}
+
+	matrix, err := bmp.GetBlackMatrix()
+	if err != nil {
+		return image.Rectangle{}, fmt.Errorf("failed to binarize image: %w", err)
+	}
+
+	detected, err := detector.NewDetector(matrix).Detect(nil)
+	if err != nil {
+		return image.Rectangle{}, fmt.Errorf("failed to locate QR code: %w", err)
+	}
+
+	// Points are bottom-left, top-left, top-right finder centers
+	points := detected.GetPoints()
+	bottomLeft, topLeft, topRight := points[0], points[1], points[2]
+	dimension := detected.GetBits().GetWidth()
+
+	modulePx := math.Hypot(topRight.GetX()-topLeft.GetX(), topRight.GetY()-topLeft.GetY()) / float64(dimension-7)
+	bottomRightX := topRight.GetX() - topLeft.GetX() + bottomLeft.GetX()
+	bottomRightY := topRight.GetY() - topLeft.GetY() + bottomLeft.GetY()
+
+	minX := math.Min(math.Min(topLeft.GetX(), bottomLeft.GetX()), math.Min(topRight.GetX(), bottomRightX))
+	maxX := math.Max(math.Max(topLeft.GetX(), bottomLeft.GetX()), math.Max(topRight.GetX(), bottomRightX))
+	minY := math.Min(math.Min(topLeft.GetY(), topRight.GetY()), math.Min(bottomLeft.GetY(), bottomRightY))
+	maxY := math.Max(math.Max(topLeft.GetY(), topRight.GetY()), math.Max(bottomLeft.GetY(), bottomRightY))
+
+	// From finder centers out to the symbol edge, then the quiet zone
+	margin := (3.5 + QuietZoneModules) * modulePx
+	b := img.Bounds()
+	bounds := image.Rect(
+		b.Min.X+int(math.Floor(minX-margin)),
+		b.Min.Y+int(math.Floor(minY-margin)),
+		b.Min.X+int(math.Ceil(maxX+margin)),
+		b.Min.Y+int(math.Ceil(maxY+margin)),
+	)
+	return bounds.Intersect(b), nil
+}
+
+// CropToQR returns the QR code found in img, cropped by LocateQR to the
+// symbol and its quiet zone, as a new image with its origin at (0, 0).
+// Returns an error if no QR code is found.
+func CropToQR(img image.Image) (*image.RGBA, error) {
+	bounds, err := LocateQR(img)
+	if err != nil {
+		return nil, err
+	}
+
+	cropped := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(cropped, cropped.Bounds(), img, bounds.Min, draw.Over)
+	return cropped, nil
+}
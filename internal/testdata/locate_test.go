@@ -0,0 +1,82 @@
+package testdata
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+	goqrcode "github.com/skip2/go-qrcode"
+)
+
+func TestLocateQR(t *testing.T) {
+	t.Run("nil image", func(t *testing.T) {
+		if _, err := LocateQR(nil); err == nil {
+			t.Fatal("expected error for nil image")
+		}
+	})
+
+	t.Run("blank image", func(t *testing.T) {
+		if _, err := LocateQR(image.NewGray(image.Rect(0, 0, 100, 100))); err == nil {
+			t.Fatal("expected error for image without a QR code")
+		}
+	})
+}
+
+func TestCropToQR_Embedded(t *testing.T) {
+	// Version 1 with a 4-module quiet zone is 29 modules: 7px each at 203px
+	pngBytes, err := goqrcode.Encode("locate me", goqrcode.Medium, 203)
+	if err != nil {
+		t.Fatalf("failed to generate test QR code: %v", err)
+	}
+	symbol, err := png.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("failed to decode PNG: %v", err)
+	}
+
+	// Off-center in a 2x canvas
+	at := image.Point{X: 150, Y: 60}
+	canvas := EmbedInCanvas(symbol, 406, 406, at)
+
+	bounds, err := LocateQR(canvas)
+	if err != nil {
+		t.Fatalf("LocateQR() failed: %v", err)
+	}
+
+	// Finder centers are detected to within a module
+	want := image.Rectangle{Min: at, Max: at.Add(image.Pt(203, 203))}
+	const tolerance = 7
+	if abs(bounds.Min.X-want.Min.X) > tolerance || abs(bounds.Min.Y-want.Min.Y) > tolerance ||
+		abs(bounds.Max.X-want.Max.X) > tolerance || abs(bounds.Max.Y-want.Max.Y) > tolerance {
+		t.Errorf("LocateQR() = %v, want within %dpx of %v", bounds, tolerance, want)
+	}
+
+	cropped, err := CropToQR(canvas)
+	if err != nil {
+		t.Fatalf("CropToQR() failed: %v", err)
+	}
+	if cropped.Bounds().Min != (image.Point{}) {
+		t.Errorf("CropToQR() origin = %v, want (0,0)", cropped.Bounds().Min)
+	}
+
+	bmp, err := gozxing.NewBinaryBitmapFromImage(cropped)
+	if err != nil {
+		t.Fatalf("failed to create binary bitmap: %v", err)
+	}
+	result, err := qrcode.NewQRCodeReader().Decode(bmp, nil)
+	if err != nil {
+		t.Fatalf("gozxing failed to decode the cropped image: %v", err)
+	}
+	if result.GetText() != "locate me" {
+		t.Errorf("decoded %q, want %q", result.GetText(), "locate me")
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
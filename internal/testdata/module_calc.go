@@ -6,12 +6,24 @@ import (
 	"fmt"
 	"image"
 	"math"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
+	"github.com/makiuchi-d/gozxing/qrcode/detector"
 )
 
 // Standard quiet zone size in modules (white border around QR code).
 // QR code specification requires minimum 4 modules.
 const QuietZoneModules = 4
 
+// MicroQuietZoneModules is the quiet zone size in modules for Micro QR codes.
+// Micro QR codes have a single finder pattern and only require 2 modules.
+const MicroQuietZoneModules = 2
+
+// MinModulePixelSize is the smallest module pixel size considered decodable.
+// Below 2 pixels per module, binarization cannot separate adjacent modules.
+const MinModulePixelSize = 2.0
+
 // DetectQRVersion detects the QR code version from an encoded image.
 // QR versions range from 1 to 40, determining the module count.
 //
@@ -76,6 +88,122 @@ func CalculateModuleCount(version int) int {
 	return 17 + 4*version
 }
 
+// CalculateMicroModuleCount returns the number of modules per side for a Micro QR version.
+// Micro QR code module count follows the formula: 9 + 2×version.
+//
+// Examples:
+//   - M1: 11 modules
+//   - M2: 13 modules
+//   - M3: 15 modules
+//   - M4: 17 modules
+//
+// Returns 0 for invalid versions (must be 1-4).
+func CalculateMicroModuleCount(version int) int {
+	if version < 1 || version > 4 {
+		return 0
+	}
+	return 9 + 2*version
+}
+
+// DetectMicroQRVersion detects the Micro QR version (M1-M4) from an encoded image.
+// Like DetectQRVersion, this expects an image with one pixel per module
+// and no quiet zone: dimension = 9 + 2×version.
+//
+// Returns the detected version (1-4) or -1 with an error if detection fails.
+func DetectMicroQRVersion(img image.Image) (int, error) {
+	if img == nil {
+		return -1, errors.New("image is nil")
+	}
+
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+
+	if width != height {
+		return -1, fmt.Errorf("image is not square: %dx%d", width, height)
+	}
+
+	if (width-9)%2 == 0 {
+		version := (width - 9) / 2
+		if version >= 1 && version <= 4 {
+			return version, nil
+		}
+	}
+
+	return -1, fmt.Errorf("could not determine Micro QR version from dimension %d", width)
+}
+
+// DetectMaskPattern reads the data mask pattern (0-7) from a QR code image.
+// Unlike DetectQRVersion, this locates the symbol in the rendered image and
+// reads the format information bits, so it works at any pixel size.
+//
+// Different encoders choose different masks for the same data, which can
+// interact with decoder binarization at fractional module sizes.
+//
+// Returns the mask pattern (0-7) or -1 with an error if detection fails.
+func DetectMaskPattern(img image.Image) (int, error) {
+	parser, err := locateSymbol(img)
+	if err != nil {
+		return -1, err
+	}
+
+	formatInfo, err := parser.ReadFormatInformation()
+	if err != nil {
+		return -1, fmt.Errorf("failed to read format information: %w", err)
+	}
+
+	return int(formatInfo.GetDataMask()), nil
+}
+
+// LocateQRVersion reads the QR version (1-40) of the symbol located in a
+// rendered image, from its version information bits or, below version 7,
+// its module count. Unlike DetectQRVersion it does not assume one pixel per
+// module, so it works at any pixel size and margin.
+//
+// Returns the version or -1 with an error if the symbol cannot be located.
+func LocateQRVersion(img image.Image) (int, error) {
+	parser, err := locateSymbol(img)
+	if err != nil {
+		return -1, err
+	}
+
+	version, err := parser.ReadVersion()
+	if err != nil {
+		return -1, fmt.Errorf("failed to read version information: %w", err)
+	}
+
+	return version.GetVersionNumber(), nil
+}
+
+// locateSymbol finds the QR symbol in img by its finder patterns and returns
+// a parser over its sampled modules.
+func locateSymbol(img image.Image) (*decoder.BitMatrixParser, error) {
+	if img == nil {
+		return nil, errors.New("image is nil")
+	}
+
+	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create binary bitmap: %w", err)
+	}
+
+	matrix, err := bmp.GetBlackMatrix()
+	if err != nil {
+		return nil, fmt.Errorf("failed to binarize image: %w", err)
+	}
+
+	detected, err := detector.NewDetector(matrix).Detect(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate QR code: %w", err)
+	}
+
+	parser, err := decoder.NewBitMatrixParser(detected.GetBits())
+	if err != nil {
+		return nil, fmt.Errorf("invalid bit matrix: %w", err)
+	}
+	return parser, nil
+}
+
 // CalculateModulePixelSize calculates the pixel dimension per module.
 // This value determines whether an encoder uses fractional or integer module sizing.
 //
@@ -101,6 +229,74 @@ func CalculateModulePixelSize(pixelSize, moduleCount, quietZone int) float64 {
 	return float64(pixelSize) / float64(moduleCount+quietZone)
 }
 
+// PredictModulePixelSize returns the module pixel size a test case is
+// expected to render at, using EstimateVersion and a standard quiet zone.
+func PredictModulePixelSize(tc TestCase) (float64, error) {
+	version, err := EstimateVersion(tc.DataSize, tc.ContentType, tc.ErrorCorrectionLevel)
+	if err != nil {
+		return 0, err
+	}
+	return CalculateModulePixelSize(tc.PixelSize, CalculateModuleCount(version), QuietZoneModules), nil
+}
+
+// FilterByModulePixelSize returns the test cases whose predicted module pixel
+// size lies within [minPx, maxPx]. A zero bound is unbounded; with both zero
+// the cases are returned as is. Cases whose version cannot be estimated (over
+// capacity) are dropped when a band is set.
+//
+// Example: a 4.0-6.0 band keeps the cases where fractional-module failures cluster.
+func FilterByModulePixelSize(cases []TestCase, minPx, maxPx float64) []TestCase {
+	if minPx == 0 && maxPx == 0 {
+		return cases
+	}
+
+	var filtered []TestCase
+	for _, tc := range cases {
+		px, err := PredictModulePixelSize(tc)
+		if err != nil {
+			continue
+		}
+		if px < minPx || (maxPx > 0 && px > maxPx) {
+			continue
+		}
+		filtered = append(filtered, tc)
+	}
+	return filtered
+}
+
+// Module size types for FilterByModuleSizeType.
+const (
+	ModuleSizeAll        = "all"
+	ModuleSizeInteger    = "integer"
+	ModuleSizeFractional = "fractional"
+)
+
+// FilterByModuleSizeType returns the test cases whose predicted module pixel
+// size is an integer (ModuleSizeInteger) or fractional (ModuleSizeFractional).
+// ModuleSizeAll or an empty sizeType returns the cases as is. Cases whose
+// version cannot be estimated (over capacity) are dropped when filtering.
+//
+// Running each half separately isolates the fractional-module hypothesis.
+func FilterByModuleSizeType(cases []TestCase, sizeType string) []TestCase {
+	if sizeType == "" || sizeType == ModuleSizeAll {
+		return cases
+	}
+
+	wantFractional := sizeType == ModuleSizeFractional
+	var filtered []TestCase
+	for _, tc := range cases {
+		px, err := PredictModulePixelSize(tc)
+		if err != nil {
+			continue
+		}
+		if IsFractionalModuleSize(px) != wantFractional {
+			continue
+		}
+		filtered = append(filtered, tc)
+	}
+	return filtered
+}
+
 // IsFractionalModuleSize checks whether a module pixel size is fractional.
 // Returns true if the module pixel size has a non-zero fractional component.
 //
@@ -143,3 +339,68 @@ func CalculateOptimalPixelSize(moduleCount, quietZone int) int {
 	multiplier := (minSize + totalModules - 1) / totalModules
 	return totalModules * multiplier
 }
+
+// PixelRange is an inclusive range of image pixel sizes.
+type PixelRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// IntegerPixelSizes returns the pixel sizes within [minPixelSize, maxPixelSize]
+// that produce an integer module pixel size for the given module count.
+// These are the multiples of (moduleCount + quietZone).
+//
+// Example:
+//   - Version 15 (77 modules) with 4 module quiet zone, range 320-560:
+//     324 (4px), 405 (5px), 486 (6px)
+//
+// Returns nil if any argument is invalid.
+func IntegerPixelSizes(moduleCount, quietZone, minPixelSize, maxPixelSize int) []int {
+	if moduleCount <= 0 || quietZone < 0 || minPixelSize <= 0 || maxPixelSize < minPixelSize {
+		return nil
+	}
+
+	totalModules := moduleCount + quietZone
+	var sizes []int
+
+	// First multiple of totalModules at or above minPixelSize
+	size := ((minPixelSize + totalModules - 1) / totalModules) * totalModules
+	for ; size <= maxPixelSize; size += totalModules {
+		sizes = append(sizes, size)
+	}
+
+	return sizes
+}
+
+// FractionalPixelRanges returns the "fractional cliffs" for a module count:
+// the pixel-size ranges within [minPixelSize, maxPixelSize] where the module
+// pixel size is non-integer. The boundaries of each range sit one pixel inside
+// the neighboring integer pixel sizes (see IntegerPixelSizes).
+//
+// Example:
+//   - Version 15 (77 modules) with 4 module quiet zone, range 320-560:
+//     320-323, 325-404, 406-485, 487-560
+//     The 406-485 range covers the known-problematic 440px and 450px sizes.
+//
+// Returns nil if any argument is invalid.
+func FractionalPixelRanges(moduleCount, quietZone, minPixelSize, maxPixelSize int) []PixelRange {
+	if moduleCount <= 0 || quietZone < 0 || minPixelSize <= 0 || maxPixelSize < minPixelSize {
+		return nil
+	}
+
+	var ranges []PixelRange
+	start := minPixelSize
+
+	for _, size := range IntegerPixelSizes(moduleCount, quietZone, minPixelSize, maxPixelSize) {
+		if size > start {
+			ranges = append(ranges, PixelRange{Min: start, Max: size - 1})
+		}
+		start = size + 1
+	}
+
+	if start <= maxPixelSize {
+		ranges = append(ranges, PixelRange{Min: start, Max: maxPixelSize})
+	}
+
+	return ranges
+}
@@ -1,8 +1,13 @@
 package testdata
 
 import (
+	"bytes"
+	"fmt"
 	"image"
+	"image/png"
 	"testing"
+
+	"github.com/skip2/go-qrcode"
 )
 
 func TestDetectQRVersion(t *testing.T) {
@@ -30,6 +35,86 @@ func TestDetectQRVersion(t *testing.T) {
 	})
 }
 
+func TestDetectMaskPattern(t *testing.T) {
+	t.Run("nil image", func(t *testing.T) {
+		mask, err := DetectMaskPattern(nil)
+		if err == nil {
+			t.Fatal("expected error for nil image")
+		}
+		if mask != -1 {
+			t.Errorf("expected mask -1, got %d", mask)
+		}
+	})
+
+	t.Run("blank image", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 100, 100))
+
+		mask, err := DetectMaskPattern(img)
+		if err == nil {
+			t.Fatal("expected error for image without a QR code")
+		}
+		if mask != -1 {
+			t.Errorf("expected mask -1, got %d", mask)
+		}
+	})
+
+	t.Run("encoded image", func(t *testing.T) {
+		pngBytes, err := qrcode.Encode("Hello, QR Code!", qrcode.Medium, 440)
+		if err != nil {
+			t.Fatalf("failed to generate test QR code: %v", err)
+		}
+
+		img, err := png.Decode(bytes.NewReader(pngBytes))
+		if err != nil {
+			t.Fatalf("failed to decode PNG: %v", err)
+		}
+
+		mask, err := DetectMaskPattern(img)
+		if err != nil {
+			t.Fatalf("DetectMaskPattern() failed: %v", err)
+		}
+		if mask < 0 || mask > 7 {
+			t.Errorf("DetectMaskPattern() = %d, want 0..7", mask)
+		}
+	})
+}
+
+func TestLocateQRVersion(t *testing.T) {
+	t.Run("blank image", func(t *testing.T) {
+		version, err := LocateQRVersion(image.NewGray(image.Rect(0, 0, 100, 100)))
+		if err == nil {
+			t.Fatal("expected error for image without a QR code")
+		}
+		if version != -1 {
+			t.Errorf("expected version -1, got %d", version)
+		}
+	})
+
+	// Versions 7 and up carry version information bits; below that the
+	// version follows from the module count
+	for _, want := range []int{2, 10} {
+		t.Run(fmt.Sprintf("version %d", want), func(t *testing.T) {
+			q, err := qrcode.NewWithForcedVersion("Hello, QR Code!", want, qrcode.Medium)
+			if err != nil {
+				t.Fatalf("failed to generate test QR code: %v", err)
+			}
+			pngBytes, err := q.PNG(440)
+			if err != nil {
+				t.Fatalf("failed to render test QR code: %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(pngBytes))
+			if err != nil {
+				t.Fatalf("failed to decode PNG: %v", err)
+			}
+
+			// 440px is not one pixel per module, so DetectQRVersion cannot help
+			if got, err := LocateQRVersion(img); err != nil || got != want {
+				t.Errorf("LocateQRVersion() = %d, %v, want %d", got, err, want)
+			}
+		})
+	}
+}
+
 func TestCalculateModuleCount(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -60,6 +145,64 @@ func TestCalculateModuleCount(t *testing.T) {
 	}
 }
 
+func TestCalculateMicroModuleCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int
+		expected int
+	}{
+		{"M1", 1, 11},
+		{"M2", 2, 13},
+		{"M3", 3, 15},
+		{"M4", 4, 17},
+		{"invalid version 0", 0, 0},
+		{"invalid version 5", 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CalculateMicroModuleCount(tt.version)
+			if result != tt.expected {
+				t.Errorf("CalculateMicroModuleCount(%d) = %d, expected %d",
+					tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectMicroQRVersion(t *testing.T) {
+	t.Run("nil image", func(t *testing.T) {
+		version, err := DetectMicroQRVersion(nil)
+		if err == nil {
+			t.Fatal("expected error for nil image")
+		}
+		if version != -1 {
+			t.Errorf("expected version -1, got %d", version)
+		}
+	})
+
+	for _, dimension := range []int{11, 13, 15, 17} {
+		img := image.NewGray(image.Rect(0, 0, dimension, dimension))
+
+		version, err := DetectMicroQRVersion(img)
+		if err != nil {
+			t.Fatalf("DetectMicroQRVersion(%dx%d) error = %v", dimension, dimension, err)
+		}
+		if CalculateMicroModuleCount(version) != dimension {
+			t.Errorf("DetectMicroQRVersion(%dx%d) = M%d, module count %d",
+				dimension, dimension, version, CalculateMicroModuleCount(version))
+		}
+	}
+
+	t.Run("standard QR dimension", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 21, 21))
+
+		if _, err := DetectMicroQRVersion(img); err == nil {
+			t.Error("expected error for 21-module standard QR dimension")
+		}
+	})
+}
+
 func TestCalculateModulePixelSize(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -223,6 +366,79 @@ func TestCalculateOptimalPixelSize(t *testing.T) {
 	}
 }
 
+func TestIntegerPixelSizes(t *testing.T) {
+	// Version 15: 77 modules + 4 quiet zone = 81
+	got := IntegerPixelSizes(77, 4, 320, 560)
+	want := []int{324, 405, 486}
+
+	if len(got) != len(want) {
+		t.Fatalf("IntegerPixelSizes(77, 4, 320, 560) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("IntegerPixelSizes(77, 4, 320, 560)[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+
+	if sizes := IntegerPixelSizes(0, 4, 320, 560); sizes != nil {
+		t.Errorf("IntegerPixelSizes with zero module count = %v, want nil", sizes)
+	}
+}
+
+func TestFractionalPixelRanges(t *testing.T) {
+	t.Run("version 15 brackets 440 and 450", func(t *testing.T) {
+		moduleCount := CalculateModuleCount(15)
+		ranges := FractionalPixelRanges(moduleCount, QuietZoneModules, 320, 560)
+
+		want := []PixelRange{
+			{Min: 320, Max: 323},
+			{Min: 325, Max: 404},
+			{Min: 406, Max: 485},
+			{Min: 487, Max: 560},
+		}
+		if len(ranges) != len(want) {
+			t.Fatalf("FractionalPixelRanges() = %v, want %v", ranges, want)
+		}
+		for i := range want {
+			if ranges[i] != want[i] {
+				t.Errorf("FractionalPixelRanges()[%d] = %v, want %v", i, ranges[i], want[i])
+			}
+		}
+
+		// The known-problematic sizes must fall in the same fractional range,
+		// bounded by the integer sizes 405 (5px/module) and 486 (6px/module)
+		for _, pixelSize := range []int{440, 450} {
+			if pixelSize < ranges[2].Min || pixelSize > ranges[2].Max {
+				t.Errorf("%dpx not within fractional range %v", pixelSize, ranges[2])
+			}
+			if !IsFractionalModuleSize(CalculateModulePixelSize(pixelSize, moduleCount, QuietZoneModules)) {
+				t.Errorf("%dpx should produce a fractional module size", pixelSize)
+			}
+		}
+	})
+
+	t.Run("range starts and ends on integer sizes", func(t *testing.T) {
+		// Version 1: 21 + 4 = 25
+		ranges := FractionalPixelRanges(21, 4, 100, 150)
+		want := []PixelRange{{Min: 101, Max: 124}, {Min: 126, Max: 149}}
+
+		if len(ranges) != len(want) {
+			t.Fatalf("FractionalPixelRanges() = %v, want %v", ranges, want)
+		}
+		for i := range want {
+			if ranges[i] != want[i] {
+				t.Errorf("FractionalPixelRanges()[%d] = %v, want %v", i, ranges[i], want[i])
+			}
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		if ranges := FractionalPixelRanges(21, 4, 500, 100); ranges != nil {
+			t.Errorf("FractionalPixelRanges with inverted range = %v, want nil", ranges)
+		}
+	})
+}
+
 // floatEqual compares two floating point numbers within epsilon tolerance.
 func floatEqual(a, b, epsilon float64) bool {
 	if a == b {
@@ -234,3 +450,81 @@ func floatEqual(a, b, epsilon float64) bool {
 	}
 	return diff < epsilon
 }
+
+func TestFilterByModulePixelSize(t *testing.T) {
+	cases := GeneratePixelSizeMatrix()
+
+	filtered := FilterByModulePixelSize(cases, 5.0, 5.5)
+
+	// Predicted versions: alphanumeric 100b-H = v8 (49 modules), 300b-H,
+	// 750b-L and UTF-8 500b-L = v15 (77), alphanumeric 500b-L = v12 (65)
+	want := []string{
+		"alphanumeric-100b-270px-ecH", // 270 / 53 = 5.09
+		"alphanumeric-300b-445px-ecH", // 445 / 81 = 5.49
+		"alphanumeric-500b-360px-ecL", // 360 / 69 = 5.22
+		"utf8-500b-445px-ecL",         // 445 / 81 = 5.49
+		"alphanumeric-750b-445px-ecL", // 445 / 81 = 5.49
+	}
+
+	if len(filtered) != len(want) {
+		names := make([]string, len(filtered))
+		for i, tc := range filtered {
+			names[i] = tc.Name
+		}
+		t.Fatalf("FilterByModulePixelSize() kept %d cases %v, want %d", len(filtered), names, len(want))
+	}
+	for i, tc := range filtered {
+		if tc.Name != want[i] {
+			t.Errorf("Case %d = %q, want %q", i, tc.Name, want[i])
+		}
+	}
+
+	if got := FilterByModulePixelSize(cases, 0, 0); len(got) != len(cases) {
+		t.Errorf("FilterByModulePixelSize() with no band kept %d cases, want %d", len(got), len(cases))
+	}
+
+	// A minimum alone is open-ended above
+	for _, tc := range FilterByModulePixelSize(cases, 8.0, 0) {
+		if px, _ := PredictModulePixelSize(tc); px < 8.0 {
+			t.Errorf("Case %q predicted %.2f px/module, want >= 8.0", tc.Name, px)
+		}
+	}
+}
+
+func TestFilterByModuleSizeType(t *testing.T) {
+	cases := GenerateComprehensiveMatrix()
+
+	integer := FilterByModuleSizeType(cases, ModuleSizeInteger)
+	fractional := FilterByModuleSizeType(cases, ModuleSizeFractional)
+	if len(integer) == 0 || len(fractional) == 0 {
+		t.Fatalf("Kept %d integer and %d fractional cases, want some of each", len(integer), len(fractional))
+	}
+
+	for _, tc := range integer {
+		if px, _ := PredictModulePixelSize(tc); IsFractionalModuleSize(px) {
+			t.Errorf("Integer filter kept %q at %.2f px/module", tc.Name, px)
+		}
+	}
+	for _, tc := range fractional {
+		if px, _ := PredictModulePixelSize(tc); !IsFractionalModuleSize(px) {
+			t.Errorf("Fractional filter kept %q at %.2f px/module", tc.Name, px)
+		}
+	}
+
+	// Together the halves cover every case with an estimable version
+	estimable := 0
+	for _, tc := range cases {
+		if _, err := PredictModulePixelSize(tc); err == nil {
+			estimable++
+		}
+	}
+	if len(integer)+len(fractional) != estimable {
+		t.Errorf("Integer + fractional = %d cases, want %d", len(integer)+len(fractional), estimable)
+	}
+
+	for _, sizeType := range []string{ModuleSizeAll, ""} {
+		if got := FilterByModuleSizeType(cases, sizeType); len(got) != len(cases) {
+			t.Errorf("FilterByModuleSizeType(%q) kept %d cases, want %d", sizeType, len(got), len(cases))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package testdata
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// OverlayScale is how many times RenderModuleOverlay magnifies an image.
+const OverlayScale = 4
+
+// overlayGridColor is the color of the module grid lines.
+var overlayGridColor = color.RGBA{R: 255, A: 255}
+
+// RenderModuleOverlay magnifies img OverlayScale times with nearest-neighbor
+// sampling and draws a one-pixel grid at the module boundaries, located the
+// same way ExtractModuleGrid locates them: the bounding box of dark pixels
+// divided into moduleCount modules per side.
+//
+// With fractional module sizes the grid drifts across the rendered module
+// edges, which shows where a decoder sampling at the same positions reads
+// the wrong module. Without dark pixels or a positive moduleCount only the
+// magnified image is returned.
+func RenderModuleOverlay(img image.Image, moduleCount int) image.Image {
+	b := img.Bounds()
+
+	// Flatten transparent encoders onto white before magnifying
+	flat := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, b.Min, draw.Over)
+
+	out := image.NewRGBA(image.Rect(0, 0, b.Dx()*OverlayScale, b.Dy()*OverlayScale))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			block := image.Rect(x*OverlayScale, y*OverlayScale, (x+1)*OverlayScale, (y+1)*OverlayScale)
+			draw.Draw(out, block, image.NewUniform(flat.At(x, y)), image.Point{}, draw.Src)
+		}
+	}
+
+	if moduleCount <= 0 {
+		return out
+	}
+	symbol, ok := darkBounds(img)
+	if !ok {
+		return out
+	}
+	symbol = symbol.Sub(b.Min)
+
+	moduleWidth := float64(symbol.Dx()) / float64(moduleCount)
+	moduleHeight := float64(symbol.Dy()) / float64(moduleCount)
+	// Lines span the symbol up to and including the closing boundaries
+	top := symbol.Min.Y * OverlayScale
+	bottom := gridLine(float64(symbol.Max.Y), out.Bounds().Dy())
+	left := symbol.Min.X * OverlayScale
+	right := gridLine(float64(symbol.Max.X), out.Bounds().Dx())
+
+	for i := 0; i <= moduleCount; i++ {
+		x := gridLine(float64(symbol.Min.X)+float64(i)*moduleWidth, out.Bounds().Dx())
+		y := gridLine(float64(symbol.Min.Y)+float64(i)*moduleHeight, out.Bounds().Dy())
+		for py := top; py <= bottom; py++ {
+			out.SetRGBA(x, py, overlayGridColor)
+		}
+		for px := left; px <= right; px++ {
+			out.SetRGBA(px, y, overlayGridColor)
+		}
+	}
+
+	return out
+}
+
+// gridLine converts a boundary in source pixels to a magnified pixel
+// coordinate, clamped so the closing boundary stays inside the image.
+func gridLine(pos float64, size int) int {
+	return min(int(math.Round(pos*OverlayScale)), size-1)
+}
@@ -0,0 +1,68 @@
+package testdata
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// redPixels counts grid-colored pixels in row y of img.
+func redPixels(img image.Image, y int) int {
+	n := 0
+	b := img.Bounds()
+	for x := b.Min.X; x < b.Max.X; x++ {
+		if r, g, bl, _ := img.At(x, y).RGBA(); r == 0xFFFF && g == 0 && bl == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRenderModuleOverlay(t *testing.T) {
+	// "HELLO" fits version 1 (21 modules); 250px gives fractional modules
+	pngBytes, err := qrcode.Encode("HELLO", qrcode.Medium, 250)
+	if err != nil {
+		t.Fatalf("Failed to generate test QR code: %v", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	overlay := RenderModuleOverlay(img, 21)
+
+	want := 250 * OverlayScale
+	if b := overlay.Bounds(); b.Dx() != want || b.Dy() != want {
+		t.Fatalf("Overlay size = %v, want %dx%d", b, want, want)
+	}
+
+	symbol, _ := darkBounds(img)
+	top := symbol.Min.Y * OverlayScale
+	span := symbol.Dx()*OverlayScale + 1 // through the closing boundary
+
+	// The top boundary is a full grid line; a row inside the first module
+	// only crosses the vertical lines
+	if got := redPixels(overlay, top); got != span {
+		t.Errorf("Grid line row has %d red pixels, want %d", got, span)
+	}
+	if got := redPixels(overlay, top+2); got != 22 {
+		t.Errorf("Row inside a module has %d red pixels, want 22 (one per vertical line)", got)
+	}
+}
+
+func TestRenderModuleOverlay_NoSymbol(t *testing.T) {
+	overlay := RenderModuleOverlay(image.NewGray(image.Rect(0, 0, 10, 10)), 21)
+	if b := overlay.Bounds(); b.Dx() != 40 || b.Dy() != 40 {
+		t.Errorf("Overlay size = %v, want 40x40", b)
+	}
+
+	blank := image.NewGray(image.Rect(0, 0, 10, 10))
+	for i := range blank.Pix {
+		blank.Pix[i] = 0xFF
+	}
+	if got := redPixels(RenderModuleOverlay(blank, 21), 0); got != 0 {
+		t.Errorf("Blank image overlay has %d red pixels, want no grid", got)
+	}
+}
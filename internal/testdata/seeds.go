@@ -0,0 +1,33 @@
+package testdata
+
+import "fmt"
+
+// ExpandSeeds repeats each single-symbol binary test case across n seeds,
+// regenerating its Data with BinaryData for seeds DefaultBinarySeed through
+// DefaultBinarySeed+n-1. Other cases are returned unchanged. Used to measure
+// whether a decoder's success depends on the random payload.
+//
+// With n <= 1 the cases are returned as is.
+func ExpandSeeds(cases []TestCase, n int) []TestCase {
+	if n <= 1 {
+		return cases
+	}
+
+	expanded := make([]TestCase, 0, len(cases))
+	for _, tc := range cases {
+		if tc.ContentType != ContentBinary || tc.IsMultiSymbol() {
+			expanded = append(expanded, tc)
+			continue
+		}
+
+		for i := 0; i < n; i++ {
+			seed := int64(DefaultBinarySeed + i)
+			swept := tc
+			swept.Name = fmt.Sprintf("%s-seed%d", tc.Name, seed)
+			swept.Data = BinaryData(tc.DataSize, seed)
+			swept.Seed = seed
+			expanded = append(expanded, swept)
+		}
+	}
+	return expanded
+}
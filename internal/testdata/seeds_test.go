@@ -0,0 +1,47 @@
+package testdata
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExpandSeeds(t *testing.T) {
+	cases := []TestCase{
+		{Name: "binary-100b-400px", Data: generateBinary(100), DataSize: 100, PixelSize: 400, ContentType: ContentBinary},
+		{Name: "numeric-100b-400px", Data: generateNumeric(100), DataSize: 100, PixelSize: 400, ContentType: ContentNumeric},
+	}
+
+	expanded := ExpandSeeds(cases, 3)
+	if len(expanded) != 4 {
+		t.Fatalf("ExpandSeeds() returned %d cases, want 4 (3 binary + 1 numeric)", len(expanded))
+	}
+
+	for i, tc := range expanded[:3] {
+		seed := int64(DefaultBinarySeed + i)
+		if tc.Seed != seed {
+			t.Errorf("Case %d seed = %d, want %d", i, tc.Seed, seed)
+		}
+		if !bytes.Equal(tc.Data, BinaryData(100, seed)) {
+			t.Errorf("Case %d data does not match BinaryData(100, %d)", i, seed)
+		}
+	}
+
+	// The first seed reproduces the default payload
+	if !bytes.Equal(expanded[0].Data, cases[0].Data) {
+		t.Error("First seed should reproduce the default binary payload")
+	}
+	if bytes.Equal(expanded[0].Data, expanded[1].Data) {
+		t.Error("Different seeds should produce different payloads")
+	}
+	if expanded[1].Name != "binary-100b-400px-seed43" {
+		t.Errorf("Case name = %q, want %q", expanded[1].Name, "binary-100b-400px-seed43")
+	}
+
+	if expanded[3].ContentType != ContentNumeric || expanded[3].Seed != 0 {
+		t.Errorf("Non-binary case should be unchanged, got %+v", expanded[3])
+	}
+
+	if got := ExpandSeeds(cases, 1); len(got) != len(cases) {
+		t.Errorf("ExpandSeeds(n=1) returned %d cases, want %d", len(got), len(cases))
+	}
+}
@@ -0,0 +1,88 @@
+package testdata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseSingleSpec builds one test case from a comma-separated key=value
+// spec such as "dataSize=550,pixelSize=440,content=alphanumeric,level=M",
+// for reproducing a single matrix cell. dataSize, pixelSize, and content
+// are required; level defaults to M. The payload is generated as the
+// matrix generators would for the same content type and size.
+//
+// Returns an error for unknown or repeated keys and invalid values.
+func ParseSingleSpec(spec string) (TestCase, error) {
+	values := make(map[string]string)
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return TestCase{}, fmt.Errorf("invalid field %q: want key=value", field)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "dataSize", "pixelSize", "content", "level":
+		default:
+			return TestCase{}, fmt.Errorf("unknown key %q: must be dataSize, pixelSize, content, or level", key)
+		}
+		if _, dup := values[key]; dup {
+			return TestCase{}, fmt.Errorf("key %q given more than once", key)
+		}
+		values[key] = value
+	}
+
+	for _, key := range []string{"dataSize", "pixelSize", "content"} {
+		if _, ok := values[key]; !ok {
+			return TestCase{}, fmt.Errorf("missing required key %q", key)
+		}
+	}
+
+	dataSize, err := strconv.Atoi(values["dataSize"])
+	if err != nil || dataSize <= 0 {
+		return TestCase{}, fmt.Errorf("dataSize must be a positive integer, got %q", values["dataSize"])
+	}
+	pixelSize, err := strconv.Atoi(values["pixelSize"])
+	if err != nil || pixelSize <= 0 {
+		return TestCase{}, fmt.Errorf("pixelSize must be a positive integer, got %q", values["pixelSize"])
+	}
+
+	contentType, ok := vectorContentTypes[values["content"]]
+	if !ok {
+		return TestCase{}, fmt.Errorf("invalid content %q: must be numeric, alphanumeric, binary, or utf8", values["content"])
+	}
+
+	level := values["level"]
+	switch level {
+	case "":
+		level = "M"
+	case "L", "M", "Q", "H":
+	default:
+		return TestCase{}, fmt.Errorf("invalid level %q: must be L, M, Q, or H", level)
+	}
+
+	var data []byte
+	switch contentType {
+	case ContentNumeric:
+		data = generateNumeric(dataSize)
+	case ContentAlphanumeric:
+		data = generateAlphanumeric(dataSize)
+	case ContentBinary:
+		data = generateBinary(dataSize)
+	case ContentUTF8:
+		data = generateUTF8(dataSize)
+	}
+
+	return TestCase{
+		Name:                 formatTestNameWithEC(values["content"], dataSize, pixelSize, level),
+		Data:                 data,
+		DataSize:             dataSize,
+		PixelSize:            pixelSize,
+		ContentType:          contentType,
+		ErrorCorrectionLevel: level,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSingleSpec(t *testing.T) {
+	tc, err := ParseSingleSpec("dataSize=550,pixelSize=440,content=alphanumeric,level=Q")
+	if err != nil {
+		t.Fatalf("ParseSingleSpec() failed: %v", err)
+	}
+	if tc.DataSize != 550 || len(tc.Data) != 550 || tc.PixelSize != 440 ||
+		tc.ContentType != ContentAlphanumeric || tc.ErrorCorrectionLevel != "Q" {
+		t.Errorf("ParseSingleSpec() = %s (%d bytes, %dpx, content %d, level %s), want 550 alphanumeric bytes at 440px, level Q",
+			tc.Name, len(tc.Data), tc.PixelSize, tc.ContentType, tc.ErrorCorrectionLevel)
+	}
+	if tc.Name != "alphanumeric-550b-440px-ecQ" {
+		t.Errorf("Name = %q, want %q", tc.Name, "alphanumeric-550b-440px-ecQ")
+	}
+
+	// The payload matches the matrix generators' for the same cell
+	if string(tc.Data) != string(generateAlphanumeric(550)) {
+		t.Error("Data differs from the generated alphanumeric payload")
+	}
+
+	// Level defaults to M; order and spacing do not matter
+	tc, err = ParseSingleSpec(" content=utf8 , pixelSize=320,dataSize=100")
+	if err != nil {
+		t.Fatalf("ParseSingleSpec() without level failed: %v", err)
+	}
+	if tc.ErrorCorrectionLevel != "M" || tc.ContentType != ContentUTF8 {
+		t.Errorf("ParseSingleSpec() = level %s, content %d; want M and utf8", tc.ErrorCorrectionLevel, tc.ContentType)
+	}
+}
+
+func TestParseSingleSpec_Invalid(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{"dataSize=550,pixelSize=440,content=alphanumeric,ec=M", "unknown key"},
+		{"dataSize=550,pixelSize=440", "missing required key \"content\""},
+		{"dataSize=550,dataSize=600,pixelSize=440,content=binary", "more than once"},
+		{"dataSize=big,pixelSize=440,content=binary", "dataSize must be a positive integer"},
+		{"dataSize=550,pixelSize=0,content=binary", "pixelSize must be a positive integer"},
+		{"dataSize=550,pixelSize=440,content=kanji", "invalid content"},
+		{"dataSize=550,pixelSize=440,content=binary,level=X", "invalid level"},
+		{"dataSize=550,pixelSize", "want key=value"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseSingleSpec(tt.spec)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ParseSingleSpec(%q) error = %v, want it to contain %q", tt.spec, err, tt.wantErr)
+		}
+	}
+}
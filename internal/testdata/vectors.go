@@ -0,0 +1,109 @@
+package testdata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"unicode/utf8"
+)
+
+// vector is one entry of a JSON test-vector file.
+type vector struct {
+	Name                 string `json:"name"`
+	DataBase64           string `json:"dataBase64"`
+	PixelSize            int    `json:"pixelSize"`
+	ContentType          string `json:"contentType"`          // "numeric", "alphanumeric", "binary", or "utf8"
+	ErrorCorrectionLevel string `json:"errorCorrectionLevel"` // optional, defaults to "M"
+}
+
+// vectorContentTypes maps vector file content type names to ContentType.
+var vectorContentTypes = map[string]ContentType{
+	"numeric":      ContentNumeric,
+	"alphanumeric": ContentAlphanumeric,
+	"binary":       ContentBinary,
+	"utf8":         ContentUTF8,
+}
+
+// LoadVectors reads test cases from a JSON test-vector file: an array of
+// {name, dataBase64, pixelSize, contentType} objects with an optional
+// errorCorrectionLevel (default "M"). This lets a canonical payload corpus
+// be committed and tested independently of the generators.
+//
+// Every entry is validated; the error names the entry that failed.
+func LoadVectors(path string) ([]TestCase, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("vectors: %w", err)
+	}
+
+	var vectors []vector
+	if err := json.Unmarshal(content, &vectors); err != nil {
+		return nil, fmt.Errorf("vectors: parsing %s: %w", path, err)
+	}
+
+	if len(vectors) == 0 {
+		return nil, fmt.Errorf("vectors: %s has no entries", path)
+	}
+
+	names := make(map[string]bool)
+	cases := make([]TestCase, 0, len(vectors))
+	for i, v := range vectors {
+		tc, err := v.testCase()
+		if err != nil {
+			return nil, fmt.Errorf("vectors: %s: entry %d (%q): %w", path, i, v.Name, err)
+		}
+		if names[tc.Name] {
+			return nil, fmt.Errorf("vectors: %s: entry %d: duplicate name %q", path, i, tc.Name)
+		}
+		names[tc.Name] = true
+		cases = append(cases, tc)
+	}
+
+	return cases, nil
+}
+
+// testCase validates the vector and converts it to a TestCase.
+func (v vector) testCase() (TestCase, error) {
+	if v.Name == "" {
+		return TestCase{}, fmt.Errorf("name is required")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(v.DataBase64)
+	if err != nil {
+		return TestCase{}, fmt.Errorf("invalid dataBase64: %w", err)
+	}
+	if len(data) == 0 {
+		return TestCase{}, fmt.Errorf("dataBase64 decodes to no data")
+	}
+
+	if v.PixelSize <= 0 {
+		return TestCase{}, fmt.Errorf("pixelSize must be positive, got %d", v.PixelSize)
+	}
+
+	contentType, ok := vectorContentTypes[v.ContentType]
+	if !ok {
+		return TestCase{}, fmt.Errorf("invalid contentType %q: must be numeric, alphanumeric, binary, or utf8", v.ContentType)
+	}
+	if contentType == ContentUTF8 && !utf8.Valid(data) {
+		return TestCase{}, fmt.Errorf("contentType utf8 but data is not valid UTF-8")
+	}
+
+	ecLevel := v.ErrorCorrectionLevel
+	switch ecLevel {
+	case "":
+		ecLevel = "M"
+	case "L", "M", "Q", "H":
+	default:
+		return TestCase{}, fmt.Errorf("invalid errorCorrectionLevel %q: must be L, M, Q, or H", ecLevel)
+	}
+
+	return TestCase{
+		Name:                 v.Name,
+		Data:                 data,
+		DataSize:             len(data),
+		PixelSize:            v.PixelSize,
+		ContentType:          contentType,
+		ErrorCorrectionLevel: ecLevel,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package testdata
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadVectors(t *testing.T) {
+	cases, err := LoadVectors("testdata/vectors.json")
+	if err != nil {
+		t.Fatalf("LoadVectors() failed: %v", err)
+	}
+
+	expected := []struct {
+		name        string
+		data        []byte
+		pixelSize   int
+		contentType ContentType
+		ecLevel     string
+	}{
+		{"numeric-digits", []byte("0123456789"), 320, ContentNumeric, "M"},
+		{"alphanumeric-hello", []byte("HELLO WORLD"), 400, ContentAlphanumeric, "H"},
+		{"binary-nul-ff", []byte{0x00, 0x01, 0x02, 0xFE, 0xFF}, 440, ContentBinary, "L"},
+		{"utf8-cafe", []byte("Café 世界"), 480, ContentUTF8, "Q"},
+	}
+
+	if len(cases) != len(expected) {
+		t.Fatalf("LoadVectors() returned %d cases, want %d", len(cases), len(expected))
+	}
+
+	for i, want := range expected {
+		tc := cases[i]
+		if tc.Name != want.name {
+			t.Errorf("Case %d name = %q, want %q", i, tc.Name, want.name)
+		}
+		if !bytes.Equal(tc.Data, want.data) {
+			t.Errorf("Case %q data = %v, want %v", want.name, tc.Data, want.data)
+		}
+		if tc.DataSize != len(want.data) {
+			t.Errorf("Case %q data size = %d, want %d", want.name, tc.DataSize, len(want.data))
+		}
+		if tc.PixelSize != want.pixelSize {
+			t.Errorf("Case %q pixel size = %d, want %d", want.name, tc.PixelSize, want.pixelSize)
+		}
+		if tc.ContentType != want.contentType {
+			t.Errorf("Case %q content type = %v, want %v", want.name, tc.ContentType, want.contentType)
+		}
+		if tc.ErrorCorrectionLevel != want.ecLevel {
+			t.Errorf("Case %q EC level = %q, want %q", want.name, tc.ErrorCorrectionLevel, want.ecLevel)
+		}
+	}
+}
+
+func TestLoadVectors_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"malformed base64", `[{"name": "bad", "dataBase64": "not base64!", "pixelSize": 320, "contentType": "binary"}]`, `entry 0 ("bad"): invalid dataBase64`},
+		{"missing name", `[{"dataBase64": "AA==", "pixelSize": 320, "contentType": "binary"}]`, "name is required"},
+		{"zero pixel size", `[{"name": "a", "dataBase64": "AA==", "contentType": "binary"}]`, "pixelSize must be positive"},
+		{"unknown content type", `[{"name": "a", "dataBase64": "AA==", "pixelSize": 320, "contentType": "kanji"}]`, `invalid contentType "kanji"`},
+		{"invalid utf8", `[{"name": "a", "dataBase64": "/w==", "pixelSize": 320, "contentType": "utf8"}]`, "not valid UTF-8"},
+		{"invalid EC level", `[{"name": "a", "dataBase64": "AA==", "pixelSize": 320, "contentType": "binary", "errorCorrectionLevel": "X"}]`, "invalid errorCorrectionLevel"},
+		{"duplicate name", `[{"name": "a", "dataBase64": "AA==", "pixelSize": 320, "contentType": "binary"}, {"name": "a", "dataBase64": "AA==", "pixelSize": 400, "contentType": "binary"}]`, `entry 1: duplicate name "a"`},
+		{"empty", `[]`, "has no entries"},
+		{"not JSON", `{`, "parsing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "vectors.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadVectors(path)
+			if err == nil {
+				t.Fatal("LoadVectors() should fail")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadVectors() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,1182 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+// CombinationRate summarizes the results of one encoder/decoder pair.
+type CombinationRate struct {
+	Encoder        string
+	Decoder        string
+	Tests          int
+	Successes      int
+	CapacitySkips  int
+	EffectiveTests int // Tests - CapacitySkips - UnsupportedSkips - UndersizedSkips
+
+	// UnsupportedSkips counts tests skipped because the decoder cannot
+	// return the content type intact (text-only decoders on binary data).
+	UnsupportedSkips int
+
+	// UndersizedSkips counts tests whose decode was skipped because the
+	// pixel size left too few pixels per module.
+	UndersizedSkips int
+
+	// SuccessRate is the percentage of effective tests that succeeded (0-100).
+	SuccessRate float64
+
+	// Encoded counts tests where the encoder produced an image.
+	Encoded int
+
+	// EncodeRate is the percentage of effective tests that encoded (0-100).
+	EncodeRate float64
+
+	// DecodeRate is the percentage of encoded tests that decoded correctly
+	// (0-100). It leaves encoder failures out, so it compares decoders only.
+	DecodeRate float64
+}
+
+// FractionalAnalysis compares failures at fractional and integer module pixel sizes.
+// Capacity-exceeded results are excluded.
+type FractionalAnalysis struct {
+	FractionalTests    int
+	FractionalFailures int
+	IntegerTests       int
+	IntegerFailures    int
+
+	// BySize breaks the tests down by module pixel size, smallest first.
+	BySize []ModuleSizeRate
+
+	// Unavailable counts tests with neither a module pixel size nor a QR
+	// version, which are left out of the comparison.
+	Unavailable int
+}
+
+// ModuleSizeRate is the failure count at one module pixel size.
+type ModuleSizeRate struct {
+	ModulePixelSize float64
+	Fractional      bool
+	Tests           int
+	Failures        int
+}
+
+// moduleSize returns the module pixel size of r: the one the runner
+// recorded, else one computed from the encoder-reported QR version with a
+// standard quiet zone. ok is false when r has neither, so the module
+// analysis populates from the encode side without image-based detection.
+func moduleSize(r RawTestResult) (size float64, ok bool) {
+	if r.ModulePixelSize > 0 {
+		return r.ModulePixelSize, true
+	}
+	if r.QRVersion <= 0 {
+		return 0, false
+	}
+
+	moduleCount, quietZone := r.ModuleCount, testdata.QuietZoneModules
+	if r.IsMicroQR {
+		quietZone = testdata.MicroQuietZoneModules
+	}
+	if moduleCount <= 0 {
+		moduleCount = testdata.CalculateModuleCount(r.QRVersion)
+		if r.IsMicroQR {
+			moduleCount = testdata.CalculateMicroModuleCount(r.QRVersion)
+		}
+	}
+
+	size = testdata.CalculateModulePixelSize(r.PixelSize, moduleCount, quietZone)
+	return size, size > 0
+}
+
+// isFractional reports whether r has a known, fractional module pixel size.
+func isFractional(r RawTestResult) bool {
+	if r.IsFractionalModule {
+		return true
+	}
+	size, ok := moduleSize(r)
+	return ok && testdata.IsFractionalModuleSize(size)
+}
+
+// FractionalFailureRate returns the failure percentage at fractional module sizes.
+func (f FractionalAnalysis) FractionalFailureRate() float64 {
+	return percent(f.FractionalFailures, f.FractionalTests)
+}
+
+// IntegerFailureRate returns the failure percentage at integer module sizes.
+func (f FractionalAnalysis) IntegerFailureRate() float64 {
+	return percent(f.IntegerFailures, f.IntegerTests)
+}
+
+// NonMonotonicCase records a pixel size that fails to decode even though a
+// smaller pixel size succeeds for the same encoder, decoder, and input.
+// Larger images are expected to be at least as decodable as smaller ones.
+type NonMonotonicCase struct {
+	Encoder              string
+	Decoder              string
+	ContentType          string
+	ErrorCorrectionLevel string
+	DataSize             int
+
+	// PassingPixelSize is the largest smaller pixel size that succeeded.
+	PassingPixelSize int
+
+	// FailingPixelSize is the pixel size that failed.
+	FailingPixelSize int
+}
+
+// LengthDrift compares decoded and expected byte lengths for one decoder and
+// content type. Only results where the decoder returned data are counted.
+type LengthDrift struct {
+	Decoder     string
+	ContentType string
+
+	// Decodes is the number of completed decodes.
+	Decodes int
+
+	// Drifted is the number of decodes whose length differed from the input.
+	Drifted int
+
+	// MinDelta and MaxDelta bound decoded minus expected bytes across all decodes.
+	MinDelta int
+	MaxDelta int
+
+	// CharsetMismatches counts data mismatches that were the input read
+	// with the wrong character set rather than corrupted, which makes the
+	// decoder charset-sensitive for this content type.
+	CharsetMismatches int
+}
+
+// Analysis holds the findings computed from a set of test results.
+type Analysis struct {
+	TotalTests     int
+	Successes      int
+	CapacitySkips  int
+	EffectiveTests int
+
+	// UnsupportedSkips counts tests skipped because the decoder cannot
+	// return the content type intact; they are not capacity skips.
+	UnsupportedSkips int
+
+	// UndersizedSkips counts tests whose pixel size left too few pixels
+	// per module to decode: a configuration problem, not a decoder failure.
+	UndersizedSkips int
+
+	// Encoded counts tests where the encoder produced an image; Successes
+	// over Encoded is the decode rate conditioned on a successful encode.
+	Encoded int
+
+	// Combinations lists every encoder/decoder pair, worst success rate first.
+	Combinations []CombinationRate
+
+	// Worst is the pair with the lowest success rate.
+	// Zero value when no pair has effective tests.
+	Worst CombinationRate
+
+	// Best is the pair with the highest success rate.
+	// Zero value when no pair has effective tests.
+	Best CombinationRate
+
+	// FailuresByType counts failed, non-capacity results by ErrorType
+	// ("encode", "decode", "dataMismatch", ...).
+	FailuresByType map[string]int
+
+	// Patterns lists encoder/decoder pairs with failures, most failures first.
+	Patterns []matrix.IncompatibilityPattern
+
+	Fractional FractionalAnalysis
+
+	NonMonotonic []NonMonotonicCase
+
+	// LengthDrift lists decoded vs expected byte lengths per decoder and content type.
+	LengthDrift []LengthDrift
+
+	// VersionSelection lists the QR version each encoder chose per data size,
+	// content type, and EC level.
+	VersionSelection []VersionSelection
+
+	// AvgVersion is each encoder's mean QR version per encoded image.
+	AvgVersion map[string]float64
+
+	// FirstFailing lists, per decoder and pixel size, the smallest QR version
+	// the decoder failed to read: a quick capability ceiling.
+	FirstFailing []FirstFailingVersion
+
+	// ByVersion groups tests by QR version, smallest first, since module
+	// count (17+4v) drives the fractional-module failures.
+	ByVersion []VersionRate
+
+	// WorstVersion is the version with the highest failure rate.
+	// Zero value when no result has a QR version.
+	WorstVersion VersionRate
+
+	// ByMask groups encoded standard QR tests by the data mask pattern the
+	// encoder chose, mask 0 first, since some masks produce module patterns
+	// binarizers misread at fractional sizes.
+	ByMask []MaskRate
+
+	// DecodeOutliers lists results flagged as decode-time outliers for
+	// their encoder/decoder pair, slowest first.
+	DecodeOutliers []DecodeOutlier
+
+	// VersionMismatches counts results where the encoder-reported version
+	// differs from the version detected in the image.
+	VersionMismatches int
+
+	// ExcludedDecoders lists decoders left out of the headline counts,
+	// Best, and Worst. Their results still appear in Combinations and Patterns.
+	ExcludedDecoders []string
+
+	// ExcludedTests is the number of results from ExcludedDecoders.
+	ExcludedTests int
+}
+
+// Analyze computes combination, failure pattern, fractional, and
+// non-monotonic findings from raw test results.
+func Analyze(results []RawTestResult) Analysis {
+	return AnalyzeExcluding(results, nil)
+}
+
+// AnalyzeExcluding is like Analyze, but results from decoders in excluded
+// are tracked only as ExcludedTests: they do not count toward the headline
+// totals, success rate, failure counts, Best, or Worst.
+func AnalyzeExcluding(results []RawTestResult, excluded map[string]bool) Analysis {
+	a := Analysis{FailuresByType: make(map[string]int)}
+	bySize := make(map[float64]*ModuleSizeRate)
+
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if excluded[r.Decoder] {
+			a.ExcludedTests++
+			if !seen[r.Decoder] {
+				seen[r.Decoder] = true
+				a.ExcludedDecoders = append(a.ExcludedDecoders, r.Decoder)
+			}
+			continue
+		}
+
+		a.TotalTests++
+		if r.Success {
+			a.Successes++
+		}
+		if r.Encoded() {
+			a.Encoded++
+		}
+		if r.VersionMismatch {
+			a.VersionMismatches++
+		}
+		if r.IsUnsupported() {
+			a.UnsupportedSkips++
+			continue
+		}
+		if r.IsUndersized() {
+			a.UndersizedSkips++
+			continue
+		}
+		if r.IsCapacityExceeded {
+			a.CapacitySkips++
+			continue
+		}
+		if !r.Success {
+			a.FailuresByType[r.ErrorType]++
+		}
+
+		size, ok := moduleSize(r)
+		if !ok {
+			a.Fractional.Unavailable++
+			continue
+		}
+		// Round away float noise so equal sizes share a row
+		size = math.Round(size*1e4) / 1e4
+		fractional := isFractional(r)
+
+		bucket := bySize[size]
+		if bucket == nil {
+			bucket = &ModuleSizeRate{ModulePixelSize: size, Fractional: fractional}
+			bySize[size] = bucket
+		}
+		bucket.Tests++
+		if fractional {
+			a.Fractional.FractionalTests++
+		} else {
+			a.Fractional.IntegerTests++
+		}
+		if !r.Success {
+			bucket.Failures++
+			if fractional {
+				a.Fractional.FractionalFailures++
+			} else {
+				a.Fractional.IntegerFailures++
+			}
+		}
+	}
+	a.EffectiveTests = a.TotalTests - a.CapacitySkips - a.UnsupportedSkips - a.UndersizedSkips
+
+	for _, bucket := range bySize {
+		a.Fractional.BySize = append(a.Fractional.BySize, *bucket)
+	}
+	sort.Slice(a.Fractional.BySize, func(i, j int) bool {
+		return a.Fractional.BySize[i].ModulePixelSize < a.Fractional.BySize[j].ModulePixelSize
+	})
+
+	a.Combinations = analyzeCombinations(results)
+	sort.Strings(a.ExcludedDecoders)
+	for _, c := range a.Combinations {
+		if c.EffectiveTests > 0 && !excluded[c.Decoder] {
+			a.Worst = c
+			break
+		}
+	}
+	for i := len(a.Combinations) - 1; i >= 0; i-- {
+		if c := a.Combinations[i]; c.EffectiveTests > 0 && !excluded[c.Decoder] {
+			a.Best = c
+			break
+		}
+	}
+
+	a.Patterns = analyzePatterns(results)
+	a.NonMonotonic = analyzeNonMonotonic(results)
+	a.LengthDrift = analyzeLengthDrift(results)
+	a.VersionSelection, a.AvgVersion = analyzeVersionSelection(results)
+	a.FirstFailing = analyzeFirstFailingVersions(results)
+	a.ByVersion, a.WorstVersion = analyzeByVersion(results, excluded)
+	a.ByMask = analyzeByMask(results, excluded)
+	a.DecodeOutliers = analyzeDecodeOutliers(results)
+
+	return a
+}
+
+// analyzeCombinations aggregates results per encoder/decoder pair,
+// sorted by success rate ascending, then by name.
+func analyzeCombinations(results []RawTestResult) []CombinationRate {
+	agg := make(map[string]*CombinationRate)
+
+	for _, r := range results {
+		key := r.Encoder + "|" + r.Decoder
+		c := agg[key]
+		if c == nil {
+			c = &CombinationRate{Encoder: r.Encoder, Decoder: r.Decoder}
+			agg[key] = c
+		}
+		c.Tests++
+		if r.Success {
+			c.Successes++
+		}
+		switch {
+		case r.IsUnsupported():
+			c.UnsupportedSkips++
+		case r.IsUndersized():
+			c.UndersizedSkips++
+		case r.IsCapacityExceeded:
+			c.CapacitySkips++
+		}
+		if r.Encoded() {
+			c.Encoded++
+		}
+	}
+
+	combinations := make([]CombinationRate, 0, len(agg))
+	for _, c := range agg {
+		c.EffectiveTests = c.Tests - c.CapacitySkips - c.UnsupportedSkips - c.UndersizedSkips
+		c.SuccessRate = percent(c.Successes, c.EffectiveTests)
+		c.EncodeRate = percent(c.Encoded, c.EffectiveTests)
+		c.DecodeRate = percent(c.Successes, c.Encoded)
+		combinations = append(combinations, *c)
+	}
+
+	sort.Slice(combinations, func(i, j int) bool {
+		ci, cj := combinations[i], combinations[j]
+		if ci.SuccessRate != cj.SuccessRate {
+			return ci.SuccessRate < cj.SuccessRate
+		}
+		if ci.Encoder != cj.Encoder {
+			return ci.Encoder < cj.Encoder
+		}
+		return ci.Decoder < cj.Decoder
+	})
+
+	return combinations
+}
+
+// analyzePatterns builds an IncompatibilityPattern for each pair with failures.
+// Failures are considered fractional-related when more than half of them
+// occur at fractional module pixel sizes.
+func analyzePatterns(results []RawTestResult) []matrix.IncompatibilityPattern {
+	type patternAgg struct {
+		encoder, decoder   string
+		tests, failures    int
+		fractionalFailures int
+		pixelSizes         map[int]bool
+	}
+
+	agg := make(map[string]*patternAgg)
+	for _, r := range results {
+		if r.IsSkipped() {
+			continue
+		}
+		key := r.Encoder + "|" + r.Decoder
+		p := agg[key]
+		if p == nil {
+			p = &patternAgg{encoder: r.Encoder, decoder: r.Decoder, pixelSizes: make(map[int]bool)}
+			agg[key] = p
+		}
+		p.tests++
+		if !r.Success {
+			p.failures++
+			p.pixelSizes[r.PixelSize] = true
+			if isFractional(r) {
+				p.fractionalFailures++
+			}
+		}
+	}
+
+	var patterns []matrix.IncompatibilityPattern
+	for _, p := range agg {
+		if p.failures == 0 {
+			continue
+		}
+
+		var sizes []int
+		for size := range p.pixelSizes {
+			sizes = append(sizes, size)
+		}
+		sort.Ints(sizes)
+
+		patterns = append(patterns, matrix.IncompatibilityPattern{
+			EncoderName:         p.encoder,
+			DecoderName:         p.decoder,
+			FailureCount:        p.failures,
+			FailureRate:         float64(p.failures) / float64(p.tests),
+			PixelSizesAffected:  sizes,
+			IsFractionalRelated: p.fractionalFailures*2 > p.failures,
+		})
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		pi, pj := patterns[i], patterns[j]
+		if pi.FailureCount != pj.FailureCount {
+			return pi.FailureCount > pj.FailureCount
+		}
+		if pi.EncoderName != pj.EncoderName {
+			return pi.EncoderName < pj.EncoderName
+		}
+		return pi.DecoderName < pj.DecoderName
+	})
+
+	return patterns
+}
+
+// analyzeNonMonotonic finds failures at a pixel size larger than one that
+// succeeded for the same encoder, decoder, and input.
+func analyzeNonMonotonic(results []RawTestResult) []NonMonotonicCase {
+	groups := make(map[string][]RawTestResult)
+	var keys []string
+	for _, r := range results {
+		if r.IsSkipped() {
+			continue
+		}
+		key := fmt.Sprintf("%s|%s|%s|%s|%d", r.Encoder, r.Decoder, r.ContentType, r.ErrorCorrectionLevel, r.DataSize)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], r)
+	}
+	sort.Strings(keys)
+
+	var cases []NonMonotonicCase
+	for _, key := range keys {
+		group := groups[key]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].PixelSize < group[j].PixelSize
+		})
+
+		lastPassing := 0
+		for _, r := range group {
+			if r.Success {
+				lastPassing = r.PixelSize
+				continue
+			}
+			if lastPassing == 0 {
+				continue
+			}
+			cases = append(cases, NonMonotonicCase{
+				Encoder:              r.Encoder,
+				Decoder:              r.Decoder,
+				ContentType:          r.ContentType,
+				ErrorCorrectionLevel: r.ErrorCorrectionLevel,
+				DataSize:             r.DataSize,
+				PassingPixelSize:     lastPassing,
+				FailingPixelSize:     r.PixelSize,
+			})
+		}
+	}
+
+	return cases
+}
+
+// analyzeLengthDrift compares DecodedLength with DataSize for every completed
+// decode, grouped by decoder and content type. A decode that returned zero
+// bytes counts as drift.
+func analyzeLengthDrift(results []RawTestResult) []LengthDrift {
+	agg := make(map[string]*LengthDrift)
+
+	for _, r := range results {
+		if r.DecodedLength == nil {
+			continue
+		}
+		key := r.Decoder + "|" + r.ContentType
+		delta := *r.DecodedLength - r.DataSize
+
+		d := agg[key]
+		if d == nil {
+			d = &LengthDrift{Decoder: r.Decoder, ContentType: r.ContentType, MinDelta: delta, MaxDelta: delta}
+			agg[key] = d
+		}
+		d.Decodes++
+		if delta != 0 {
+			d.Drifted++
+		}
+		if r.CharsetMismatch {
+			d.CharsetMismatches++
+		}
+		if delta < d.MinDelta {
+			d.MinDelta = delta
+		}
+		if delta > d.MaxDelta {
+			d.MaxDelta = delta
+		}
+	}
+
+	drift := make([]LengthDrift, 0, len(agg))
+	for _, d := range agg {
+		drift = append(drift, *d)
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Decoder != drift[j].Decoder {
+			return drift[i].Decoder < drift[j].Decoder
+		}
+		return drift[i].ContentType < drift[j].ContentType
+	})
+
+	return drift
+}
+
+// FirstFailingVersion is the smallest QR version a decoder failed to read
+// at one pixel size. Larger versions pack more modules into the same image,
+// so this is where the decoder starts running out of pixels per module.
+type FirstFailingVersion struct {
+	Decoder   string
+	PixelSize int
+
+	// Version is the smallest version with a read failure, 0 when every
+	// tested version decoded.
+	Version int
+
+	// MaxTestedVersion is the largest version the decoder was given at
+	// this pixel size.
+	MaxTestedVersion int
+}
+
+// analyzeFirstFailingVersions finds the first failing version per decoder
+// and pixel size, sorted by decoder then pixel size. Only encoded standard
+// QR results with a known version count; encode failures and skips never
+// reached the decoder.
+func analyzeFirstFailingVersions(results []RawTestResult) []FirstFailingVersion {
+	type key struct {
+		decoder   string
+		pixelSize int
+	}
+
+	byKey := make(map[key]*FirstFailingVersion)
+	for _, r := range results {
+		if r.QRVersion <= 0 || r.IsMicroQR || !r.Encoded() {
+			continue
+		}
+
+		k := key{r.Decoder, r.PixelSize}
+		f := byKey[k]
+		if f == nil {
+			f = &FirstFailingVersion{Decoder: r.Decoder, PixelSize: r.PixelSize}
+			byKey[k] = f
+		}
+		if r.QRVersion > f.MaxTestedVersion {
+			f.MaxTestedVersion = r.QRVersion
+		}
+		if !r.Success && (f.Version == 0 || r.QRVersion < f.Version) {
+			f.Version = r.QRVersion
+		}
+	}
+
+	first := make([]FirstFailingVersion, 0, len(byKey))
+	for _, f := range byKey {
+		first = append(first, *f)
+	}
+	sort.Slice(first, func(i, j int) bool {
+		if first[i].Decoder != first[j].Decoder {
+			return first[i].Decoder < first[j].Decoder
+		}
+		return first[i].PixelSize < first[j].PixelSize
+	})
+	return first
+}
+
+// VersionRate is the failure count at one QR version.
+type VersionRate struct {
+	Version int
+
+	// ModuleCount is 17+4×Version.
+	ModuleCount int
+
+	Tests    int
+	Failures int
+}
+
+// FailureRate returns the failure percentage at this version.
+func (v VersionRate) FailureRate() float64 {
+	return percent(v.Failures, v.Tests)
+}
+
+// analyzeByVersion groups standard QR results by the encoder-reported (or
+// detected) QR version, leaving out skips, excluded decoders, and
+// results without a version. It returns the versions in ascending order
+// and the one with the highest failure rate, ties going to the version
+// with more failures, then the smaller version.
+func analyzeByVersion(results []RawTestResult, excluded map[string]bool) ([]VersionRate, VersionRate) {
+	byVersion := make(map[int]*VersionRate)
+	for _, r := range results {
+		if r.QRVersion <= 0 || r.IsMicroQR || r.IsSkipped() || excluded[r.Decoder] {
+			continue
+		}
+		v := byVersion[r.QRVersion]
+		if v == nil {
+			v = &VersionRate{Version: r.QRVersion, ModuleCount: testdata.CalculateModuleCount(r.QRVersion)}
+			byVersion[r.QRVersion] = v
+		}
+		v.Tests++
+		if !r.Success {
+			v.Failures++
+		}
+	}
+
+	versions := make([]VersionRate, 0, len(byVersion))
+	for _, v := range byVersion {
+		versions = append(versions, *v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+
+	var worst VersionRate
+	for _, v := range versions {
+		if v.Failures == 0 {
+			continue
+		}
+		if worst.Tests == 0 || v.FailureRate() > worst.FailureRate() ||
+			(v.FailureRate() == worst.FailureRate() && v.Failures > worst.Failures) {
+			worst = v
+		}
+	}
+	return versions, worst
+}
+
+// Thresholds for flagging a mask pattern whose failures are elevated.
+const (
+	// maskElevationFactor is how many times the failure rate of every other
+	// mask combined a mask's failure rate must reach.
+	maskElevationFactor = 2.0
+
+	// minElevatedMaskFailures keeps a couple of chance failures on a rarely
+	// chosen mask from being flagged.
+	minElevatedMaskFailures = 3
+)
+
+// MaskRate is the failure count at one data mask pattern.
+type MaskRate struct {
+	Mask     int
+	Tests    int
+	Failures int
+
+	// Elevated is true when failures cluster on this mask: its failure rate
+	// is at least maskElevationFactor times that of all other masks
+	// combined, over at least minElevatedMaskFailures failures.
+	Elevated bool
+}
+
+// FailureRate returns the failure percentage at this mask.
+func (m MaskRate) FailureRate() float64 {
+	return percent(m.Failures, m.Tests)
+}
+
+// analyzeByMask groups encoded standard QR results by detected mask
+// pattern, skipping excluded decoders and results without a mask, and
+// flags masks whose failure rate stands out from the rest. Masks are
+// returned in ascending order.
+func analyzeByMask(results []RawTestResult, excluded map[string]bool) []MaskRate {
+	byMask := make(map[int]*MaskRate)
+	tests, failures := 0, 0
+	for _, r := range results {
+		if r.Mask == nil || r.IsMicroQR || !r.Encoded() || excluded[r.Decoder] {
+			continue
+		}
+		m := byMask[*r.Mask]
+		if m == nil {
+			m = &MaskRate{Mask: *r.Mask}
+			byMask[*r.Mask] = m
+		}
+		m.Tests++
+		tests++
+		if !r.Success {
+			m.Failures++
+			failures++
+		}
+	}
+
+	masks := make([]MaskRate, 0, len(byMask))
+	for _, m := range byMask {
+		others := percent(failures-m.Failures, tests-m.Tests)
+		m.Elevated = m.Failures >= minElevatedMaskFailures && m.FailureRate() >= maskElevationFactor*others
+		masks = append(masks, *m)
+	}
+	sort.Slice(masks, func(i, j int) bool {
+		return masks[i].Mask < masks[j].Mask
+	})
+	return masks
+}
+
+// DecodeOutlier is one decode that took far longer than the other decodes
+// of its encoder/decoder pair.
+type DecodeOutlier struct {
+	Encoder              string
+	Decoder              string
+	DataSize             int
+	PixelSize            int
+	ContentType          string
+	ErrorCorrectionLevel string
+	DecodeTimeMs         float64
+
+	// MedianMs is the pair's median decode time, over every result with a
+	// decode time.
+	MedianMs float64
+}
+
+// analyzeDecodeOutliers collects results flagged IsDecodeOutlier with their
+// pair's median decode time, slowest first.
+func analyzeDecodeOutliers(results []RawTestResult) []DecodeOutlier {
+	type pairKey struct{ encoder, decoder string }
+	times := make(map[pairKey][]float64)
+	for _, r := range results {
+		if r.DecodeTimeMs > 0 {
+			pk := pairKey{r.Encoder, r.Decoder}
+			times[pk] = append(times[pk], r.DecodeTimeMs)
+		}
+	}
+
+	var outliers []DecodeOutlier
+	for _, r := range results {
+		if !r.IsDecodeOutlier {
+			continue
+		}
+		outliers = append(outliers, DecodeOutlier{
+			Encoder:              r.Encoder,
+			Decoder:              r.Decoder,
+			DataSize:             r.DataSize,
+			PixelSize:            r.PixelSize,
+			ContentType:          r.ContentType,
+			ErrorCorrectionLevel: r.ErrorCorrectionLevel,
+			DecodeTimeMs:         r.DecodeTimeMs,
+			MedianMs:             median(times[pairKey{r.Encoder, r.Decoder}]),
+		})
+	}
+	sort.SliceStable(outliers, func(i, j int) bool {
+		return outliers[i].DecodeTimeMs > outliers[j].DecodeTimeMs
+	})
+	return outliers
+}
+
+// median returns the median of values, or 0 when empty. values is sorted
+// in place.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+// VersionSelection records the QR versions each encoder chose for one payload.
+// Encoders can pick different versions for the same data (mode selection and
+// packing differ), which changes module count and fractional behavior.
+type VersionSelection struct {
+	DataSize             int
+	ContentType          string
+	ErrorCorrectionLevel string
+
+	// Versions maps encoder name to the distinct versions it chose, ascending.
+	// Usually one; more when the choice depended on pixel size.
+	Versions map[string][]int
+}
+
+// analyzeVersionSelection collects the versions each encoder chose, sorted by
+// data size, content type, and EC level, and each encoder's mean version.
+// Each encoded image counts once, not once per decoder. Results without a
+// known version are skipped.
+func analyzeVersionSelection(results []RawTestResult) ([]VersionSelection, map[string]float64) {
+	type payloadKey struct {
+		dataSize    int
+		contentType string
+		ecLevel     string
+	}
+
+	byPayload := make(map[payloadKey]map[string]map[int]bool)
+	images := make(map[string]bool)
+	sums := make(map[string]int)
+	counts := make(map[string]int)
+
+	for _, r := range results {
+		if r.QRVersion <= 0 {
+			continue
+		}
+
+		pk := payloadKey{r.DataSize, r.ContentType, r.ErrorCorrectionLevel}
+		if byPayload[pk] == nil {
+			byPayload[pk] = make(map[string]map[int]bool)
+		}
+		if byPayload[pk][r.Encoder] == nil {
+			byPayload[pk][r.Encoder] = make(map[int]bool)
+		}
+		byPayload[pk][r.Encoder][r.QRVersion] = true
+
+		imageKey := fmt.Sprintf("%s|%d|%d|%s|%s", r.Encoder, r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel)
+		if !images[imageKey] {
+			images[imageKey] = true
+			sums[r.Encoder] += r.QRVersion
+			counts[r.Encoder]++
+		}
+	}
+
+	selections := make([]VersionSelection, 0, len(byPayload))
+	for pk, encoders := range byPayload {
+		sel := VersionSelection{
+			DataSize:             pk.dataSize,
+			ContentType:          pk.contentType,
+			ErrorCorrectionLevel: pk.ecLevel,
+			Versions:             make(map[string][]int),
+		}
+		for enc, versions := range encoders {
+			for v := range versions {
+				sel.Versions[enc] = append(sel.Versions[enc], v)
+			}
+			sort.Ints(sel.Versions[enc])
+		}
+		selections = append(selections, sel)
+	}
+
+	sort.Slice(selections, func(i, j int) bool {
+		a, b := selections[i], selections[j]
+		if a.DataSize != b.DataSize {
+			return a.DataSize < b.DataSize
+		}
+		if a.ContentType != b.ContentType {
+			return a.ContentType < b.ContentType
+		}
+		return a.ErrorCorrectionLevel < b.ErrorCorrectionLevel
+	})
+
+	avg := make(map[string]float64, len(sums))
+	for enc, sum := range sums {
+		avg[enc] = float64(sum) / float64(counts[enc])
+	}
+
+	return selections, avg
+}
+
+// WriteAnalysisMarkdown writes a markdown summary of the analysis to w.
+func WriteAnalysisMarkdown(w io.Writer, a Analysis) error {
+	var b strings.Builder
+
+	writeMarkdownHeader(&b, a)
+
+	b.WriteString("## Combination Overview\n\n")
+	if err := WriteCombinationGrid(&b, a.Combinations); err != nil {
+		return err
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Encoder Version Selection\n\n")
+	if err := WriteVersionSelection(&b, a.VersionSelection, a.AvgVersion); err != nil {
+		return err
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## First Failing Version\n\n")
+	if err := WriteFirstFailingVersions(&b, a.FirstFailing); err != nil {
+		return err
+	}
+	b.WriteString("\n")
+
+	writeWorstMarkdown(&b, a)
+	writePatternsMarkdown(&b, a)
+
+	writeFractionalMarkdown(&b, a.Fractional)
+	writeVersionMarkdown(&b, a)
+	writeMaskMarkdown(&b, a)
+	writeDecodeOutliersMarkdown(&b, a)
+
+	b.WriteString("## Decoded vs Expected Bytes\n\n")
+	if len(a.LengthDrift) == 0 {
+		b.WriteString("No decoded length data.\n\n")
+	} else {
+		b.WriteString("| Decoder | Content | Decodes | Length Drift | Delta (bytes) | Charset Mismatches |\n")
+		b.WriteString("|---------|---------|---------|--------------|---------------|--------------------|\n")
+		charsetSensitive := false
+		for _, d := range a.LengthDrift {
+			note := fmt.Sprintf("%d", d.CharsetMismatches)
+			if d.CharsetMismatches > 0 {
+				note += " (charset-sensitive)"
+				charsetSensitive = true
+			}
+			fmt.Fprintf(&b, "| %s | %s | %d | %d | %s | %s |\n",
+				d.Decoder, d.ContentType, d.Decodes, d.Drifted, formatDeltaRange(d.MinDelta, d.MaxDelta), note)
+		}
+		b.WriteString("\n")
+		if charsetSensitive {
+			b.WriteString("Charset-sensitive decoders returned the input read as the wrong character set (UTF-8 vs Latin-1): a charset issue, not data corruption.\n\n")
+		}
+	}
+
+	writeNonMonotonicMarkdown(&b, a)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteFailuresMarkdown writes only the failing parts of the analysis: the
+// combination grid limited to pairs with failures, the worst combination,
+// failure patterns, and non-monotonic failures. For large matrices where the
+// all-passing grid is noise.
+func WriteFailuresMarkdown(w io.Writer, a Analysis) error {
+	var b strings.Builder
+
+	writeMarkdownHeader(&b, a)
+
+	var failing []CombinationRate
+	for _, c := range a.Combinations {
+		if c.Successes < c.EffectiveTests {
+			failing = append(failing, c)
+		}
+	}
+
+	b.WriteString("## Failing Combinations\n\n")
+	if len(failing) == 0 {
+		b.WriteString("No failures.\n\n")
+	} else {
+		if err := WriteCombinationGrid(&b, failing); err != nil {
+			return err
+		}
+		b.WriteString("\n")
+	}
+
+	writeWorstMarkdown(&b, a)
+	writePatternsMarkdown(&b, a)
+	writeNonMonotonicMarkdown(&b, a)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownHeader writes the title and headline counts.
+func writeMarkdownHeader(b *strings.Builder, a Analysis) {
+	b.WriteString("# QR Compatibility Analysis\n\n")
+	fmt.Fprintf(b, "- **Total tests:** %d\n", a.TotalTests)
+	fmt.Fprintf(b, "- **Capacity skips:** %d\n", a.CapacitySkips)
+	if a.UnsupportedSkips > 0 {
+		fmt.Fprintf(b, "- **Unsupported skips:** %d\n", a.UnsupportedSkips)
+	}
+	if a.UndersizedSkips > 0 {
+		fmt.Fprintf(b, "- **Undersized skips:** %d\n", a.UndersizedSkips)
+	}
+	fmt.Fprintf(b, "- **Effective tests:** %d\n", a.EffectiveTests)
+	fmt.Fprintf(b, "- **Success rate:** %.1f%%\n\n", percent(a.Successes, a.EffectiveTests))
+}
+
+// writeWorstMarkdown writes the worst combination section.
+func writeWorstMarkdown(b *strings.Builder, a Analysis) {
+	b.WriteString("## Worst Combination\n\n")
+	if a.Worst.EffectiveTests == 0 {
+		b.WriteString("No combinations with effective tests.\n\n")
+	} else {
+		fmt.Fprintf(b, "**%s → %s**: %.1f%% (%d/%d effective tests)\n\n",
+			a.Worst.Encoder, a.Worst.Decoder, a.Worst.SuccessRate, a.Worst.Successes, a.Worst.EffectiveTests)
+	}
+}
+
+// writePatternsMarkdown writes the failure patterns table.
+func writePatternsMarkdown(b *strings.Builder, a Analysis) {
+	b.WriteString("## Failure Patterns\n\n")
+	if len(a.Patterns) == 0 {
+		b.WriteString("No failures.\n\n")
+		return
+	}
+
+	b.WriteString("| Encoder | Decoder | Failures | Failure Rate | Pixel Sizes | Fractional-Related |\n")
+	b.WriteString("|---------|---------|----------|--------------|-------------|--------------------|\n")
+	for _, p := range a.Patterns {
+		sizes := make([]string, len(p.PixelSizesAffected))
+		for i, size := range p.PixelSizesAffected {
+			sizes[i] = fmt.Sprintf("%d", size)
+		}
+		fractional := "no"
+		if p.IsFractionalRelated {
+			fractional = "yes"
+		}
+		fmt.Fprintf(b, "| %s | %s | %d | %.1f%% | %s | %s |\n",
+			p.EncoderName, p.DecoderName, p.FailureCount, p.FailureRate*100, strings.Join(sizes, ", "), fractional)
+	}
+	b.WriteString("\n")
+}
+
+// writeNonMonotonicMarkdown writes the non-monotonic failures table.
+func writeNonMonotonicMarkdown(b *strings.Builder, a Analysis) {
+	b.WriteString("## Non-Monotonic Failures\n\n")
+	if len(a.NonMonotonic) == 0 {
+		b.WriteString("None: no pixel size failed after a smaller size succeeded.\n")
+		return
+	}
+
+	b.WriteString("| Encoder | Decoder | Content | EC | Data Size | Passes At | Fails At |\n")
+	b.WriteString("|---------|---------|---------|----|-----------|-----------|----------|\n")
+	for _, c := range a.NonMonotonic {
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %d | %dpx | %dpx |\n",
+			c.Encoder, c.Decoder, c.ContentType, c.ErrorCorrectionLevel, c.DataSize, c.PassingPixelSize, c.FailingPixelSize)
+	}
+}
+
+// writeFractionalMarkdown writes the fractional vs integer comparison and
+// the failures per module pixel size.
+func writeFractionalMarkdown(b *strings.Builder, f FractionalAnalysis) {
+	b.WriteString("## Fractional Module Sizes\n\n")
+	if len(f.BySize) == 0 {
+		b.WriteString("Module sizes unavailable: no results carry a QR version.\n\n")
+		return
+	}
+
+	b.WriteString("| Module Size | Tests | Failures | Failure Rate |\n")
+	b.WriteString("|-------------|-------|----------|--------------|\n")
+	fmt.Fprintf(b, "| Fractional | %d | %d | %.1f%% |\n",
+		f.FractionalTests, f.FractionalFailures, f.FractionalFailureRate())
+	fmt.Fprintf(b, "| Integer | %d | %d | %.1f%% |\n\n",
+		f.IntegerTests, f.IntegerFailures, f.IntegerFailureRate())
+
+	b.WriteString("| Pixels/Module | Type | Tests | Failures | Failure Rate |\n")
+	b.WriteString("|---------------|------|-------|----------|--------------|\n")
+	for _, size := range f.BySize {
+		kind := "integer"
+		if size.Fractional {
+			kind = "fractional"
+		}
+		fmt.Fprintf(b, "| %.2f | %s | %d | %d | %.1f%% |\n",
+			size.ModulePixelSize, kind, size.Tests, size.Failures, percent(size.Failures, size.Tests))
+	}
+	b.WriteString("\n")
+
+	if f.Unavailable > 0 {
+		fmt.Fprintf(b, "%d tests without a QR version or module size are not included.\n\n", f.Unavailable)
+	}
+}
+
+// writeVersionMarkdown writes the failure rate per QR version and the
+// version where failures peak.
+func writeVersionMarkdown(b *strings.Builder, a Analysis) {
+	b.WriteString("## Failures by QR Version\n\n")
+	if len(a.ByVersion) == 0 {
+		b.WriteString("No QR version data.\n\n")
+		return
+	}
+
+	b.WriteString("| Version | Modules | Tests | Failures | Failure Rate |\n")
+	b.WriteString("|---------|---------|-------|----------|--------------|\n")
+	for _, v := range a.ByVersion {
+		fmt.Fprintf(b, "| %d | %d | %d | %d | %.1f%% |\n",
+			v.Version, v.ModuleCount, v.Tests, v.Failures, v.FailureRate())
+	}
+	b.WriteString("\n")
+
+	if a.WorstVersion.Tests > 0 {
+		fmt.Fprintf(b, "Failures peak at version %d (%d modules): %.1f%% of %d tests.\n\n",
+			a.WorstVersion.Version, a.WorstVersion.ModuleCount, a.WorstVersion.FailureRate(), a.WorstVersion.Tests)
+	}
+}
+
+// writeMaskMarkdown writes the failure rate per mask pattern and the masks
+// failures cluster on.
+func writeMaskMarkdown(b *strings.Builder, a Analysis) {
+	b.WriteString("## Failures by Mask Pattern\n\n")
+	if len(a.ByMask) == 0 {
+		b.WriteString("No mask pattern data.\n\n")
+		return
+	}
+
+	b.WriteString("| Mask | Tests | Failures | Failure Rate |\n")
+	b.WriteString("|------|-------|----------|--------------|\n")
+	var elevated []string
+	for _, m := range a.ByMask {
+		note := ""
+		if m.Elevated {
+			note = " (elevated)"
+			elevated = append(elevated, fmt.Sprintf("%d", m.Mask))
+		}
+		fmt.Fprintf(b, "| %d | %d | %d | %.1f%%%s |\n", m.Mask, m.Tests, m.Failures, m.FailureRate(), note)
+	}
+	b.WriteString("\n")
+
+	if len(elevated) > 0 {
+		fmt.Fprintf(b, "Failures cluster on mask %s: at least %.0f× the failure rate of the other masks.\n\n",
+			strings.Join(elevated, ", "), maskElevationFactor)
+	}
+}
+
+// writeDecodeOutliersMarkdown writes the decodes that took far longer than
+// their pair's median.
+func writeDecodeOutliersMarkdown(b *strings.Builder, a Analysis) {
+	b.WriteString("## Decode Time Outliers\n\n")
+	if len(a.DecodeOutliers) == 0 {
+		b.WriteString("No decode time outliers.\n\n")
+		return
+	}
+
+	b.WriteString("| Encoder | Decoder | Data Size | Pixel Size | Content | EC | Decode Time | Pair Median |\n")
+	b.WriteString("|---------|---------|-----------|------------|---------|----|-------------|-------------|\n")
+	for _, o := range a.DecodeOutliers {
+		fmt.Fprintf(b, "| %s | %s | %d | %dpx | %s | %s | %.2fms | %.2fms |\n",
+			o.Encoder, o.Decoder, o.DataSize, o.PixelSize, o.ContentType, o.ErrorCorrectionLevel, o.DecodeTimeMs, o.MedianMs)
+	}
+	b.WriteString("\n")
+}
+
+// formatDeltaRange formats a byte delta range as "+11..+17", or a single value when equal.
+func formatDeltaRange(min, max int) string {
+	if min == 0 && max == 0 {
+		return "0"
+	}
+	if min == max {
+		return fmt.Sprintf("%+d", min)
+	}
+	return fmt.Sprintf("%+d..%+d", min, max)
+}
+
+// percent returns n as a percentage of total, or 0 when total is 0.
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
@@ -0,0 +1,138 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Likely causes ExplainFailure attaches to a result that did not succeed.
+const (
+	CauseCapacityExceeded = "capacity-exceeded" // payload too large for the requested size
+	CauseEmptyData        = "empty-data"        // encoder rejected a zero-length payload
+	CauseUnsupported      = "unsupported"       // decoder cannot return the content type intact
+	CauseUndersized       = "undersized"        // pixel size below the minimum module size
+	CauseCharset          = "charset"           // decoded bytes read as the wrong character set
+	CauseFractionalModule = "fractional-module" // non-integer module size on a decoder that samples integer boundaries
+	CauseArchivedLibrary  = "archived-library"  // decoder wraps an unmaintained library
+)
+
+// fractionalSensitiveDecoders are name prefixes of decoders known to misread
+// fractional module sizes: gozxing assumes integer module boundaries, and
+// readqr is a gozxing fork. The prefix covers the -gozxing-binarizers
+// variants.
+var fractionalSensitiveDecoders = []string{
+	"makiuchi-d/gozxing",
+	"caiguanhao/readqr",
+}
+
+// ExplainFailure returns the likely cause of a result that did not succeed,
+// or "" for a success or a failure none of the known causes fit. archived
+// holds the names of decoders that wrap archived libraries.
+//
+// Causes are checked from the most to the least certain: skips and charset
+// mismatches are recorded by the runner, a fractional module on a sensitive
+// decoder is the known skip2 + gozxing failure mode, and an archived decoder
+// is suspect for anything else. Empty-data skips set IsCapacityExceeded, as
+// do unsupported-content skips in older results files, so their error
+// types are checked first.
+func ExplainFailure(r RawTestResult, archived map[string]bool) string {
+	switch {
+	case r.Success:
+		return ""
+	case r.ErrorType == "emptyData":
+		return CauseEmptyData
+	case r.ErrorType == "unsupported":
+		return CauseUnsupported
+	case r.IsCapacityExceeded || r.ErrorType == "capacity":
+		return CauseCapacityExceeded
+	case r.ErrorType == "undersized":
+		return CauseUndersized
+	case r.CharsetMismatch:
+		return CauseCharset
+	case isFractionalSensitive(r.Decoder) && isFractional(r):
+		return CauseFractionalModule
+	case archived[r.Decoder]:
+		return CauseArchivedLibrary
+	}
+	return ""
+}
+
+// isFractionalSensitive reports whether decoder is one of
+// fractionalSensitiveDecoders.
+func isFractionalSensitive(decoder string) bool {
+	for _, prefix := range fractionalSensitiveDecoders {
+		if strings.HasPrefix(decoder, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteExplainMarkdown writes the number of unsuccessful results per likely
+// cause, capacity skips included, then every failure with its cause.
+// Failures no known cause fits are listed as "unexplained".
+func WriteExplainMarkdown(w io.Writer, results []RawTestResult, archived map[string]bool) error {
+	var b strings.Builder
+	b.WriteString("## Failure Causes\n\n")
+
+	counts := make(map[string]int)
+	var failed []RawTestResult
+	for _, r := range results {
+		if r.Success {
+			continue
+		}
+		counts[explainOrUnexplained(r, archived)]++
+		if r.IsFailure() {
+			failed = append(failed, r)
+		}
+	}
+
+	if len(counts) == 0 {
+		b.WriteString("No failures.\n\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	causes := make([]string, 0, len(counts))
+	for cause := range counts {
+		causes = append(causes, cause)
+	}
+	sort.Slice(causes, func(i, j int) bool {
+		if counts[causes[i]] != counts[causes[j]] {
+			return counts[causes[i]] > counts[causes[j]]
+		}
+		return causes[i] < causes[j]
+	})
+	b.WriteString("| Cause | Results |\n")
+	b.WriteString("|-------|---------|\n")
+	for _, cause := range causes {
+		fmt.Fprintf(&b, "| %s | %d |\n", cause, counts[cause])
+	}
+	b.WriteString("\n")
+
+	if len(failed) > 0 {
+		sortResults(failed)
+		b.WriteString("| Encoder | Decoder | Data Size | Pixel Size | Content | EC | Error | Likely Cause |\n")
+		b.WriteString("|---------|---------|-----------|------------|---------|----|-------|--------------|\n")
+		for _, r := range failed {
+			fmt.Fprintf(&b, "| %s | %s | %d | %dpx | %s | %s | %s | %s |\n",
+				r.Encoder, r.Decoder, r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel,
+				r.ErrorType, explainOrUnexplained(r, archived))
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// explainOrUnexplained returns ExplainFailure, or "unexplained" when no
+// known cause fits.
+func explainOrUnexplained(r RawTestResult, archived map[string]bool) string {
+	if cause := ExplainFailure(r, archived); cause != "" {
+		return cause
+	}
+	return "unexplained"
+}
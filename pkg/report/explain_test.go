@@ -0,0 +1,115 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExplainFailure(t *testing.T) {
+	archived := map[string]bool{"liyue201/goqr": true}
+
+	tests := []struct {
+		name   string
+		result RawTestResult
+		want   string
+	}{
+		{
+			name:   "success",
+			result: RawTestResult{Decoder: "makiuchi-d/gozxing", Success: true, ModulePixelSize: 10.24, IsFractionalModule: true},
+			want:   "",
+		},
+		{
+			name:   "capacity",
+			result: RawTestResult{Decoder: "makiuchi-d/gozxing", ErrorType: "capacity", IsCapacityExceeded: true},
+			want:   CauseCapacityExceeded,
+		},
+		{
+			name:   "empty data",
+			result: RawTestResult{Decoder: "makiuchi-d/gozxing", ErrorType: "emptyData", IsCapacityExceeded: true},
+			want:   CauseEmptyData,
+		},
+		{
+			name:   "unsupported content",
+			result: RawTestResult{Decoder: "tuotoo/qrcode", ErrorType: "unsupported"},
+			want:   CauseUnsupported,
+		},
+		{
+			name:   "undersized",
+			result: RawTestResult{Decoder: "makiuchi-d/gozxing", ErrorType: "undersized", ModulePixelSize: 1.5, IsFractionalModule: true},
+			want:   CauseUndersized,
+		},
+		{
+			name:   "charset",
+			result: RawTestResult{Decoder: "tuotoo/qrcode", ErrorType: "dataMismatch", CharsetMismatch: true},
+			want:   CauseCharset,
+		},
+		{
+			name:   "fractional on gozxing",
+			result: RawTestResult{Decoder: "makiuchi-d/gozxing", ErrorType: "decode", ModulePixelSize: 12.857, IsFractionalModule: true},
+			want:   CauseFractionalModule,
+		},
+		{
+			name:   "fractional on binarizer variant",
+			result: RawTestResult{Decoder: "makiuchi-d/gozxing-global", ErrorType: "decode", ModulePixelSize: 12.857, IsFractionalModule: true},
+			want:   CauseFractionalModule,
+		},
+		{
+			name:   "archived",
+			result: RawTestResult{Decoder: "liyue201/goqr", ErrorType: "decode", ModulePixelSize: 12.857, IsFractionalModule: true},
+			want:   CauseArchivedLibrary,
+		},
+		{
+			name:   "integer module on gozxing",
+			result: RawTestResult{Decoder: "makiuchi-d/gozxing", ErrorType: "decode", ModulePixelSize: 10},
+			want:   "",
+		},
+		{
+			name:   "fractional on insensitive decoder",
+			result: RawTestResult{Decoder: "tuotoo/qrcode", ErrorType: "decode", ModulePixelSize: 12.857, IsFractionalModule: true},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExplainFailure(tt.result, archived); got != tt.want {
+				t.Errorf("ExplainFailure() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteExplainMarkdown(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc", Decoder: "makiuchi-d/gozxing", DataSize: 10, PixelSize: 400, ContentType: "numeric", ErrorCorrectionLevel: "M", Success: true},
+		{Encoder: "enc", Decoder: "makiuchi-d/gozxing", DataSize: 3000, PixelSize: 400, ContentType: "numeric", ErrorCorrectionLevel: "M", ErrorType: "capacity", IsCapacityExceeded: true},
+		{Encoder: "enc", Decoder: "makiuchi-d/gozxing", DataSize: 500, PixelSize: 440, ContentType: "binary", ErrorCorrectionLevel: "M", ErrorType: "decode", ModulePixelSize: 12.857, IsFractionalModule: true},
+		{Encoder: "enc", Decoder: "liyue201/goqr", DataSize: 500, PixelSize: 440, ContentType: "binary", ErrorCorrectionLevel: "M", ErrorType: "decode"},
+		{Encoder: "enc", Decoder: "tuotoo/qrcode", DataSize: 500, PixelSize: 440, ContentType: "binary", ErrorCorrectionLevel: "M", ErrorType: "panic"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteExplainMarkdown(&buf, results, map[string]bool{"liyue201/goqr": true}); err != nil {
+		t.Fatalf("WriteExplainMarkdown() failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"## Failure Causes",
+		"| capacity-exceeded | 1 |",
+		"| fractional-module | 1 |",
+		"| unexplained | 1 |",
+		"| enc | makiuchi-d/gozxing | 500 | 440px | binary | M | decode | fractional-module |",
+		"| enc | liyue201/goqr | 500 | 440px | binary | M | decode | archived-library |",
+		"| enc | tuotoo/qrcode | 500 | 440px | binary | M | panic | unexplained |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+	// Capacity skips are counted but not listed as failures
+	if strings.Contains(out, "| 3000 |") {
+		t.Errorf("capacity skip listed as a failure\n\nOutput:\n%s", out)
+	}
+}
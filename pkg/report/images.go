@@ -0,0 +1,72 @@
+package report
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+// SaveFailedImages writes the image behind every failed test to dir, one
+// subdirectory per encoder: <test case>.png as the decoder saw it and
+// <test case>-overlay.png magnified with the module grid drawn on
+// (testdata.RenderModuleOverlay). An image several decoders failed is
+// written once. Skips never reached a decode verdict and are ignored.
+//
+// Images come from m.Images, so the run needs Config.RetainImages; failures
+// whose image was evicted or never retained are skipped. Returns the number
+// of images written.
+func SaveFailedImages(dir string, m *matrix.CompatibilityMatrix) (int, error) {
+	if m.Images == nil {
+		return 0, fmt.Errorf("failed images: no retained images (enable RetainImages)")
+	}
+
+	saved := make(map[matrix.ImageKey]bool)
+	for _, result := range m.Results {
+		if result.Error == nil || result.IsSkipped() {
+			continue
+		}
+
+		key := matrix.ImageKey{Encoder: result.EncoderName, TestCase: result.TestCase}
+		if saved[key] {
+			continue
+		}
+		img, ok := m.Images.Get(key)
+		if !ok {
+			continue
+		}
+
+		encoderDir := filepath.Join(dir, sanitizeFilename(result.EncoderName))
+		if err := os.MkdirAll(encoderDir, 0755); err != nil {
+			return len(saved), fmt.Errorf("failed to create %s: %w", encoderDir, err)
+		}
+
+		base := filepath.Join(encoderDir, result.TestCase)
+		if err := writePNG(base+".png", img); err != nil {
+			return len(saved), err
+		}
+		if err := writePNG(base+"-overlay.png", testdata.RenderModuleOverlay(img, result.ModuleCount)); err != nil {
+			return len(saved), err
+		}
+		saved[key] = true
+	}
+
+	return len(saved), nil
+}
+
+// writePNG encodes img as a PNG file.
+func writePNG(filename string, img image.Image) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filename, err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+	"github.com/13rac1/qr-library-test/internal/testdata"
+)
+
+func TestSaveFailedImages(t *testing.T) {
+	images := matrix.NewImageStore(0)
+	img := image.NewGray(image.Rect(0, 0, 50, 50))
+	images.Put(matrix.ImageKey{Encoder: "skip2/go-qrcode", TestCase: "numeric-10b-50px-ecM"}, img)
+	images.Put(matrix.ImageKey{Encoder: "skip2/go-qrcode", TestCase: "numeric-10b-60px-ecM"}, img)
+
+	failed := errors.New("not found")
+	m := &matrix.CompatibilityMatrix{
+		Images: images,
+		Results: []matrix.TestResult{
+			// Two decoders failed the same image
+			{EncoderName: "skip2/go-qrcode", DecoderName: "a", TestCase: "numeric-10b-50px-ecM", ModuleCount: 21, Error: matrix.DecodeError{Err: failed}},
+			{EncoderName: "skip2/go-qrcode", DecoderName: "b", TestCase: "numeric-10b-50px-ecM", ModuleCount: 21, Error: matrix.DecodeError{Err: failed}},
+			// Passing tests are not saved
+			{EncoderName: "skip2/go-qrcode", DecoderName: "a", TestCase: "numeric-10b-60px-ecM"},
+		},
+	}
+
+	dir := t.TempDir()
+	saved, err := SaveFailedImages(dir, m)
+	if err != nil {
+		t.Fatalf("SaveFailedImages() failed: %v", err)
+	}
+	if saved != 1 {
+		t.Errorf("SaveFailedImages() saved %d images, want 1", saved)
+	}
+
+	base := filepath.Join(dir, "skip2_go-qrcode", "numeric-10b-50px-ecM")
+	for name, size := range map[string]int{base + ".png": 50, base + "-overlay.png": 50 * testdata.OverlayScale} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatalf("Missing %s: %v", name, err)
+		}
+		got, err := png.Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("Decoding %s failed: %v", name, err)
+		}
+		if got.Bounds().Dx() != size {
+			t.Errorf("%s width = %d, want %d", name, got.Bounds().Dx(), size)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "skip2_go-qrcode", "numeric-10b-60px-ecM.png")); !os.IsNotExist(err) {
+		t.Error("SaveFailedImages() wrote an image for a passing test")
+	}
+
+	if _, err := SaveFailedImages(dir, &matrix.CompatibilityMatrix{}); err == nil {
+		t.Error("SaveFailedImages() without retained images should fail")
+	}
+}
@@ -2,11 +2,15 @@
 package report
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/13rac1/qr-library-test/internal/matrix"
@@ -16,6 +20,14 @@ import (
 // Outputs raw test results without aggregation.
 type JSONReporter struct {
 	OutputDir string
+
+	// Compress gzips each file and names it .json.gz. LoadResults reads
+	// both forms.
+	Compress bool
+
+	// FailuresOnly keeps only failed results (IsFailure), so encoders and
+	// decoders without failures get no file at all.
+	FailuresOnly bool
 }
 
 // NewJSONReporter creates a new JSON reporter that writes to the specified directory.
@@ -32,23 +44,118 @@ type RawTestResult struct {
 	DataSize             int     `json:"dataSize"`
 	PixelSize            int     `json:"pixelSize"`
 	ContentType          string  `json:"contentType"`
-	ErrorCorrectionLevel string  `json:"errorCorrectionLevel"` // "L", "M", "Q", or "H"
+	ErrorCorrectionLevel string  `json:"errorCorrectionLevel"`      // "L", "M", "Q", or "H"
+	Seed                 int64   `json:"seed,omitempty"`            // binary payload seed in a seed sweep
+	Repeat               int     `json:"repeat,omitempty"`          // pass of a repeated run, 1-based
+	CenterOcclusion      float64 `json:"centerOcclusion,omitempty"` // fraction of the image area blanked from the center
+	CorruptFraction      float64 `json:"corruptFraction,omitempty"` // fraction of modules inverted before decoding
 	Success              bool    `json:"success"`
-	ErrorType            string  `json:"errorType,omitempty"` // "encode", "decode", "dataMismatch"
+	ErrorType            string  `json:"errorType,omitempty"` // "encode", "capacity", "emptyData", "unsupported", "decode", "dataMismatch", "undersized", "timeout", "panic"
 	ErrorMsg             string  `json:"errorMsg,omitempty"`
 	IsCapacityExceeded   bool    `json:"isCapacityExceeded,omitempty"`
+	IsBlindSpot          bool    `json:"isBlindSpot,omitempty"` // all decoders failed, reference decoder succeeded
 	EncodeTimeMs         float64 `json:"encodeTimeMs"`
+	QRConstructTimeMs    float64 `json:"qrConstructTimeMs,omitempty"` // encode phases, for phase-timed encoders
+	ImageEncodeTimeMs    float64 `json:"imageEncodeTimeMs,omitempty"`
+	ImageDecodeTimeMs    float64 `json:"imageDecodeTimeMs,omitempty"`
 	DecodeTimeMs         float64 `json:"decodeTimeMs"`
+	IsDecodeOutlier      bool    `json:"isDecodeOutlier,omitempty"` // decode far slower than the pair's median
+	DecodedLength        *int    `json:"decodedLength,omitempty"`   // bytes the decoder returned, nil when it did not return
+	CharsetMismatch      bool    `json:"charsetMismatch,omitempty"` // data mismatch from a UTF-8 vs Latin-1 misread, not corruption
 	QRVersion            int     `json:"qrVersion,omitempty"`
+	VersionMismatch      bool    `json:"versionMismatch,omitempty"` // encoder-reported version differs from the detected one
 	ModuleCount          int     `json:"moduleCount,omitempty"`
+	CapacityUtilization  float64 `json:"capacityUtilization,omitempty"` // fraction of the version's data capacity used
+	IsMicroQR            bool    `json:"isMicroQR,omitempty"`
+	Mask                 *int    `json:"mask,omitempty"` // 0-7, nil when not detected
 	ModulePixelSize      float64 `json:"modulePixelSize,omitempty"`
 	IsFractionalModule   bool    `json:"isFractionalModule"`
 }
 
+// Encoded reports whether the encoder produced an image and the decoder was
+// run on it: the result is neither an encode failure nor a skip.
+func (r RawTestResult) Encoded() bool {
+	return r.ErrorType != "encode" && r.ErrorType != "capacity" && r.ErrorType != "emptyData" && !r.IsSkipped()
+}
+
+// IsUnsupported reports whether the test was skipped because the decoder
+// cannot return the content type intact. It is a skip, counted apart from
+// capacity skips. Files written before the runner kept the two apart also
+// set IsCapacityExceeded on these results.
+func (r RawTestResult) IsUnsupported() bool {
+	return r.ErrorType == "unsupported"
+}
+
+// IsUndersized reports whether the decode was skipped because the pixel
+// size left too few pixels per module: a configuration problem, not a
+// decoder failure.
+func (r RawTestResult) IsUndersized() bool {
+	return r.ErrorType == "undersized"
+}
+
+// IsSkipped reports whether the test never reached a decode verdict: a
+// capacity or empty-data rejection, content the decoder cannot return, or
+// an undersized image. Skips are left out of effective tests and success
+// rates.
+func (r RawTestResult) IsSkipped() bool {
+	return r.IsCapacityExceeded || r.IsUnsupported() || r.IsUndersized()
+}
+
+// IsFailure reports whether the test failed: not a success and not a skip.
+func (r RawTestResult) IsFailure() bool {
+	return !r.Success && !r.IsSkipped()
+}
+
 // RawResults contains all test results with metadata.
 type RawResults struct {
-	Timestamp string          `json:"timestamp"`
-	Results   []RawTestResult `json:"results"`
+	Timestamp string `json:"timestamp"`
+
+	// ExecutedAxes lists the axis values that actually ran, which filters,
+	// capacity skips, and early stops can narrow from the configured ones.
+	// Nil in files written before it was recorded.
+	ExecutedAxes *ExecutedAxes `json:"executedAxes,omitempty"`
+
+	Results []RawTestResult `json:"results"`
+}
+
+// ExecutedAxes holds the distinct data sizes, pixel sizes, content types,
+// and error correction levels of the results that ran, so downstream tools
+// know the real coverage of a results file.
+type ExecutedAxes struct {
+	DataSizes             []int    `json:"dataSizes"`
+	PixelSizes            []int    `json:"pixelSizes"`
+	ContentTypes          []string `json:"contentTypes"`
+	ErrorCorrectionLevels []string `json:"errorCorrectionLevels"` // in L, M, Q, H order
+}
+
+// executedAxes collects the axis values of results, leaving out skips: a
+// skipped case never reached a decode. Sizes are ascending and
+// content types sorted by name.
+func executedAxes(results []RawTestResult) *ExecutedAxes {
+	dataSizes := make(map[int]bool)
+	pixelSizes := make(map[int]bool)
+	contentTypes := make(map[string]bool)
+	levels := make(map[string]bool)
+	for _, r := range results {
+		if r.IsSkipped() {
+			continue
+		}
+		dataSizes[r.DataSize] = true
+		pixelSizes[r.PixelSize] = true
+		contentTypes[r.ContentType] = true
+		levels[r.ErrorCorrectionLevel] = true
+	}
+
+	axes := &ExecutedAxes{
+		DataSizes:             sortedKeys(dataSizes),
+		PixelSizes:            sortedKeys(pixelSizes),
+		ContentTypes:          sortedKeys(contentTypes),
+		ErrorCorrectionLevels: sortedKeys(levels),
+	}
+	sort.SliceStable(axes.ErrorCorrectionLevels, func(i, j int) bool {
+		return strings.Index("LMQH", axes.ErrorCorrectionLevels[i]) < strings.Index("LMQH", axes.ErrorCorrectionLevels[j])
+	})
+	return axes
 }
 
 // Generate creates JSON files split by encoder and decoder.
@@ -69,18 +176,27 @@ func (r *JSONReporter) generateEncoderFiles(m *matrix.CompatibilityMatrix) error
 	// Group results by encoder
 	byEncoder := make(map[string][]RawTestResult)
 	for _, result := range m.Results {
-		raw := convertResult(result)
-		byEncoder[result.EncoderName] = append(byEncoder[result.EncoderName], raw)
+		byEncoder[result.EncoderName] = append(byEncoder[result.EncoderName], convertResult(result))
 	}
 
-	// Write one file per encoder
+	// Write one file per encoder, in name order so runs diff cleanly
 	timestamp := time.Now().UTC().Format(time.RFC3339)
-	for encoder, results := range byEncoder {
+	for _, encoder := range sortedNames(byEncoder) {
+		results := byEncoder[encoder]
+		sortResults(results)
+		// Axes cover every result that ran, before FailuresOnly drops any
 		data := RawResults{
-			Timestamp: timestamp,
-			Results:   results,
+			Timestamp:    timestamp,
+			ExecutedAxes: executedAxes(results),
+			Results:      results,
 		}
-		filename := filepath.Join(encoderDir, sanitizeFilename(encoder)+".json")
+		if r.FailuresOnly {
+			data.Results = failedResults(results)
+			if len(data.Results) == 0 {
+				continue
+			}
+		}
+		filename := filepath.Join(encoderDir, sanitizeFilename(encoder)+r.fileExt())
 		if err := r.writeJSON(filename, data); err != nil {
 			return err
 		}
@@ -99,18 +215,27 @@ func (r *JSONReporter) generateDecoderFiles(m *matrix.CompatibilityMatrix) error
 	// Group results by decoder
 	byDecoder := make(map[string][]RawTestResult)
 	for _, result := range m.Results {
-		raw := convertResult(result)
-		byDecoder[result.DecoderName] = append(byDecoder[result.DecoderName], raw)
+		byDecoder[result.DecoderName] = append(byDecoder[result.DecoderName], convertResult(result))
 	}
 
-	// Write one file per decoder
+	// Write one file per decoder, in name order so runs diff cleanly
 	timestamp := time.Now().UTC().Format(time.RFC3339)
-	for decoder, results := range byDecoder {
+	for _, decoder := range sortedNames(byDecoder) {
+		results := byDecoder[decoder]
+		sortResults(results)
+		// Axes cover every result that ran, before FailuresOnly drops any
 		data := RawResults{
-			Timestamp: timestamp,
-			Results:   results,
+			Timestamp:    timestamp,
+			ExecutedAxes: executedAxes(results),
+			Results:      results,
+		}
+		if r.FailuresOnly {
+			data.Results = failedResults(results)
+			if len(data.Results) == 0 {
+				continue
+			}
 		}
-		filename := filepath.Join(decoderDir, sanitizeFilename(decoder)+".json")
+		filename := filepath.Join(decoderDir, sanitizeFilename(decoder)+r.fileExt())
 		if err := r.writeJSON(filename, data); err != nil {
 			return err
 		}
@@ -119,6 +244,73 @@ func (r *JSONReporter) generateDecoderFiles(m *matrix.CompatibilityMatrix) error
 	return nil
 }
 
+// failedResults returns the results that failed (IsFailure).
+func failedResults(results []RawTestResult) []RawTestResult {
+	var failed []RawTestResult
+	for _, r := range results {
+		if r.IsFailure() {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// sortedNames returns the keys of a grouped result map in ascending order.
+func sortedNames(groups map[string][]RawTestResult) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortResults orders results by encoder, decoder, data size, pixel size,
+// content type, and error correction level. Parallel runs finish in
+// arbitrary order; sorting keeps output files stable between runs.
+func sortResults(results []RawTestResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Encoder != b.Encoder {
+			return a.Encoder < b.Encoder
+		}
+		if a.Decoder != b.Decoder {
+			return a.Decoder < b.Decoder
+		}
+		if a.DataSize != b.DataSize {
+			return a.DataSize < b.DataSize
+		}
+		if a.PixelSize != b.PixelSize {
+			return a.PixelSize < b.PixelSize
+		}
+		if a.ContentType != b.ContentType {
+			return a.ContentType < b.ContentType
+		}
+		if a.ErrorCorrectionLevel != b.ErrorCorrectionLevel {
+			return a.ErrorCorrectionLevel < b.ErrorCorrectionLevel
+		}
+		if a.Seed != b.Seed {
+			return a.Seed < b.Seed
+		}
+		if a.Repeat != b.Repeat {
+			return a.Repeat < b.Repeat
+		}
+		if a.CenterOcclusion != b.CenterOcclusion {
+			return a.CenterOcclusion < b.CenterOcclusion
+		}
+		return a.CorruptFraction < b.CorruptFraction
+	})
+}
+
+// ConvertResults converts every result in the matrix to its JSON form.
+func ConvertResults(m *matrix.CompatibilityMatrix) []RawTestResult {
+	raw := make([]RawTestResult, len(m.Results))
+	for i, result := range m.Results {
+		raw[i] = convertResult(result)
+	}
+	return raw
+}
+
 // convertResult converts a matrix.TestResult to RawTestResult.
 func convertResult(result matrix.TestResult) RawTestResult {
 	raw := RawTestResult{
@@ -128,22 +320,58 @@ func convertResult(result matrix.TestResult) RawTestResult {
 		PixelSize:            result.PixelSize,
 		ContentType:          result.ContentType,
 		ErrorCorrectionLevel: result.ErrorCorrectionLevel,
+		Seed:                 result.Seed,
+		Repeat:               result.Repeat,
+		CenterOcclusion:      result.CenterOcclusion,
+		CorruptFraction:      result.CorruptFraction,
 		Success:              result.Error == nil,
 		IsCapacityExceeded:   result.IsCapacityExceeded,
+		IsBlindSpot:          result.IsBlindSpot,
 		EncodeTimeMs:         toMilliseconds(result.EncodeTime),
+		QRConstructTimeMs:    toMilliseconds(result.QRConstructTime),
+		ImageEncodeTimeMs:    toMilliseconds(result.ImageEncodeTime),
+		ImageDecodeTimeMs:    toMilliseconds(result.ImageDecodeTime),
 		DecodeTimeMs:         toMilliseconds(result.DecodeTime),
+		IsDecodeOutlier:      result.IsDecodeOutlier,
+		CharsetMismatch:      result.CharsetMismatch,
 		QRVersion:            result.QRVersion,
+		VersionMismatch:      result.VersionMismatch,
 		ModuleCount:          result.ModuleCount,
+		CapacityUtilization:  result.CapacityUtilization,
+		IsMicroQR:            result.IsMicroQR,
 		ModulePixelSize:      result.ModulePixelSize,
 		IsFractionalModule:   result.IsFractionalModule,
 	}
 
+	if result.Decoded {
+		length := result.DecodedLength
+		raw.DecodedLength = &length
+	}
+
+	if result.MaskDetected {
+		mask := result.Mask
+		raw.Mask = &mask
+	}
+
 	if result.Error != nil {
 		raw.ErrorMsg = result.Error.Error()
 
 		var encErr matrix.EncodeError
 		if errors.As(result.Error, &encErr) {
 			raw.ErrorType = "encode"
+			if result.IsCapacityExceeded {
+				raw.ErrorType = "capacity"
+			}
+		}
+
+		var emptyErr matrix.EmptyDataError
+		if errors.As(result.Error, &emptyErr) {
+			raw.ErrorType = "emptyData"
+		}
+
+		var unsupportedErr matrix.UnsupportedContentError
+		if errors.As(result.Error, &unsupportedErr) {
+			raw.ErrorType = "unsupported"
 		}
 
 		var decErr matrix.DecodeError
@@ -155,18 +383,55 @@ func convertResult(result matrix.TestResult) RawTestResult {
 		if errors.As(result.Error, &dataErr) {
 			raw.ErrorType = "dataMismatch"
 		}
+
+		var sizeErr matrix.UndersizedError
+		if errors.As(result.Error, &sizeErr) {
+			raw.ErrorType = "undersized"
+		}
+
+		var timeoutErr matrix.TimeoutError
+		if errors.As(result.Error, &timeoutErr) {
+			raw.ErrorType = "timeout"
+		}
+
+		var panicErr matrix.PanicError
+		if errors.As(result.Error, &panicErr) {
+			raw.ErrorType = "panic"
+		}
 	}
 
 	return raw
 }
 
-// writeJSON writes data to a JSON file with pretty formatting.
+// fileExt returns the results file extension: .json, or .json.gz when
+// compressing.
+func (r *JSONReporter) fileExt() string {
+	if r.Compress {
+		return ".json.gz"
+	}
+	return ".json"
+}
+
+// writeJSON writes data to a JSON file with pretty formatting, gzipped
+// when r.Compress is set.
 func (r *JSONReporter) writeJSON(path string, data interface{}) error {
 	content, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
+	if r.Compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(content); err != nil {
+			return fmt.Errorf("failed to compress %s: %w", path, err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to compress %s: %w", path, err)
+		}
+		content = buf.Bytes()
+	}
+
 	if err := os.WriteFile(path, content, 0644); err != nil {
 		return fmt.Errorf("failed to write file %s: %w", path, err)
 	}
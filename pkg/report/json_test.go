@@ -0,0 +1,332 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+func TestConvertResult_ErrorType(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   matrix.TestResult
+		wantType string
+	}{
+		{
+			name:     "success",
+			result:   matrix.TestResult{},
+			wantType: "",
+		},
+		{
+			name:     "encode",
+			result:   matrix.TestResult{Error: matrix.EncodeError{Err: errors.New("bug")}},
+			wantType: "encode",
+		},
+		{
+			name:     "capacity",
+			result:   matrix.TestResult{Error: matrix.EncodeError{Err: errors.New("too long")}, IsCapacityExceeded: true},
+			wantType: "capacity",
+		},
+		{
+			name:     "empty data",
+			result:   matrix.TestResult{Error: matrix.EmptyDataError{Err: errors.New("no data")}, IsCapacityExceeded: true},
+			wantType: "emptyData",
+		},
+		{
+			name:     "unsupported",
+			result:   matrix.TestResult{Error: matrix.UnsupportedContentError{ContentType: "binary"}, IsUnsupported: true},
+			wantType: "unsupported",
+		},
+		{
+			name:     "decode",
+			result:   matrix.TestResult{Error: matrix.DecodeError{Err: errors.New("not found")}},
+			wantType: "decode",
+		},
+		{
+			name:     "data mismatch",
+			result:   matrix.TestResult{Error: matrix.DataMismatchError{Expected: 10, Got: 12}},
+			wantType: "dataMismatch",
+		},
+		{
+			name:     "undersized",
+			result:   matrix.TestResult{Error: matrix.UndersizedError{ModulePixelSize: 0.5, Minimum: 2}},
+			wantType: "undersized",
+		},
+		{
+			name:     "timeout",
+			result:   matrix.TestResult{Error: matrix.TimeoutError{Stage: "decode", Timeout: time.Second}},
+			wantType: "timeout",
+		},
+		{
+			name:     "panic",
+			result:   matrix.TestResult{Error: matrix.PanicError{Stage: "decode", Value: "index out of range"}},
+			wantType: "panic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := convertResult(tt.result)
+			if raw.ErrorType != tt.wantType {
+				t.Errorf("convertResult() ErrorType = %q, want %q", raw.ErrorType, tt.wantType)
+			}
+			if raw.Success != (tt.result.Error == nil) {
+				t.Errorf("convertResult() Success = %v, want %v", raw.Success, tt.result.Error == nil)
+			}
+		})
+	}
+}
+
+func TestConvertResult_EncodePhaseTimings(t *testing.T) {
+	raw := convertResult(matrix.TestResult{
+		EncodeTime:      10 * time.Millisecond,
+		QRConstructTime: 2 * time.Millisecond,
+		ImageEncodeTime: 5 * time.Millisecond,
+		ImageDecodeTime: 2500 * time.Microsecond,
+	})
+	if raw.QRConstructTimeMs != 2 || raw.ImageEncodeTimeMs != 5 || raw.ImageDecodeTimeMs != 2.5 {
+		t.Errorf("Phase timings = %v/%v/%v ms, want 2/5/2.5", raw.QRConstructTimeMs, raw.ImageEncodeTimeMs, raw.ImageDecodeTimeMs)
+	}
+
+	out, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{`"qrConstructTimeMs":2`, `"imageEncodeTimeMs":5`, `"imageDecodeTimeMs":2.5`} {
+		if !strings.Contains(string(out), key) {
+			t.Errorf("JSON missing %s: %s", key, out)
+		}
+	}
+
+	// Uninstrumented encoders leave the phases out
+	out, err = json.Marshal(convertResult(matrix.TestResult{EncodeTime: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "qrConstructTimeMs") {
+		t.Errorf("JSON has phase timings for an uninstrumented encoder: %s", out)
+	}
+}
+
+func TestConvertResult_Mask(t *testing.T) {
+	// A zero-valued result never had its format information read
+	if raw := convertResult(matrix.TestResult{}); raw.Mask != nil {
+		t.Errorf("Mask = %d for a result without a detected mask, want nil", *raw.Mask)
+	}
+
+	raw := convertResult(matrix.TestResult{Mask: 0, MaskDetected: true})
+	if raw.Mask == nil || *raw.Mask != 0 {
+		t.Errorf("Mask = %v for a detected mask 0, want 0", raw.Mask)
+	}
+}
+
+func TestJSONReporter_Generate_SortedResults(t *testing.T) {
+	dir := t.TempDir()
+
+	// Results in the scrambled order parallel workers might finish
+	m := &matrix.CompatibilityMatrix{
+		Results: []matrix.TestResult{
+			{EncoderName: "enc", DecoderName: "dec-b", DataSize: 100, PixelSize: 320, ContentType: "numeric"},
+			{EncoderName: "enc", DecoderName: "dec-a", DataSize: 300, PixelSize: 320, ContentType: "numeric"},
+			{EncoderName: "enc", DecoderName: "dec-a", DataSize: 100, PixelSize: 480, ContentType: "numeric"},
+			{EncoderName: "enc", DecoderName: "dec-a", DataSize: 100, PixelSize: 320, ContentType: "utf8"},
+			{EncoderName: "enc", DecoderName: "dec-a", DataSize: 100, PixelSize: 320, ContentType: "binary"},
+		},
+	}
+
+	if err := NewJSONReporter(dir).Generate(m); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "encoders", "enc.json"))
+	if err != nil {
+		t.Fatalf("Failed to read encoder file: %v", err)
+	}
+
+	var raw RawResults
+	if err := json.Unmarshal(content, &raw); err != nil {
+		t.Fatalf("Failed to parse encoder file: %v", err)
+	}
+
+	want := []struct {
+		decoder     string
+		dataSize    int
+		pixelSize   int
+		contentType string
+	}{
+		{"dec-a", 100, 320, "binary"},
+		{"dec-a", 100, 320, "utf8"},
+		{"dec-a", 100, 480, "numeric"},
+		{"dec-a", 300, 320, "numeric"},
+		{"dec-b", 100, 320, "numeric"},
+	}
+
+	if len(raw.Results) != len(want) {
+		t.Fatalf("Encoder file has %d results, want %d", len(raw.Results), len(want))
+	}
+	for i, w := range want {
+		got := raw.Results[i]
+		if got.Decoder != w.decoder || got.DataSize != w.dataSize || got.PixelSize != w.pixelSize || got.ContentType != w.contentType {
+			t.Errorf("Result %d = %s/%d/%d/%s, want %s/%d/%d/%s", i,
+				got.Decoder, got.DataSize, got.PixelSize, got.ContentType,
+				w.decoder, w.dataSize, w.pixelSize, w.contentType)
+		}
+	}
+}
+
+func TestJSONReporter_Generate_Compressed(t *testing.T) {
+	m := &matrix.CompatibilityMatrix{
+		Results: []matrix.TestResult{
+			{EncoderName: "enc/a", DecoderName: "dec", DataSize: 100, PixelSize: 320, ContentType: "numeric", ErrorCorrectionLevel: "M"},
+			{EncoderName: "enc/a", DecoderName: "dec", DataSize: 200, PixelSize: 320, ContentType: "binary", ErrorCorrectionLevel: "M",
+				Error: matrix.DecodeError{Err: errors.New("not found")}},
+			{EncoderName: "enc/b", DecoderName: "dec", DataSize: 100, PixelSize: 480, ContentType: "utf8", ErrorCorrectionLevel: "H"},
+		},
+	}
+
+	plainDir := t.TempDir()
+	if err := NewJSONReporter(plainDir).Generate(m); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	gzDir := t.TempDir()
+	reporter := NewJSONReporter(gzDir)
+	reporter.Compress = true
+	if err := reporter.Generate(m); err != nil {
+		t.Fatalf("Generate() with Compress failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(gzDir, "encoders", "enc_a.json.gz")); err != nil {
+		t.Fatalf("Compressed encoder file missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(gzDir, "encoders", "enc_a.json")); !os.IsNotExist(err) {
+		t.Errorf("Compressed run also wrote enc_a.json")
+	}
+
+	plain, err := LoadResults(plainDir)
+	if err != nil {
+		t.Fatalf("LoadResults(plain) failed: %v", err)
+	}
+	compressed, err := LoadResults(gzDir)
+	if err != nil {
+		t.Fatalf("LoadResults(compressed) failed: %v", err)
+	}
+
+	if len(compressed) != 3 {
+		t.Fatalf("LoadResults(compressed) returned %d results, want 3", len(compressed))
+	}
+	if !reflect.DeepEqual(compressed, plain) {
+		t.Errorf("Compressed results differ from plain:\n got %+v\nwant %+v", compressed, plain)
+	}
+}
+
+func TestJSONReporter_Generate_FailuresOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	m := &matrix.CompatibilityMatrix{
+		Results: []matrix.TestResult{
+			// enc-pass → dec-pass passes everything
+			{EncoderName: "enc-pass", DecoderName: "dec-pass", DataSize: 100, PixelSize: 320, ContentType: "numeric"},
+			{EncoderName: "enc-pass", DecoderName: "dec-pass", DataSize: 200, PixelSize: 320, ContentType: "numeric"},
+			// Capacity skips are not failures
+			{EncoderName: "enc-pass", DecoderName: "dec-pass", DataSize: 3000, PixelSize: 320, ContentType: "numeric",
+				Error: matrix.EncodeError{Err: errors.New("too long")}, IsCapacityExceeded: true},
+			// enc-fail → dec-fail has one failure
+			{EncoderName: "enc-fail", DecoderName: "dec-fail", DataSize: 100, PixelSize: 320, ContentType: "numeric"},
+			{EncoderName: "enc-fail", DecoderName: "dec-fail", DataSize: 200, PixelSize: 320, ContentType: "numeric",
+				Error: matrix.DecodeError{Err: errors.New("not found")}},
+		},
+	}
+
+	reporter := NewJSONReporter(dir)
+	reporter.FailuresOnly = true
+	if err := reporter.Generate(m); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	for _, name := range []string{"encoders/enc-pass.json", "decoders/dec-pass.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s written for an all-passing pair", name)
+		}
+	}
+
+	for _, name := range []string{"encoders/enc-fail.json", "decoders/dec-fail.json"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		var raw RawResults
+		if err := json.Unmarshal(content, &raw); err != nil {
+			t.Fatalf("Failed to parse %s: %v", name, err)
+		}
+		if len(raw.Results) != 1 || raw.Results[0].Success || raw.Results[0].DataSize != 200 {
+			t.Errorf("%s results = %+v, want only the 200-byte failure", name, raw.Results)
+		}
+	}
+}
+
+func TestJSONReporter_Generate_ExecutedAxes(t *testing.T) {
+	dir := t.TempDir()
+
+	// The configured pixel sizes were 240, 320, and 400; sampling kept
+	// only 240 and 400. A 320px capacity skip never ran and is left out
+	m := &matrix.CompatibilityMatrix{
+		Results: []matrix.TestResult{
+			{EncoderName: "enc", DecoderName: "dec", DataSize: 200, PixelSize: 400, ContentType: "numeric", ErrorCorrectionLevel: "H"},
+			{EncoderName: "enc", DecoderName: "dec", DataSize: 100, PixelSize: 240, ContentType: "alphanumeric", ErrorCorrectionLevel: "L"},
+			{EncoderName: "enc", DecoderName: "dec", DataSize: 100, PixelSize: 400, ContentType: "numeric", ErrorCorrectionLevel: "M",
+				Error: matrix.DecodeError{Err: errors.New("not found")}},
+			{EncoderName: "enc", DecoderName: "dec", DataSize: 3000, PixelSize: 320, ContentType: "binary", ErrorCorrectionLevel: "Q",
+				Error: matrix.EncodeError{Err: errors.New("too long")}, IsCapacityExceeded: true},
+		},
+	}
+
+	reporter := NewJSONReporter(dir)
+	if err := reporter.Generate(m); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	want := &ExecutedAxes{
+		DataSizes:             []int{100, 200},
+		PixelSizes:            []int{240, 400},
+		ContentTypes:          []string{"alphanumeric", "numeric"},
+		ErrorCorrectionLevels: []string{"L", "M", "H"},
+	}
+	for _, name := range []string{"encoders/enc.json", "decoders/dec.json"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		var raw RawResults
+		if err := json.Unmarshal(content, &raw); err != nil {
+			t.Fatalf("Failed to parse %s: %v", name, err)
+		}
+		if !reflect.DeepEqual(raw.ExecutedAxes, want) {
+			t.Errorf("%s executedAxes = %+v, want %+v", name, raw.ExecutedAxes, want)
+		}
+	}
+
+	// Dropping passes keeps the axes of everything that ran
+	reporter.FailuresOnly = true
+	if err := reporter.Generate(m); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "encoders/enc.json"))
+	if err != nil {
+		t.Fatalf("Failed to read enc.json: %v", err)
+	}
+	var raw RawResults
+	if err := json.Unmarshal(content, &raw); err != nil {
+		t.Fatalf("Failed to parse enc.json: %v", err)
+	}
+	if len(raw.Results) != 1 || !reflect.DeepEqual(raw.ExecutedAxes, want) {
+		t.Errorf("FailuresOnly enc.json = %d results, executedAxes %+v; want 1 result and %+v", len(raw.Results), raw.ExecutedAxes, want)
+	}
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+// JSONLFile is the file JSONLReporter writes in its output directory.
+const JSONLFile = "results.jsonl"
+
+// JSONLReporter writes every result as one compact JSON object per line,
+// for loading into data pipelines (Spark, BigQuery) that read JSON Lines
+// rather than a single array. Each line is a RawTestResult.
+type JSONLReporter struct {
+	OutputDir string
+
+	// FailuresOnly keeps only failed results (IsFailure).
+	FailuresOnly bool
+}
+
+// NewJSONLReporter creates a JSON Lines reporter that writes to the specified directory.
+func NewJSONLReporter(outputDir string) *JSONLReporter {
+	return &JSONLReporter{
+		OutputDir: outputDir,
+	}
+}
+
+// Generate writes results.jsonl, one result per line in sortResults order.
+// Results are encoded as they are written, so the whole file is never held
+// in memory.
+func (r *JSONLReporter) Generate(m *matrix.CompatibilityMatrix) error {
+	if err := os.MkdirAll(r.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	results := make([]RawTestResult, 0, len(m.Results))
+	for _, result := range m.Results {
+		raw := convertResult(result)
+		if r.FailuresOnly && !raw.IsFailure() {
+			continue
+		}
+		results = append(results, raw)
+	}
+	sortResults(results)
+
+	path := filepath.Join(r.OutputDir, JSONLFile)
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	// Encode appends the newline that ends each line
+	enc := json.NewEncoder(w)
+	for _, raw := range results {
+		if err := enc.Encode(raw); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+func TestJSONLReporter_Generate(t *testing.T) {
+	m := &matrix.CompatibilityMatrix{
+		Results: []matrix.TestResult{
+			{EncoderName: "enc/b", DecoderName: "dec/a", DataSize: 100, PixelSize: 400, ContentType: "binary", ErrorCorrectionLevel: "M"},
+			{EncoderName: "enc/a", DecoderName: "dec/a", DataSize: 100, PixelSize: 400, ContentType: "binary", ErrorCorrectionLevel: "M", Mask: 3, MaskDetected: true},
+			{EncoderName: "enc/a", DecoderName: "dec/b", DataSize: 100, PixelSize: 440, ContentType: "binary", ErrorCorrectionLevel: "M",
+				Error: matrix.DecodeError{Err: errors.New("not found")}},
+		},
+	}
+
+	dir := t.TempDir()
+	if err := NewJSONLReporter(dir).Generate(m); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	f, err := os.Open(filepath.Join(dir, JSONLFile))
+	if err != nil {
+		t.Fatalf("Open(%s) error = %v", JSONLFile, err)
+	}
+	defer f.Close()
+
+	var lines []RawTestResult
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r RawTestResult
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("line %d does not parse as a RawTestResult: %v\n%s", len(lines)+1, err, scanner.Text())
+		}
+		lines = append(lines, r)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("reading %s: %v", JSONLFile, err)
+	}
+
+	if len(lines) != len(m.Results) {
+		t.Fatalf("%s has %d lines, want %d", JSONLFile, len(lines), len(m.Results))
+	}
+
+	// Sorted like the JSON files
+	if lines[0].Encoder != "enc/a" || lines[0].Decoder != "dec/a" || lines[2].Encoder != "enc/b" {
+		t.Errorf("lines out of order: %+v", lines)
+	}
+	if lines[1].ErrorType != "decode" || lines[1].Success {
+		t.Errorf("failed result = %+v, want a decode failure", lines[1])
+	}
+	if lines[0].Mask == nil || *lines[0].Mask != 3 {
+		t.Errorf("Mask = %v, want 3", lines[0].Mask)
+	}
+}
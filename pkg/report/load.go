@@ -0,0 +1,140 @@
+package report
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadResults reads all JSON result files written by JSONReporter from dir,
+// plain (.json) or compressed (.json.gz).
+// Results are read from the per-encoder files and deduplicated, since each
+// result also appears in a per-decoder file.
+func LoadResults(dir string) ([]RawTestResult, error) {
+	var allResults []RawTestResult
+
+	// Load from encoders directory
+	encodersDir := filepath.Join(dir, "encoders")
+	if err := loadResultsFromDir(encodersDir, &allResults); err != nil {
+		return nil, err
+	}
+
+	// Deduplicate (since we only need one copy of each result)
+	seen := make(map[string]bool)
+	var unique []RawTestResult
+	for _, r := range allResults {
+		key := resultKey(r)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, r)
+		}
+	}
+
+	return unique, nil
+}
+
+// resultKey identifies a test by its encoder, decoder, and test case.
+// Seed sweep and repeated run results also differ by seed and repeat.
+func resultKey(r RawTestResult) string {
+	key := fmt.Sprintf("%s|%s|%d|%d|%s|%s", r.Encoder, r.Decoder, r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel)
+	if r.Seed != 0 {
+		key += fmt.Sprintf("|seed%d", r.Seed)
+	}
+	if r.Repeat != 0 {
+		key += fmt.Sprintf("|repeat%d", r.Repeat)
+	}
+	if r.CenterOcclusion != 0 {
+		key += fmt.Sprintf("|occlusion%g", r.CenterOcclusion)
+	}
+	if r.CorruptFraction != 0 {
+		key += fmt.Sprintf("|corrupt%g", r.CorruptFraction)
+	}
+	return key
+}
+
+// loadResultsFromDir appends the results of every JSON file in dir.
+// A missing directory is not an error.
+func loadResultsFromDir(dir string, results *[]RawTestResult) error {
+	files, err := readResultFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, raw := range files {
+		*results = append(*results, raw.Results...)
+	}
+
+	return nil
+}
+
+// readResultFiles parses every .json and .json.gz file in dir, in name order,
+// and warns about results that fail ValidateResults.
+// A missing directory is not an error.
+func readResultFiles(dir string) ([]RawResults, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []RawResults
+	for _, entry := range entries {
+		if entry.IsDir() || !IsResultFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := ReadResultFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var raw RawResults
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		for _, verr := range ValidateResults(raw) {
+			fmt.Fprintf(warningOutput, "Warning: %s: %v\n", path, verr)
+		}
+
+		files = append(files, raw)
+	}
+
+	return files, nil
+}
+
+// IsResultFile reports whether name is a results file: .json or .json.gz.
+func IsResultFile(name string) bool {
+	return strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json.gz")
+}
+
+// ReadResultFile returns the JSON content of a results file, decompressing
+// it when the name ends in .gz.
+func ReadResultFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return data, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	data, err = io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing %s: %w", path, err)
+	}
+	return data, nil
+}
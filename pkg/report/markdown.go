@@ -0,0 +1,279 @@
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Success rate thresholds for the combination grid emoji.
+const (
+	gridGoodRate = 95.0
+	gridFairRate = 70.0
+)
+
+// WriteCombinationGrid writes an encoder × decoder markdown table of
+// effective success rates. Rows are encoders, columns are decoders.
+// Each cell is color-coded: 🟢 ≥95%, 🟡 ≥70%, 🔴 below 70%.
+// Pairs without effective tests (all capacity skips or not run) show "—".
+func WriteCombinationGrid(w io.Writer, combinations []CombinationRate) error {
+	var b strings.Builder
+	encoders, decoders, rate := pivotCombinations(combinations)
+
+	b.WriteString("| Encoder \\ Decoder |")
+	for _, dec := range decoders {
+		fmt.Fprintf(&b, " %s |", dec)
+	}
+	b.WriteString("\n|---|")
+	for range decoders {
+		b.WriteString("---|")
+	}
+	b.WriteString("\n")
+
+	for _, enc := range encoders {
+		fmt.Fprintf(&b, "| **%s** |", enc)
+		for _, dec := range decoders {
+			c, ok := rate(enc, dec)
+			if !ok {
+				b.WriteString(" — |")
+				continue
+			}
+			fmt.Fprintf(&b, " %s %.1f%% |", rateEmoji(c.SuccessRate), c.SuccessRate)
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCompatibilityTable writes the encoder × decoder success rates as a
+// GitHub-flavored markdown table: one row per encoder, one column per
+// decoder, plain percentages right-aligned under a separator row. Unlike
+// WriteCombinationGrid the columns are padded to equal width, so the source
+// reads as a table too. Pairs without effective tests show "—".
+func WriteCompatibilityTable(w io.Writer, combinations []CombinationRate) error {
+	encoders, decoders, rate := pivotCombinations(combinations)
+
+	rows := make([][]string, 0, len(encoders)+1)
+	header := []string{"Encoder"}
+	for _, dec := range decoders {
+		header = append(header, escapeCell(dec))
+	}
+	rows = append(rows, header)
+	for _, enc := range encoders {
+		row := []string{escapeCell(enc)}
+		for _, dec := range decoders {
+			c, ok := rate(enc, dec)
+			if !ok {
+				row = append(row, "—")
+				continue
+			}
+			row = append(row, fmt.Sprintf("%.1f%%", c.SuccessRate))
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell), 3)
+		}
+	}
+
+	var b strings.Builder
+	for r, row := range rows {
+		b.WriteString("|")
+		for i, cell := range row {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			if i == 0 || r == 0 {
+				fmt.Fprintf(&b, " %s%s |", cell, pad)
+			} else {
+				fmt.Fprintf(&b, " %s%s |", pad, cell)
+			}
+		}
+		b.WriteString("\n")
+
+		if r == 0 {
+			// Encoder names left-aligned, rates right-aligned
+			b.WriteString("|")
+			for i, width := range widths {
+				if i == 0 {
+					fmt.Fprintf(&b, " :%s |", strings.Repeat("-", width-1))
+				} else {
+					fmt.Fprintf(&b, " %s: |", strings.Repeat("-", width-1))
+				}
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// pivotCombinations returns the encoders and decoders in combinations, each
+// sorted, and a lookup of one pair's rate. The lookup's ok is false for
+// pairs without effective tests (all capacity skips or not run).
+func pivotCombinations(combinations []CombinationRate) (encoders, decoders []string, rate func(enc, dec string) (CombinationRate, bool)) {
+	encSet := make(map[string]bool)
+	decSet := make(map[string]bool)
+	byPair := make(map[string]CombinationRate)
+	for _, c := range combinations {
+		encSet[c.Encoder] = true
+		decSet[c.Decoder] = true
+		byPair[c.Encoder+"|"+c.Decoder] = c
+	}
+
+	rate = func(enc, dec string) (CombinationRate, bool) {
+		c, ok := byPair[enc+"|"+dec]
+		return c, ok && c.EffectiveTests > 0
+	}
+	return sortedKeys(encSet), sortedKeys(decSet), rate
+}
+
+// escapeCell escapes pipes so s stays in one markdown table cell.
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// WriteVersionSelection writes a markdown table of the QR version each
+// encoder chose per data size, content type, and EC level, followed by each
+// encoder's average version. Encoders choosing several versions for one
+// payload show them all ("14/15"); encoders without a version show "—".
+func WriteVersionSelection(w io.Writer, selections []VersionSelection, avg map[string]float64) error {
+	var b strings.Builder
+
+	if len(selections) == 0 {
+		b.WriteString("No QR version data.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	encSet := make(map[string]bool)
+	for _, sel := range selections {
+		for enc := range sel.Versions {
+			encSet[enc] = true
+		}
+	}
+	encoders := sortedKeys(encSet)
+
+	b.WriteString("| Data Size | Content | EC |")
+	for _, enc := range encoders {
+		fmt.Fprintf(&b, " %s |", enc)
+	}
+	b.WriteString("\n|---|---|---|")
+	for range encoders {
+		b.WriteString("---|")
+	}
+	b.WriteString("\n")
+
+	for _, sel := range selections {
+		fmt.Fprintf(&b, "| %d | %s | %s |", sel.DataSize, sel.ContentType, sel.ErrorCorrectionLevel)
+		for _, enc := range encoders {
+			versions := sel.Versions[enc]
+			if len(versions) == 0 {
+				b.WriteString(" — |")
+				continue
+			}
+			parts := make([]string, len(versions))
+			for i, v := range versions {
+				parts[i] = fmt.Sprintf("%d", v)
+			}
+			fmt.Fprintf(&b, " %s |", strings.Join(parts, "/"))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("| **Average** | | |")
+	for _, enc := range encoders {
+		fmt.Fprintf(&b, " %.1f |", avg[enc])
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteFirstFailingVersions writes a decoder × pixel size markdown table of
+// the smallest QR version each decoder failed to read. Cells where every
+// version decoded show "✓ ≤N" with the largest version tested; untested
+// cells show "—".
+func WriteFirstFailingVersions(w io.Writer, first []FirstFailingVersion) error {
+	var b strings.Builder
+
+	if len(first) == 0 {
+		b.WriteString("No QR version data.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	decSet := make(map[string]bool)
+	sizeSet := make(map[int]bool)
+	byCell := make(map[string]FirstFailingVersion)
+	for _, f := range first {
+		decSet[f.Decoder] = true
+		sizeSet[f.PixelSize] = true
+		byCell[fmt.Sprintf("%s|%d", f.Decoder, f.PixelSize)] = f
+	}
+	sizes := make([]int, 0, len(sizeSet))
+	for size := range sizeSet {
+		sizes = append(sizes, size)
+	}
+	sort.Ints(sizes)
+
+	b.WriteString("| Decoder \\ Pixel Size |")
+	for _, size := range sizes {
+		fmt.Fprintf(&b, " %dpx |", size)
+	}
+	b.WriteString("\n|---|")
+	for range sizes {
+		b.WriteString("---|")
+	}
+	b.WriteString("\n")
+
+	for _, dec := range sortedKeys(decSet) {
+		fmt.Fprintf(&b, "| **%s** |", dec)
+		for _, size := range sizes {
+			f, ok := byCell[fmt.Sprintf("%s|%d", dec, size)]
+			switch {
+			case !ok:
+				b.WriteString(" — |")
+			case f.Version == 0:
+				fmt.Fprintf(&b, " ✓ ≤%d |", f.MaxTestedVersion)
+			default:
+				fmt.Fprintf(&b, " %d |", f.Version)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// rateEmoji returns the grid color for a success percentage.
+func rateEmoji(rate float64) string {
+	switch {
+	case rate >= gridGoodRate:
+		return "🟢"
+	case rate >= gridFairRate:
+		return "🟡"
+	default:
+		return "🔴"
+	}
+}
+
+// sortedKeys returns the keys of set in ascending order.
+func sortedKeys[T cmp.Ordered](set map[T]bool) []T {
+	keys := make([]T, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
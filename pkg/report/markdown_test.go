@@ -0,0 +1,184 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWriteCombinationGrid(t *testing.T) {
+	combinations := []CombinationRate{
+		{Encoder: "enc-a", Decoder: "dec-x", Successes: 99, EffectiveTests: 100, SuccessRate: 99},
+		{Encoder: "enc-a", Decoder: "dec-y", Successes: 80, EffectiveTests: 100, SuccessRate: 80},
+		{Encoder: "enc-b", Decoder: "dec-x", Successes: 50, EffectiveTests: 100, SuccessRate: 50},
+		{Encoder: "enc-b", Decoder: "dec-y", Tests: 4, CapacitySkips: 4},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCombinationGrid(&buf, combinations); err != nil {
+		t.Fatalf("WriteCombinationGrid() failed: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		"| Encoder \\ Decoder | dec-x | dec-y |",
+		"| **enc-a** | 🟢 99.0% | 🟡 80.0% |",
+		"| **enc-b** | 🔴 50.0% | — |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("grid missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteCompatibilityTable(t *testing.T) {
+	combinations := []CombinationRate{
+		{Encoder: "skip2/go-qrcode", Decoder: "dec-x", Successes: 99, EffectiveTests: 100, SuccessRate: 99},
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", Successes: 80, EffectiveTests: 100, SuccessRate: 80},
+		{Encoder: "enc|b", Decoder: "dec-x", Successes: 100, EffectiveTests: 100, SuccessRate: 100},
+		{Encoder: "enc|b", Decoder: "makiuchi-d/gozxing", Tests: 4, CapacitySkips: 4},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCompatibilityTable(&buf, combinations); err != nil {
+		t.Fatalf("WriteCompatibilityTable() failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("table has %d lines, want header, separator, and 2 rows:\n%s", len(lines), buf.String())
+	}
+
+	// Every row has the encoder column plus one per decoder, counting only
+	// unescaped pipes as cell boundaries
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "| ") || !strings.HasSuffix(line, " |") {
+			t.Errorf("line %d %q is not a table row", i, line)
+		}
+		if got := strings.Count(line, "|") - strings.Count(line, `\|`) - 1; got != 3 {
+			t.Errorf("line %d %q has %d columns, want 3", i, line, got)
+		}
+		if i > 0 && utf8.RuneCountInString(line) != utf8.RuneCountInString(lines[0]) {
+			t.Errorf("line %d %q is not aligned with the header %q", i, line, lines[0])
+		}
+	}
+
+	separator := lines[1]
+	for _, cell := range strings.Split(strings.Trim(separator, "| "), " | ") {
+		if strings.Trim(cell, ":-") != "" || !strings.Contains(cell, "---") {
+			t.Errorf("separator cell %q is not a GFM delimiter", cell)
+		}
+	}
+	if !strings.HasPrefix(separator, "| :") || !strings.HasSuffix(separator, ": |") {
+		t.Errorf("separator %q should left-align encoders and right-align rates", separator)
+	}
+
+	want := []string{
+		"| Encoder         | dec-x  | makiuchi-d/gozxing |",
+		"| :-------------- | -----: | -----------------: |",
+		`| enc\|b          | 100.0% |                  — |`,
+		"| skip2/go-qrcode |  99.0% |              80.0% |",
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestRateEmoji(t *testing.T) {
+	tests := []struct {
+		rate float64
+		want string
+	}{
+		{100, "🟢"},
+		{95, "🟢"},
+		{94.9, "🟡"},
+		{70, "🟡"},
+		{69.9, "🔴"},
+		{0, "🔴"},
+	}
+
+	for _, tt := range tests {
+		if got := rateEmoji(tt.rate); got != tt.want {
+			t.Errorf("rateEmoji(%.1f) = %q, want %q", tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestWriteVersionSelection(t *testing.T) {
+	// Same payload, two encoders choosing different versions; each image is
+	// decoded twice but counts once toward the average
+	results := []RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "dec-a", DataSize: 300, PixelSize: 400, ContentType: "alphanumeric", ErrorCorrectionLevel: "L", QRVersion: 7},
+		{Encoder: "skip2/go-qrcode", Decoder: "dec-b", DataSize: 300, PixelSize: 400, ContentType: "alphanumeric", ErrorCorrectionLevel: "L", QRVersion: 7},
+		{Encoder: "yeqown/go-qrcode", Decoder: "dec-a", DataSize: 300, PixelSize: 400, ContentType: "alphanumeric", ErrorCorrectionLevel: "L", QRVersion: 8},
+		{Encoder: "yeqown/go-qrcode", Decoder: "dec-b", DataSize: 300, PixelSize: 400, ContentType: "alphanumeric", ErrorCorrectionLevel: "L", QRVersion: 8},
+		{Encoder: "yeqown/go-qrcode", Decoder: "dec-a", DataSize: 100, PixelSize: 400, ContentType: "alphanumeric", ErrorCorrectionLevel: "L", QRVersion: 4},
+		// Unknown versions are skipped
+		{Encoder: "skip2/go-qrcode", Decoder: "dec-a", DataSize: 100, PixelSize: 400, ContentType: "alphanumeric", ErrorCorrectionLevel: "L", QRVersion: -1},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAnalysisMarkdown(&buf, Analyze(results)); err != nil {
+		t.Fatalf("WriteAnalysisMarkdown() failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"## Encoder Version Selection",
+		"| Data Size | Content | EC | skip2/go-qrcode | yeqown/go-qrcode |",
+		"| 100 | alphanumeric | L | — | 4 |",
+		"| 300 | alphanumeric | L | 7 | 8 |",
+		"| **Average** | | | 7.0 | 6.0 |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Markdown missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteFirstFailingVersions(t *testing.T) {
+	var results []RawTestResult
+	for version := 1; version <= 20; version++ {
+		// dec-a fails from version 12 upward at 400px
+		failing := RawTestResult{Encoder: "enc", Decoder: "dec-a", PixelSize: 400, QRVersion: version, Success: true}
+		if version >= 12 {
+			failing.Success, failing.ErrorType = false, "decode"
+		}
+		results = append(results, failing,
+			RawTestResult{Encoder: "enc", Decoder: "dec-b", PixelSize: 400, QRVersion: version, Success: true})
+	}
+	// Capacity skips and encode failures never reached the decoder
+	results = append(results,
+		RawTestResult{Encoder: "enc", Decoder: "dec-b", PixelSize: 400, QRVersion: 3, ErrorType: "encode"},
+		RawTestResult{Encoder: "enc", Decoder: "dec-b", PixelSize: 400, ErrorType: "capacity", IsCapacityExceeded: true},
+	)
+
+	a := Analyze(results)
+	if len(a.FirstFailing) != 2 {
+		t.Fatalf("FirstFailing = %+v, want 2 entries", a.FirstFailing)
+	}
+	if got := a.FirstFailing[0]; got.Decoder != "dec-a" || got.Version != 12 || got.MaxTestedVersion != 20 {
+		t.Errorf("dec-a first failing = %+v, want version 12 of 20", got)
+	}
+	if got := a.FirstFailing[1]; got.Version != 0 {
+		t.Errorf("dec-b first failing = %+v, want none", got)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAnalysisMarkdown(&buf, a); err != nil {
+		t.Fatalf("WriteAnalysisMarkdown() failed: %v", err)
+	}
+	for _, want := range []string{
+		"## First Failing Version",
+		"| Decoder \\ Pixel Size | 400px |",
+		"| **dec-a** | 12 |",
+		"| **dec-b** | ✓ ≤20 |",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Markdown missing %q\n\nOutput:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+// MarkdownSummaryFile is the summary file MarkdownReporter writes in its
+// output directory; pair reports go in the MarkdownPairsDir subdirectory.
+const (
+	MarkdownSummaryFile = "summary.md"
+	MarkdownPairsDir    = "markdown"
+)
+
+// MarkdownReporter writes a human-readable report: a summary with the
+// combination grid, then one section per encoder/decoder pair listing its
+// failed test cases.
+type MarkdownReporter struct {
+	OutputDir string
+
+	// Output, when set, receives the summary and every pair section as a
+	// single document instead of files in OutputDir, for piping a one-off
+	// check.
+	Output io.Writer
+
+	// FailuresOnly leaves out pairs without failures.
+	FailuresOnly bool
+}
+
+// NewMarkdownReporter creates a markdown reporter that writes to the specified directory.
+func NewMarkdownReporter(outputDir string) *MarkdownReporter {
+	return &MarkdownReporter{
+		OutputDir: outputDir,
+	}
+}
+
+// Generate writes the report to Output when set, otherwise summary.md and
+// one markdown/<encoder>__<decoder>.md per pair in OutputDir.
+func (r *MarkdownReporter) Generate(m *matrix.CompatibilityMatrix) error {
+	results := ConvertResults(m)
+	sortResults(results)
+
+	byPair := make(map[string][]RawTestResult)
+	for _, raw := range results {
+		key := raw.Encoder + "|" + raw.Decoder
+		byPair[key] = append(byPair[key], raw)
+	}
+
+	var pairs []string
+	for _, key := range sortedNames(byPair) {
+		if r.FailuresOnly && !hasFailure(byPair[key]) {
+			continue
+		}
+		pairs = append(pairs, key)
+	}
+
+	var b strings.Builder
+	b.WriteString("# QR Compatibility Report\n\n")
+	if err := writeSummaryMarkdown(&b, Analyze(results)); err != nil {
+		return err
+	}
+
+	if r.Output != nil {
+		for _, key := range pairs {
+			writePairMarkdown(&b, byPair[key])
+		}
+		_, err := io.WriteString(r.Output, b.String())
+		return err
+	}
+
+	pairDir := filepath.Join(r.OutputDir, MarkdownPairsDir)
+	if err := os.MkdirAll(pairDir, 0755); err != nil {
+		return fmt.Errorf("failed to create markdown directory: %w", err)
+	}
+	if err := writeMarkdownFile(filepath.Join(r.OutputDir, MarkdownSummaryFile), b.String()); err != nil {
+		return err
+	}
+
+	for _, key := range pairs {
+		pair := byPair[key]
+		b.Reset()
+		writePairMarkdown(&b, pair)
+		name := sanitizeFilename(pair[0].Encoder) + "__" + sanitizeFilename(pair[0].Decoder) + ".md"
+		if err := writeMarkdownFile(filepath.Join(pairDir, name), b.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSummaryMarkdown writes the headline counts and the compatibility
+// table of every pair.
+func writeSummaryMarkdown(b *strings.Builder, a Analysis) error {
+	b.WriteString("## Summary\n\n")
+	fmt.Fprintf(b, "- **Total tests:** %d\n", a.TotalTests)
+	fmt.Fprintf(b, "- **Capacity skips:** %d\n", a.CapacitySkips)
+	fmt.Fprintf(b, "- **Success rate:** %.1f%% (%d/%d)\n\n", percent(a.Successes, a.EffectiveTests), a.Successes, a.EffectiveTests)
+	if err := WriteCompatibilityTable(b, a.Combinations); err != nil {
+		return err
+	}
+	b.WriteString("\n")
+	return nil
+}
+
+// writePairMarkdown writes one encoder/decoder pair's counts and a table of
+// its failed test cases. results must all share one pair.
+func writePairMarkdown(b *strings.Builder, results []RawTestResult) {
+	c := analyzeCombinations(results)[0]
+
+	fmt.Fprintf(b, "## %s → %s\n\n", c.Encoder, c.Decoder)
+	fmt.Fprintf(b, "- **Tests:** %d (%s)\n", c.Tests, skipCounts(c.CapacitySkips, c.UnsupportedSkips, c.UndersizedSkips))
+	fmt.Fprintf(b, "- **Success rate:** %.1f%% (%d/%d)\n\n", c.SuccessRate, c.Successes, c.EffectiveTests)
+
+	if !hasFailure(results) {
+		b.WriteString("No failures.\n\n")
+		return
+	}
+
+	b.WriteString("| Data Size | Pixel Size | Content | EC | Error |\n")
+	b.WriteString("|-----------|------------|---------|----|-------|\n")
+	for _, r := range results {
+		if !r.IsFailure() {
+			continue
+		}
+		fmt.Fprintf(b, "| %d | %dpx | %s | %s | %s |\n",
+			r.DataSize, r.PixelSize, r.ContentType, r.ErrorCorrectionLevel, escapeCell(r.ErrorMsg))
+	}
+	b.WriteString("\n")
+}
+
+// hasFailure reports whether any result failed.
+func hasFailure(results []RawTestResult) bool {
+	for _, r := range results {
+		if r.IsFailure() {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMarkdownFile writes content to path.
+func writeMarkdownFile(path, content string) error {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+func TestMarkdownReporter_Generate(t *testing.T) {
+	dir := t.TempDir()
+
+	m := &matrix.CompatibilityMatrix{
+		Results: []matrix.TestResult{
+			{EncoderName: "enc/a", DecoderName: "dec/x", DataSize: 100, PixelSize: 256, ContentType: "binary", ErrorCorrectionLevel: "M"},
+			{EncoderName: "enc/a", DecoderName: "dec/y", DataSize: 100, PixelSize: 256, ContentType: "binary", ErrorCorrectionLevel: "M",
+				Error: matrix.DecodeError{Err: errors.New("no code | found")}},
+		},
+	}
+
+	reporter := NewMarkdownReporter(dir)
+	if err := reporter.Generate(m); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	summary, err := os.ReadFile(filepath.Join(dir, MarkdownSummaryFile))
+	if err != nil {
+		t.Fatalf("Failed to read summary: %v", err)
+	}
+	if !strings.Contains(string(summary), "- **Success rate:** 50.0% (1/2)") {
+		t.Errorf("Summary missing success rate:\n%s", summary)
+	}
+	if !strings.Contains(string(summary), "| enc/a   | 100.0% |  0.0% |") {
+		t.Errorf("Summary missing compatibility table row:\n%s", summary)
+	}
+
+	passing, err := os.ReadFile(filepath.Join(dir, MarkdownPairsDir, "enc_a__dec_x.md"))
+	if err != nil {
+		t.Fatalf("Failed to read pair report: %v", err)
+	}
+	if !strings.Contains(string(passing), "No failures.") {
+		t.Errorf("Passing pair report missing %q:\n%s", "No failures.", passing)
+	}
+
+	failing, err := os.ReadFile(filepath.Join(dir, MarkdownPairsDir, "enc_a__dec_y.md"))
+	if err != nil {
+		t.Fatalf("Failed to read pair report: %v", err)
+	}
+	// Pipes in error messages would split the table cell
+	if !strings.Contains(string(failing), `| 100 | 256px | binary | M | decode failed: no code \| found |`) {
+		t.Errorf("Failing pair report missing failure row:\n%s", failing)
+	}
+
+	// FailuresOnly drops the passing pair
+	onlyDir := t.TempDir()
+	reporter = NewMarkdownReporter(onlyDir)
+	reporter.FailuresOnly = true
+	if err := reporter.Generate(m); err != nil {
+		t.Fatalf("Generate() with FailuresOnly failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(onlyDir, MarkdownPairsDir, "enc_a__dec_x.md")); !os.IsNotExist(err) {
+		t.Errorf("FailuresOnly wrote a passing pair report, stat error = %v", err)
+	}
+}
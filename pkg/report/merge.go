@@ -0,0 +1,108 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// MergeConflict records a test that appears in more than one results tree.
+type MergeConflict struct {
+	Key string // encoder|decoder|dataSize|pixelSize|contentType|ecLevel[|seedN][|repeatN][|occlusionF][|corruptF]
+
+	KeptDir       string
+	KeptTimestamp string
+
+	DroppedDir       string
+	DroppedTimestamp string
+
+	// Changed is true when the dropped result's outcome differed from the kept one.
+	Changed bool
+}
+
+// MergeResults combines the results trees written by separate qr-tester runs,
+// such as CI jobs that each test a subset of encoders.
+//
+// Conflict policy: when the same test (encoder, decoder, and test case) appears
+// in more than one tree, the result from the file with the latest timestamp
+// wins. Equal timestamps keep the tree listed last. Use MergeResultsConflicts
+// to see which results were dropped.
+//
+// The merged Timestamp is the latest timestamp seen, and results are sorted
+// like the reporter's output.
+func MergeResults(dirs ...string) (*RawResults, error) {
+	merged, _, err := MergeResultsConflicts(dirs...)
+	return merged, err
+}
+
+// MergeResultsConflicts is MergeResults that also returns every conflict,
+// in the order found.
+func MergeResultsConflicts(dirs ...string) (*RawResults, []MergeConflict, error) {
+	type entry struct {
+		result    RawTestResult
+		dir       string
+		timestamp string
+		at        time.Time
+	}
+
+	byKey := make(map[string]*entry)
+	var order []string
+	var conflicts []MergeConflict
+	var latest entry
+
+	for _, dir := range dirs {
+		files, err := readResultFiles(filepath.Join(dir, "encoders"))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, file := range files {
+			at, err := time.Parse(time.RFC3339, file.Timestamp)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: invalid timestamp %q: %w", dir, file.Timestamp, err)
+			}
+			if !at.Before(latest.at) {
+				latest = entry{timestamp: file.Timestamp, at: at}
+			}
+
+			for _, r := range file.Results {
+				key := resultKey(r)
+				next := &entry{result: r, dir: dir, timestamp: file.Timestamp, at: at}
+
+				prev, ok := byKey[key]
+				if !ok {
+					byKey[key] = next
+					order = append(order, key)
+					continue
+				}
+				if prev.dir == dir {
+					// Same run; the encoder files never overlap
+					continue
+				}
+
+				kept, dropped := next, prev
+				if next.at.Before(prev.at) {
+					kept, dropped = prev, next
+				}
+				byKey[key] = kept
+				conflicts = append(conflicts, MergeConflict{
+					Key:              key,
+					KeptDir:          kept.dir,
+					KeptTimestamp:    kept.timestamp,
+					DroppedDir:       dropped.dir,
+					DroppedTimestamp: dropped.timestamp,
+					Changed:          kept.result.Success != dropped.result.Success || kept.result.ErrorType != dropped.result.ErrorType,
+				})
+			}
+		}
+	}
+
+	merged := &RawResults{Timestamp: latest.timestamp}
+	for _, key := range order {
+		merged.Results = append(merged.Results, byKey[key].result)
+	}
+	sortResults(merged.Results)
+	merged.ExecutedAxes = executedAxes(merged.Results)
+
+	return merged, conflicts, nil
+}
@@ -0,0 +1,85 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTree writes results as a single encoder file under dir/encoders.
+func writeTree(t *testing.T, dir, timestamp string, results []RawTestResult) {
+	t.Helper()
+
+	encDir := filepath.Join(dir, "encoders")
+	if err := os.MkdirAll(encDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(RawResults{Timestamp: timestamp, Results: results})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(encDir, "enc.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeResults_LatestTimestampWins(t *testing.T) {
+	older := t.TempDir()
+	newer := t.TempDir()
+
+	writeTree(t, older, "2026-01-01T00:00:00Z", []RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, Success: false, ErrorType: "decode"},
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", DataSize: 100, PixelSize: 300, Success: true},
+	})
+	writeTree(t, newer, "2026-02-01T00:00:00Z", []RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, Success: true},
+		{Encoder: "boombuler/barcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, Success: true},
+	})
+
+	// Argument order must not matter
+	for _, dirs := range [][]string{{older, newer}, {newer, older}} {
+		merged, conflicts, err := MergeResultsConflicts(dirs...)
+		if err != nil {
+			t.Fatalf("MergeResultsConflicts() failed: %v", err)
+		}
+
+		if len(merged.Results) != 3 {
+			t.Fatalf("Merged %d results, want 3", len(merged.Results))
+		}
+		if merged.Timestamp != "2026-02-01T00:00:00Z" {
+			t.Errorf("Merged timestamp = %q, want the newest", merged.Timestamp)
+		}
+
+		for _, r := range merged.Results {
+			if r.Encoder == "skip2/go-qrcode" && r.Decoder == "tuotoo/qrcode" && !r.Success {
+				t.Error("Overlapping result kept the older failure, want the newer success")
+			}
+		}
+
+		if len(conflicts) != 1 {
+			t.Fatalf("Got %d conflicts, want 1", len(conflicts))
+		}
+		c := conflicts[0]
+		if c.KeptDir != newer || c.DroppedDir != older || !c.Changed {
+			t.Errorf("Conflict = %+v, want newer kept over older with a changed outcome", c)
+		}
+	}
+
+	merged, err := MergeResults(older, newer)
+	if err != nil {
+		t.Fatalf("MergeResults() failed: %v", err)
+	}
+	if merged.Results[0].Encoder != "boombuler/barcode" {
+		t.Errorf("Merged results not sorted: first encoder = %q", merged.Results[0].Encoder)
+	}
+}
+
+func TestMergeResults_InvalidTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, "yesterday", []RawTestResult{{Encoder: "enc", Decoder: "dec"}})
+
+	if _, err := MergeResults(dir); err == nil {
+		t.Error("MergeResults() with an invalid timestamp should fail")
+	}
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// pairMetrics accumulates one encoder/decoder pair's gauges.
+type pairMetrics struct {
+	encoder, decoder string
+	effective        int
+	successes        int
+	encoded          int
+	encodeMs         float64
+	decodeMs         float64
+}
+
+// WriteMetrics writes per encoder/decoder pair gauges in the Prometheus text
+// exposition format, for scraping with a textfile collector:
+//
+//	qr_success_rate{encoder="skip2/go-qrcode",decoder="makiuchi-d/gozxing"} 0.95
+//
+// qr_success_rate is the fraction (0-1) of effective tests that succeeded;
+// qr_encode_ms and qr_decode_ms are mean times over encoded tests. Skips
+// are left out, as in the success rates elsewhere.
+func WriteMetrics(w io.Writer, results []RawTestResult) error {
+	agg := make(map[string]*pairMetrics)
+	for _, r := range results {
+		if r.IsSkipped() {
+			continue
+		}
+		key := r.Encoder + "|" + r.Decoder
+		p := agg[key]
+		if p == nil {
+			p = &pairMetrics{encoder: r.Encoder, decoder: r.Decoder}
+			agg[key] = p
+		}
+		p.effective++
+		if r.Success {
+			p.successes++
+		}
+		if r.Encoded() {
+			p.encoded++
+			p.encodeMs += r.EncodeTimeMs
+			p.decodeMs += r.DecodeTimeMs
+		}
+	}
+
+	pairs := make([]*pairMetrics, 0, len(agg))
+	for _, p := range agg {
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].encoder != pairs[j].encoder {
+			return pairs[i].encoder < pairs[j].encoder
+		}
+		return pairs[i].decoder < pairs[j].decoder
+	})
+
+	gauges := []struct {
+		name, help string
+		value      func(p *pairMetrics) float64
+	}{
+		{"qr_success_rate", "Fraction of effective tests (excluding skips) that decoded correctly.", func(p *pairMetrics) float64 {
+			return ratio(p.successes, p.effective)
+		}},
+		{"qr_encode_ms", "Mean encode time in milliseconds over encoded tests.", func(p *pairMetrics) float64 {
+			return meanMs(p.encodeMs, p.encoded)
+		}},
+		{"qr_decode_ms", "Mean decode time in milliseconds over encoded tests.", func(p *pairMetrics) float64 {
+			return meanMs(p.decodeMs, p.encoded)
+		}},
+	}
+
+	var b strings.Builder
+	for _, g := range gauges {
+		fmt.Fprintf(&b, "# HELP %s %s\n", g.name, g.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", g.name)
+		for _, p := range pairs {
+			fmt.Fprintf(&b, "%s{encoder=\"%s\",decoder=\"%s\"} %g\n",
+				g.name, escapeLabel(p.encoder), escapeLabel(p.decoder), g.value(p))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMetricsFile writes WriteMetrics output to path.
+func WriteMetricsFile(path string, results []RawTestResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	if err := WriteMetrics(f, results); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write metrics file %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// ratio returns n/total, or 0 when total is 0.
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// meanMs returns sum/n, or 0 when n is 0.
+func meanMs(sum float64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// escapeLabel escapes a Prometheus label value: backslash, double quote,
+// and newline.
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteMetricsFile(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc/a", Decoder: "dec/a", Success: true, EncodeTimeMs: 2, DecodeTimeMs: 10},
+		{Encoder: "enc/a", Decoder: "dec/a", Success: false, ErrorType: "decode", EncodeTimeMs: 4, DecodeTimeMs: 30},
+		{Encoder: "enc/a", Decoder: "dec/a", ErrorType: "capacity", IsCapacityExceeded: true},
+		{Encoder: "enc/a", Decoder: "dec/b", Success: true, EncodeTimeMs: 1, DecodeTimeMs: 5},
+		{Encoder: "enc/\"q\"", Decoder: "dec/b", Success: true, EncodeTimeMs: 1, DecodeTimeMs: 5},
+	}
+
+	path := filepath.Join(t.TempDir(), "qr.prom")
+	if err := WriteMetricsFile(path, results); err != nil {
+		t.Fatalf("WriteMetricsFile() error = %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	sample := regexp.MustCompile(`^(qr_[a-z_]+)\{encoder="((?:[^"\\]|\\.)*)",decoder="((?:[^"\\]|\\.)*)"\} (\S+)$`)
+	got := make(map[string]float64)
+	types := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		if strings.HasPrefix(line, "# TYPE ") {
+			fields := strings.Fields(line)
+			if len(fields) != 4 || fields[3] != "gauge" {
+				t.Errorf("TYPE line %q, want a gauge", line)
+			}
+			types[fields[2]] = true
+			continue
+		}
+		if strings.HasPrefix(line, "# HELP ") {
+			continue
+		}
+
+		m := sample.FindStringSubmatch(line)
+		if m == nil {
+			t.Errorf("malformed sample line %q", line)
+			continue
+		}
+		if !types[m[1]] {
+			t.Errorf("sample %q before its TYPE line", line)
+		}
+		value, err := strconv.ParseFloat(m[4], 64)
+		if err != nil {
+			t.Errorf("sample %q has non-numeric value: %v", line, err)
+		}
+		got[m[1]+"|"+m[2]+"|"+m[3]] = value
+	}
+
+	want := map[string]float64{
+		"qr_success_rate|enc/a|dec/a":     0.5,
+		"qr_encode_ms|enc/a|dec/a":        3,
+		"qr_decode_ms|enc/a|dec/a":        20,
+		"qr_success_rate|enc/a|dec/b":     1,
+		"qr_encode_ms|enc/a|dec/b":        1,
+		"qr_decode_ms|enc/a|dec/b":        5,
+		`qr_success_rate|enc/\"q\"|dec/b`: 1,
+		`qr_encode_ms|enc/\"q\"|dec/b`:    1,
+		`qr_decode_ms|enc/\"q\"|dec/b`:    5,
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d samples, want %d:\n%s", len(got), len(want), content)
+	}
+	for key, w := range want {
+		if v, ok := got[key]; !ok || v != w {
+			t.Errorf("sample %s = %v (present %v), want %v", key, v, ok, w)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+//go:build sqlite
+// +build sqlite
+
+package report
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+
+	// Pure Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// resultsSchema creates the results table. Each run's rows share a run_id.
+const resultsSchema = `
+CREATE TABLE IF NOT EXISTS results (
+	run_id                 TEXT NOT NULL,
+	timestamp              TEXT NOT NULL,
+	encoder                TEXT NOT NULL,
+	decoder                TEXT NOT NULL,
+	data_size              INTEGER NOT NULL,
+	pixel_size             INTEGER NOT NULL,
+	content_type           TEXT NOT NULL,
+	error_correction_level TEXT NOT NULL,
+	success                INTEGER NOT NULL,
+	error_type             TEXT,
+	error_msg              TEXT,
+	is_capacity_exceeded   INTEGER NOT NULL,
+	encode_time_ms         REAL NOT NULL,
+	decode_time_ms         REAL NOT NULL,
+	qr_version             INTEGER,
+	module_count           INTEGER,
+	is_micro_qr            INTEGER NOT NULL,
+	mask                   INTEGER,
+	module_pixel_size      REAL,
+	is_fractional_module   INTEGER NOT NULL,
+	decoded_length         INTEGER
+);
+CREATE INDEX IF NOT EXISTS results_run_id ON results (run_id);
+`
+
+// SQLiteReporter writes test results as rows in a SQLite database.
+// Results from many runs accumulate in one file and can be queried with SQL.
+//
+// Only available when built with the sqlite tag:
+//
+//	go build -tags sqlite ./cmd/qr-tester
+type SQLiteReporter struct {
+	// Path is the database file, created if missing.
+	Path string
+
+	// RunID identifies this run's rows. Defaults to the run start time.
+	RunID string
+}
+
+// NewSQLiteReporter creates a SQLite reporter that writes to the database at path.
+func NewSQLiteReporter(path string) *SQLiteReporter {
+	return &SQLiteReporter{
+		Path:  path,
+		RunID: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// Generate inserts every result in the matrix in a single transaction.
+func (r *SQLiteReporter) Generate(m *matrix.CompatibilityMatrix) error {
+	db, err := OpenResultsDB(r.Path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO results (
+		run_id, timestamp, encoder, decoder, data_size, pixel_size, content_type,
+		error_correction_level, success, error_type, error_msg, is_capacity_exceeded,
+		encode_time_ms, decode_time_ms, qr_version, module_count, is_micro_qr, mask,
+		module_pixel_size, is_fractional_module, decoded_length
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	for _, result := range m.Results {
+		raw := convertResult(result)
+
+		var mask interface{}
+		if raw.Mask != nil {
+			mask = *raw.Mask
+		}
+		var decodedLength interface{}
+		if raw.DecodedLength != nil {
+			decodedLength = *raw.DecodedLength
+		}
+
+		if _, err := stmt.Exec(
+			r.RunID, timestamp, raw.Encoder, raw.Decoder, raw.DataSize, raw.PixelSize, raw.ContentType,
+			raw.ErrorCorrectionLevel, raw.Success, raw.ErrorType, raw.ErrorMsg, raw.IsCapacityExceeded,
+			raw.EncodeTimeMs, raw.DecodeTimeMs, raw.QRVersion, raw.ModuleCount, raw.IsMicroQR, mask,
+			raw.ModulePixelSize, raw.IsFractionalModule, decodedLength,
+		); err != nil {
+			return fmt.Errorf("sqlite: failed to insert result: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: failed to commit results: %w", err)
+	}
+
+	return nil
+}
+
+// OpenResultsDB opens the results database at path, creating the schema if needed.
+func OpenResultsDB(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to open %s: %w", path, err)
+	}
+
+	if _, err := db.Exec(resultsSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlite: failed to create schema: %w", err)
+	}
+
+	return db, nil
+}
+
+// CountResults returns the number of result rows stored for a run.
+func CountResults(db *sql.DB, runID string) (int, error) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM results WHERE run_id = ?`, runID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: count query failed: %w", err)
+	}
+	return count, nil
+}
+
+// SuccessRateByEncoder returns each encoder's success percentage for a run.
+// Capacity-exceeded results are excluded, matching the JSON reports.
+func SuccessRateByEncoder(db *sql.DB, runID string) (map[string]float64, error) {
+	rows, err := db.Query(`
+		SELECT encoder, 100.0 * SUM(success) / COUNT(*)
+		FROM results
+		WHERE run_id = ? AND is_capacity_exceeded = 0
+		GROUP BY encoder`, runID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: success rate query failed: %w", err)
+	}
+	defer rows.Close()
+
+	rates := make(map[string]float64)
+	for rows.Next() {
+		var encoder string
+		var rate float64
+		if err := rows.Scan(&encoder, &rate); err != nil {
+			return nil, fmt.Errorf("sqlite: failed to read success rate: %w", err)
+		}
+		rates[encoder] = rate
+	}
+
+	return rates, rows.Err()
+}
@@ -0,0 +1,27 @@
+//go:build !sqlite
+// +build !sqlite
+
+package report
+
+import (
+	"fmt"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+// SQLiteReporter is a stub when built without the sqlite tag.
+// This keeps the SQLite driver out of default builds.
+type SQLiteReporter struct {
+	Path  string
+	RunID string
+}
+
+// NewSQLiteReporter creates a stub SQLite reporter.
+func NewSQLiteReporter(path string) *SQLiteReporter {
+	return &SQLiteReporter{Path: path}
+}
+
+// Generate always returns an error when built without the sqlite tag.
+func (r *SQLiteReporter) Generate(m *matrix.CompatibilityMatrix) error {
+	return fmt.Errorf("sqlite: reporter not available (build with -tags sqlite)")
+}
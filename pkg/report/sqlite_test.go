@@ -0,0 +1,69 @@
+//go:build sqlite
+// +build sqlite
+
+package report
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+func TestSQLiteReporter_Generate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.db")
+
+	m := &matrix.CompatibilityMatrix{
+		Results: []matrix.TestResult{
+			{EncoderName: "enc/a", DecoderName: "dec/x", DataSize: 100, PixelSize: 320, ContentType: "numeric", ErrorCorrectionLevel: "M", Mask: 2, MaskDetected: true},
+			{EncoderName: "enc/a", DecoderName: "dec/y", DataSize: 100, PixelSize: 320, ContentType: "numeric", ErrorCorrectionLevel: "M", Mask: 2, MaskDetected: true,
+				Error: matrix.DecodeError{Err: errors.New("not found")}},
+			{EncoderName: "enc/b", DecoderName: "dec/x", DataSize: 100, PixelSize: 320, ContentType: "numeric", ErrorCorrectionLevel: "M"},
+			{EncoderName: "enc/b", DecoderName: "dec/y", DataSize: 3000, PixelSize: 320, ContentType: "numeric", ErrorCorrectionLevel: "M",
+				Error: matrix.EncodeError{Err: errors.New("too long")}, IsCapacityExceeded: true},
+		},
+	}
+
+	reporter := NewSQLiteReporter(path)
+	reporter.RunID = "run-1"
+	if err := reporter.Generate(m); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	db, err := OpenResultsDB(path)
+	if err != nil {
+		t.Fatalf("OpenResultsDB() failed: %v", err)
+	}
+	defer db.Close()
+
+	count, err := CountResults(db, "run-1")
+	if err != nil {
+		t.Fatalf("CountResults() failed: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("CountResults() = %d, want 4", count)
+	}
+
+	rates, err := SuccessRateByEncoder(db, "run-1")
+	if err != nil {
+		t.Fatalf("SuccessRateByEncoder() failed: %v", err)
+	}
+
+	// enc/a: 1 of 2; enc/b: 1 of 1 after excluding the capacity skip
+	if rates["enc/a"] != 50 {
+		t.Errorf("enc/a success rate = %v, want 50", rates["enc/a"])
+	}
+	if rates["enc/b"] != 100 {
+		t.Errorf("enc/b success rate = %v, want 100", rates["enc/b"])
+	}
+
+	// A second run appends without touching the first
+	reporter.RunID = "run-2"
+	if err := reporter.Generate(m); err != nil {
+		t.Fatalf("second Generate() failed: %v", err)
+	}
+	if count, _ := CountResults(db, "run-1"); count != 4 {
+		t.Errorf("run-1 count after second run = %d, want 4", count)
+	}
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+// WriteRunSummary writes a short plain-text summary of a run to w:
+// test counts, overall success rate, encode rate and decode rate (over
+// encoded tests only), best and worst combinations, and failure counts per
+// error type. Meant for the terminal after a run.
+func WriteRunSummary(w io.Writer, a Analysis) error {
+	var b strings.Builder
+
+	b.WriteString("\nRun summary\n")
+	fmt.Fprintf(&b, "  Total tests:     %d\n", a.TotalTests)
+	fmt.Fprintf(&b, "  Effective tests: %d (%s)\n", a.EffectiveTests, skipCounts(a.CapacitySkips, a.UnsupportedSkips, a.UndersizedSkips))
+	fmt.Fprintf(&b, "  Success rate:    %.1f%% (%d/%d)\n", percent(a.Successes, a.EffectiveTests), a.Successes, a.EffectiveTests)
+	fmt.Fprintf(&b, "  Encode rate:     %.1f%% (%d/%d)\n", percent(a.Encoded, a.EffectiveTests), a.Encoded, a.EffectiveTests)
+	fmt.Fprintf(&b, "  Decode rate:     %.1f%% (%d/%d encoded)\n", percent(a.Successes, a.Encoded), a.Successes, a.Encoded)
+
+	if len(a.ExcludedDecoders) > 0 {
+		fmt.Fprintf(&b, "  Excluded:        %s (%d tests, not in rates)\n", strings.Join(a.ExcludedDecoders, ", "), a.ExcludedTests)
+	}
+
+	if a.Best.EffectiveTests > 0 {
+		fmt.Fprintf(&b, "  Best:            %s → %s (%.1f%%)\n", a.Best.Encoder, a.Best.Decoder, a.Best.SuccessRate)
+	}
+	if a.Worst.EffectiveTests > 0 {
+		fmt.Fprintf(&b, "  Worst:           %s → %s (%.1f%%)\n", a.Worst.Encoder, a.Worst.Decoder, a.Worst.SuccessRate)
+	}
+
+	if a.VersionMismatches > 0 {
+		fmt.Fprintf(&b, "  Version mismatch: %d (encoder-reported vs detected)\n", a.VersionMismatches)
+	}
+
+	if len(a.FailuresByType) == 0 {
+		b.WriteString("  Failures:        none\n")
+	} else {
+		types := make([]string, 0, len(a.FailuresByType))
+		for t := range a.FailuresByType {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+
+		parts := make([]string, len(types))
+		for i, t := range types {
+			parts[i] = fmt.Sprintf("%s %d", t, a.FailuresByType[t])
+		}
+		fmt.Fprintf(&b, "  Failures:        %s\n", strings.Join(parts, ", "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteFlakiness writes one line per encoder/decoder pair of a repeated run:
+// the success rate across repeats and whether any test case changed outcome.
+func WriteFlakiness(w io.Writer, flakiness []matrix.Flakiness) error {
+	var b strings.Builder
+
+	repeats := 0
+	for _, f := range flakiness {
+		repeats = max(repeats, f.Repeats)
+	}
+	fmt.Fprintf(&b, "\nFlakiness (%d repeats)\n", repeats)
+
+	for _, f := range flakiness {
+		status := "consistent"
+		if f.Flaky {
+			status = fmt.Sprintf("FLAKY in %d cases", f.FlakyCases)
+		}
+		fmt.Fprintf(&b, "  %s → %s: %.1f%% (%d/%d) %s\n",
+			f.EncoderName, f.DecoderName, percent(f.Successes, f.Tests), f.Successes, f.Tests, status)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCrossEncoder writes one line per encoder for a single decoder, most
+// readable first, marking encoders whose output the decoder could not read.
+func WriteCrossEncoder(w io.Writer, decoderName string, readability []matrix.EncoderReadability) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "\nCross-encoder readability (%s)\n", decoderName)
+	for _, e := range readability {
+		status := ""
+		if e.Unreadable {
+			status = " UNREADABLE"
+		}
+		fmt.Fprintf(&b, "  %s: %.1f%% (%d/%d)%s\n",
+			e.EncoderName, e.SuccessRate*100, e.Successes, e.Tests, status)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteConsensus writes how often the decoders agreed on an image and, for
+// images where all but a minority agreed, how often each decoder was the
+// dissenter. A decoder that dissents often likely has a bug.
+func WriteConsensus(w io.Writer, consensus []matrix.CaseConsensus) error {
+	var b strings.Builder
+
+	counts := make(map[matrix.Agreement]int)
+	dissents := make(map[string]int)
+	for _, c := range consensus {
+		counts[c.Agreement]++
+		for _, name := range c.Dissenters {
+			dissents[name]++
+		}
+	}
+
+	b.WriteString("\nDecoder consensus\n")
+	fmt.Fprintf(&b, "  All agree:   %d images\n", counts[matrix.AgreementAll])
+	fmt.Fprintf(&b, "  Majority:    %d images\n", counts[matrix.AgreementMajority])
+	fmt.Fprintf(&b, "  No majority: %d images\n", counts[matrix.AgreementNone])
+
+	names := make([]string, 0, len(dissents))
+	for name := range dissents {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if dissents[names[i]] != dissents[names[j]] {
+			return dissents[names[i]] > dissents[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		fmt.Fprintf(&b, "  Dissenter %s: %d images\n", name, dissents[name])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteSeedStability writes one line per encoder/decoder pair of a seed
+// sweep: the success rate across seeds, the variance of per-seed rates, and
+// whether success depends on the payload.
+func WriteSeedStability(w io.Writer, stability []matrix.SeedStability) error {
+	var b strings.Builder
+
+	seeds := 0
+	for _, s := range stability {
+		seeds = max(seeds, s.Seeds)
+	}
+	fmt.Fprintf(&b, "\nSeed stability (%d seeds)\n", seeds)
+
+	for _, s := range stability {
+		status := "stable"
+		if s.Unstable {
+			status = fmt.Sprintf("UNSTABLE in %d cases", s.UnstableCases)
+		}
+		fmt.Fprintf(&b, "  %s → %s: %.1f%% (%d/%d, variance %.4f) %s\n",
+			s.EncoderName, s.DecoderName, s.SuccessRate*100, s.Successes, s.Tests, s.RateVariance, status)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// skipCounts formats the capacity skip count followed by any unsupported
+// and undersized skips, e.g. "12 capacity skips, 3 undersized".
+func skipCounts(capacity, unsupported, undersized int) string {
+	s := fmt.Sprintf("%d capacity skips", capacity)
+	if unsupported > 0 {
+		s += fmt.Sprintf(", %d unsupported", unsupported)
+	}
+	if undersized > 0 {
+		s += fmt.Sprintf(", %d undersized", undersized)
+	}
+	return s
+}
@@ -0,0 +1,520 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/13rac1/qr-library-test/internal/matrix"
+)
+
+func TestWriteRunSummary(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc-a", Decoder: "dec", Success: true},
+		{Encoder: "enc-a", Decoder: "dec", Success: true},
+		{Encoder: "enc-b", Decoder: "dec", Success: true},
+		{Encoder: "enc-b", Decoder: "dec", ErrorType: "decode"},
+		{Encoder: "enc-b", Decoder: "dec", ErrorType: "capacity", IsCapacityExceeded: true},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRunSummary(&buf, Analyze(results)); err != nil {
+		t.Fatalf("WriteRunSummary() failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"Total tests:     5",
+		"Effective tests: 4 (1 capacity skips)",
+		"Success rate:    75.0% (3/4)",
+		"Best:            enc-a → dec (100.0%)",
+		"Worst:           enc-b → dec (50.0%)",
+		"Failures:        decode 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Summary missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestAnalyze_EncodeDecodeRates(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc", Decoder: "dec", Success: true},
+		{Encoder: "enc", Decoder: "dec", Success: true},
+		{Encoder: "enc", Decoder: "dec", Success: true},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "decode"},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "encode"},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "encode"},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "capacity", IsCapacityExceeded: true},
+	}
+
+	a := Analyze(results)
+	if a.EffectiveTests != 6 || a.Encoded != 4 {
+		t.Fatalf("Analyze() encoded = %d/%d, want 4/6", a.Encoded, a.EffectiveTests)
+	}
+
+	c := a.Combinations[0]
+	if c.SuccessRate != 50 {
+		t.Errorf("SuccessRate = %.1f, want 50.0 (3/6)", c.SuccessRate)
+	}
+	if got := fmt.Sprintf("%.1f", c.EncodeRate); got != "66.7" {
+		t.Errorf("EncodeRate = %s, want 66.7 (4/6)", got)
+	}
+	// Decode rate is over the 4 encoded tests only, not the 6 effective ones
+	if c.DecodeRate != 75 {
+		t.Errorf("DecodeRate = %.1f, want 75.0 (3/4 encoded)", c.DecodeRate)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRunSummary(&buf, a); err != nil {
+		t.Fatalf("WriteRunSummary() failed: %v", err)
+	}
+	for _, want := range []string{
+		"Encode rate:     66.7% (4/6)",
+		"Decode rate:     75.0% (3/4 encoded)",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Summary missing %q\n\nOutput:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestAnalyze_UnsupportedSkips(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc", Decoder: "dec", Success: true},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "decode"},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "capacity", IsCapacityExceeded: true},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "unsupported"},
+		// As written before unsupported skips had their own flag
+		{Encoder: "enc", Decoder: "dec", ErrorType: "unsupported", IsCapacityExceeded: true},
+	}
+
+	a := Analyze(results)
+	if a.CapacitySkips != 1 || a.UnsupportedSkips != 2 || a.EffectiveTests != 2 {
+		t.Errorf("Analyze() capacity/unsupported/effective = %d/%d/%d, want 1/2/2",
+			a.CapacitySkips, a.UnsupportedSkips, a.EffectiveTests)
+	}
+	if c := a.Combinations[0]; c.CapacitySkips != 1 || c.UnsupportedSkips != 2 || c.SuccessRate != 50 {
+		t.Errorf("Combination capacity/unsupported = %d/%d at %.1f%%, want 1/2 at 50.0%%",
+			c.CapacitySkips, c.UnsupportedSkips, c.SuccessRate)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRunSummary(&buf, a); err != nil {
+		t.Fatalf("WriteRunSummary() failed: %v", err)
+	}
+	if want := "Effective tests: 2 (1 capacity skips, 2 unsupported)"; !strings.Contains(buf.String(), want) {
+		t.Errorf("Summary missing %q\n\nOutput:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteAnalysisMarkdown(&buf, a); err != nil {
+		t.Fatalf("WriteAnalysisMarkdown() failed: %v", err)
+	}
+	for _, want := range []string{"- **Capacity skips:** 1\n", "- **Unsupported skips:** 2\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Markdown missing %q\n\nOutput:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestAnalyze_UndersizedSkips(t *testing.T) {
+	// 800 bytes at 50px, as the runner reports it
+	undersized := convertResult(matrix.TestResult{
+		EncoderName: "enc",
+		DecoderName: "dec",
+		Error:       matrix.UndersizedError{ModulePixelSize: 0.5, Minimum: 2},
+	})
+	if !undersized.IsSkipped() || undersized.IsFailure() || undersized.Encoded() {
+		t.Errorf("Undersized result skipped/failure/encoded = %v/%v/%v, want true/false/false",
+			undersized.IsSkipped(), undersized.IsFailure(), undersized.Encoded())
+	}
+
+	results := []RawTestResult{
+		{Encoder: "enc", Decoder: "dec", Success: true},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "decode"},
+		{Encoder: "enc", Decoder: "dec", ErrorType: "capacity", IsCapacityExceeded: true},
+		undersized,
+	}
+
+	a := Analyze(results)
+	if a.CapacitySkips != 1 || a.UndersizedSkips != 1 || a.EffectiveTests != 2 {
+		t.Errorf("Analyze() capacity/undersized/effective = %d/%d/%d, want 1/1/2",
+			a.CapacitySkips, a.UndersizedSkips, a.EffectiveTests)
+	}
+	if a.FailuresByType["undersized"] != 0 {
+		t.Errorf("FailuresByType[undersized] = %d, want 0", a.FailuresByType["undersized"])
+	}
+	if c := a.Combinations[0]; c.UndersizedSkips != 1 || c.SuccessRate != 50 {
+		t.Errorf("Combination undersized = %d at %.1f%%, want 1 at 50.0%%", c.UndersizedSkips, c.SuccessRate)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRunSummary(&buf, a); err != nil {
+		t.Fatalf("WriteRunSummary() failed: %v", err)
+	}
+	if want := "Effective tests: 2 (1 capacity skips, 1 undersized)"; !strings.Contains(buf.String(), want) {
+		t.Errorf("Summary missing %q\n\nOutput:\n%s", want, buf.String())
+	}
+}
+
+func TestAnalyze_LengthDrift(t *testing.T) {
+	results := []RawTestResult{
+		convertResult(matrix.TestResult{EncoderName: "enc", DecoderName: "dec", DataSize: 10, Decoded: true, DecodedLength: 10}),
+		// A decoder that returns nothing has drifted by the whole input
+		convertResult(matrix.TestResult{EncoderName: "enc", DecoderName: "dec", DataSize: 10, Decoded: true,
+			Error: matrix.DataMismatchError{Expected: 10, Got: 0}}),
+		// A failed decode returned nothing to measure
+		convertResult(matrix.TestResult{EncoderName: "enc", DecoderName: "dec", DataSize: 10,
+			Error: matrix.DecodeError{Err: fmt.Errorf("not found")}}),
+	}
+
+	drift := Analyze(results).LengthDrift
+	if len(drift) != 1 {
+		t.Fatalf("LengthDrift = %+v, want one decoder", drift)
+	}
+	if d := drift[0]; d.Decodes != 2 || d.Drifted != 1 || d.MinDelta != -10 || d.MaxDelta != 0 {
+		t.Errorf("LengthDrift = %+v, want 2 decodes, 1 drifted, deltas -10..0", d)
+	}
+}
+
+func TestWriteRunSummary_VersionMismatch(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc", Decoder: "dec-a", Success: true, QRVersion: 10, VersionMismatch: true},
+		{Encoder: "enc", Decoder: "dec-b", Success: true, QRVersion: 10, VersionMismatch: true},
+		{Encoder: "enc", Decoder: "dec-a", Success: true, QRVersion: 5},
+	}
+
+	a := Analyze(results)
+	if a.VersionMismatches != 2 {
+		t.Errorf("VersionMismatches = %d, want 2", a.VersionMismatches)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRunSummary(&buf, a); err != nil {
+		t.Fatalf("WriteRunSummary() failed: %v", err)
+	}
+	if want := "Version mismatch: 2 (encoder-reported vs detected)"; !strings.Contains(buf.String(), want) {
+		t.Errorf("Summary missing %q\n\nOutput:\n%s", want, buf.String())
+	}
+}
+
+func TestAnalyzeExcluding_ArchivedDecoder(t *testing.T) {
+	results := []RawTestResult{
+		{Encoder: "enc", Decoder: "makiuchi-d/gozxing", Success: true},
+		{Encoder: "enc", Decoder: "makiuchi-d/gozxing", Success: true},
+		{Encoder: "enc", Decoder: "liyue201/goqr", ErrorType: "dataMismatch"},
+		{Encoder: "enc", Decoder: "liyue201/goqr", ErrorType: "dataMismatch"},
+	}
+
+	if got := Analyze(results); got.Successes != 2 || got.EffectiveTests != 4 {
+		t.Fatalf("Analyze() successes = %d/%d, want 2/4", got.Successes, got.EffectiveTests)
+	}
+
+	a := AnalyzeExcluding(results, map[string]bool{"liyue201/goqr": true})
+	if a.Successes != 2 || a.EffectiveTests != 2 {
+		t.Errorf("AnalyzeExcluding() successes = %d/%d, want 2/2", a.Successes, a.EffectiveTests)
+	}
+	if a.ExcludedTests != 2 {
+		t.Errorf("ExcludedTests = %d, want 2", a.ExcludedTests)
+	}
+	if len(a.FailuresByType) != 0 {
+		t.Errorf("FailuresByType = %v, want none", a.FailuresByType)
+	}
+	if a.Worst.Decoder != "makiuchi-d/gozxing" {
+		t.Errorf("Worst decoder = %q, want the excluded decoder skipped", a.Worst.Decoder)
+	}
+	if len(a.Combinations) != 2 {
+		t.Errorf("Combinations has %d pairs, want excluded pair still listed", len(a.Combinations))
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRunSummary(&buf, a); err != nil {
+		t.Fatalf("WriteRunSummary() failed: %v", err)
+	}
+	for _, want := range []string{
+		"Success rate:    100.0% (2/2)",
+		"Excluded:        liyue201/goqr (2 tests, not in rates)",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Summary missing %q\n\nOutput:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestWriteSeedStability(t *testing.T) {
+	stability := []matrix.SeedStability{
+		{EncoderName: "enc", DecoderName: "dec-a", Seeds: 4, Tests: 4, Successes: 4, SuccessRate: 1},
+		{EncoderName: "enc", DecoderName: "dec-b", Seeds: 4, Tests: 4, Successes: 2, SuccessRate: 0.5, RateVariance: 0.25, UnstableCases: 1, Unstable: true},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSeedStability(&buf, stability); err != nil {
+		t.Fatalf("WriteSeedStability() failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"Seed stability (4 seeds)",
+		"enc → dec-a: 100.0% (4/4, variance 0.0000) stable",
+		"enc → dec-b: 50.0% (2/4, variance 0.2500) UNSTABLE in 1 cases",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteFlakiness(t *testing.T) {
+	flakiness := []matrix.Flakiness{
+		{EncoderName: "enc", DecoderName: "dec-a", Repeats: 3, Tests: 3, Successes: 3},
+		{EncoderName: "enc", DecoderName: "dec-b", Repeats: 3, Tests: 3, Successes: 2, FlakyCases: 1, Flaky: true},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteFlakiness(&buf, flakiness); err != nil {
+		t.Fatalf("WriteFlakiness() failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"Flakiness (3 repeats)",
+		"enc → dec-a: 100.0% (3/3) consistent",
+		"enc → dec-b: 66.7% (2/3) FLAKY in 1 cases",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteConsensus(t *testing.T) {
+	consensus := []matrix.CaseConsensus{
+		{Agreement: matrix.AgreementAll},
+		{Agreement: matrix.AgreementMajority, Dissenters: []string{"dec-c"}},
+		{Agreement: matrix.AgreementMajority, Dissenters: []string{"dec-c"}},
+		{Agreement: matrix.AgreementMajority, Dissenters: []string{"dec-a"}},
+		{Agreement: matrix.AgreementNone},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteConsensus(&buf, consensus); err != nil {
+		t.Fatalf("WriteConsensus() failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"All agree:   1 images",
+		"Majority:    3 images",
+		"No majority: 1 images",
+		"Dissenter dec-c: 2 images\n  Dissenter dec-a: 1 images",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q\n\nOutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestAnalyze_ModuleSizesFromEncoderVersion(t *testing.T) {
+	// Encoder-reported versions only: no module pixel size was recorded
+	results := []RawTestResult{
+		// Version 1: 21 + 4 quiet zone modules, 250px → 10 px/module
+		{Encoder: "enc", Decoder: "dec", PixelSize: 250, QRVersion: 1, Success: true},
+		// Version 2: 25 + 4 modules, 320px → 11.03 px/module
+		{Encoder: "enc", Decoder: "dec", PixelSize: 320, QRVersion: 2, ErrorType: "decode"},
+		{Encoder: "enc", Decoder: "dec", PixelSize: 320, QRVersion: 2, Success: true},
+		// No version: left out
+		{Encoder: "enc", Decoder: "dec", PixelSize: 320, ErrorType: "decode"},
+	}
+
+	a := Analyze(results)
+	f := a.Fractional
+	if f.IntegerTests != 1 || f.FractionalTests != 2 || f.FractionalFailures != 1 {
+		t.Errorf("Fractional = %+v, want 1 integer test and 1/2 fractional failures", f)
+	}
+	if f.Unavailable != 1 {
+		t.Errorf("Unavailable = %d, want 1", f.Unavailable)
+	}
+	if len(f.BySize) != 2 || f.BySize[0].ModulePixelSize != 10 {
+		t.Fatalf("BySize = %+v, want 10 px/module first", f.BySize)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAnalysisMarkdown(&buf, a); err != nil {
+		t.Fatalf("WriteAnalysisMarkdown() failed: %v", err)
+	}
+	for _, want := range []string{
+		"| Fractional | 2 | 1 | 50.0% |",
+		"| Integer | 1 | 0 | 0.0% |",
+		"| 10.00 | integer | 1 | 0 | 0.0% |",
+		"| 11.03 | fractional | 2 | 1 | 50.0% |",
+		"1 tests without a QR version or module size are not included.",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Analysis missing %q\n\nOutput:\n%s", want, buf.String())
+		}
+	}
+
+	// Without any version the section says so instead of an all-integer table
+	buf.Reset()
+	if err := WriteAnalysisMarkdown(&buf, Analyze(results[3:])); err != nil {
+		t.Fatalf("WriteAnalysisMarkdown() failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Module sizes unavailable") {
+		t.Errorf("Analysis without versions should report module sizes unavailable\n\nOutput:\n%s", buf.String())
+	}
+}
+
+func TestAnalyze_ByVersion(t *testing.T) {
+	var results []RawTestResult
+	for version := 1; version <= 20; version++ {
+		// Four tests per version; failures concentrate in versions 10-15,
+		// peaking at 12 where every test fails
+		failures := 0
+		switch {
+		case version == 12:
+			failures = 4
+		case version >= 10 && version <= 15:
+			failures = 2
+		}
+		for i := 0; i < 4; i++ {
+			r := RawTestResult{Encoder: "enc", Decoder: "dec", PixelSize: 400, QRVersion: version, Success: i >= failures}
+			if !r.Success {
+				r.ErrorType = "decode"
+			}
+			results = append(results, r)
+		}
+	}
+	// Capacity skips and Micro QR codes are not counted
+	results = append(results,
+		RawTestResult{Encoder: "enc", Decoder: "dec", QRVersion: 12, ErrorType: "capacity", IsCapacityExceeded: true},
+		RawTestResult{Encoder: "enc", Decoder: "dec", QRVersion: 2, IsMicroQR: true, ErrorType: "decode"},
+	)
+
+	a := Analyze(results)
+	if len(a.ByVersion) != 20 {
+		t.Fatalf("ByVersion has %d versions, want 20", len(a.ByVersion))
+	}
+	for i, v := range a.ByVersion {
+		if v.Version != i+1 || v.Tests != 4 {
+			t.Errorf("ByVersion[%d] = %+v, want version %d with 4 tests", i, v, i+1)
+		}
+	}
+	if v := a.ByVersion[1]; v.Failures != 0 || v.ModuleCount != 25 {
+		t.Errorf("Version 2 = %+v, want 0 failures and 25 modules (Micro QR left out)", v)
+	}
+	if v := a.ByVersion[10]; v.Failures != 2 {
+		t.Errorf("Version 11 failures = %d, want 2", v.Failures)
+	}
+
+	if a.WorstVersion.Version != 12 || a.WorstVersion.FailureRate() != 100 {
+		t.Errorf("WorstVersion = %+v, want version 12 at 100%%", a.WorstVersion)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAnalysisMarkdown(&buf, a); err != nil {
+		t.Fatalf("WriteAnalysisMarkdown() failed: %v", err)
+	}
+	for _, want := range []string{
+		"## Failures by QR Version",
+		"| 12 | 65 | 4 | 4 | 100.0% |",
+		"| 13 | 69 | 4 | 2 | 50.0% |",
+		"Failures peak at version 12 (65 modules): 100.0% of 4 tests.",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Analysis missing %q\n\nOutput:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestAnalyze_ByMask(t *testing.T) {
+	var results []RawTestResult
+	for mask := 0; mask < 8; mask++ {
+		// Ten tests per mask; mask 3 fails six times, the others once at most
+		failures := mask % 2
+		if mask == 3 {
+			failures = 6
+		}
+		for i := 0; i < 10; i++ {
+			m := mask
+			r := RawTestResult{Encoder: "enc", Decoder: "dec", PixelSize: 400, Mask: &m, Success: i >= failures}
+			if !r.Success {
+				r.ErrorType = "decode"
+			}
+			results = append(results, r)
+		}
+	}
+	// Capacity skips, Micro QR codes and undetected masks are not counted
+	mask := 3
+	results = append(results,
+		RawTestResult{Encoder: "enc", Decoder: "dec", Mask: &mask, ErrorType: "capacity", IsCapacityExceeded: true},
+		RawTestResult{Encoder: "enc", Decoder: "dec", Mask: &mask, IsMicroQR: true, ErrorType: "decode"},
+		RawTestResult{Encoder: "enc", Decoder: "dec", ErrorType: "decode"},
+	)
+
+	a := Analyze(results)
+	if len(a.ByMask) != 8 {
+		t.Fatalf("ByMask has %d masks, want 8", len(a.ByMask))
+	}
+	for i, m := range a.ByMask {
+		if m.Mask != i || m.Tests != 10 {
+			t.Errorf("ByMask[%d] = %+v, want mask %d with 10 tests", i, m, i)
+		}
+		if m.Elevated != (i == 3) {
+			t.Errorf("Mask %d elevated = %v, want %v", i, m.Elevated, i == 3)
+		}
+	}
+	if m := a.ByMask[3]; m.Failures != 6 {
+		t.Errorf("Mask 3 failures = %d, want 6", m.Failures)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAnalysisMarkdown(&buf, a); err != nil {
+		t.Fatalf("WriteAnalysisMarkdown() failed: %v", err)
+	}
+	for _, want := range []string{
+		"## Failures by Mask Pattern",
+		"| 3 | 10 | 6 | 60.0% (elevated) |",
+		"| 1 | 10 | 1 | 10.0% |",
+		"Failures cluster on mask 3",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Analysis missing %q\n\nOutput:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestAnalyze_DecodeOutliers(t *testing.T) {
+	var results []RawTestResult
+	for i, ms := range []float64{10, 12, 8, 11, 9} {
+		results = append(results, RawTestResult{Encoder: "enc", Decoder: "dec", DataSize: i, PixelSize: 400, ContentType: "numeric", ErrorCorrectionLevel: "M", Success: true, DecodeTimeMs: ms})
+	}
+	results = append(results,
+		RawTestResult{Encoder: "enc", Decoder: "dec", DataSize: 100, PixelSize: 400, ContentType: "numeric", ErrorCorrectionLevel: "M", Success: true, DecodeTimeMs: 1000, IsDecodeOutlier: true},
+		RawTestResult{Encoder: "enc", Decoder: "other", DataSize: 100, PixelSize: 400, ContentType: "numeric", ErrorCorrectionLevel: "M", Success: true, DecodeTimeMs: 5},
+	)
+
+	a := Analyze(results)
+	want := []DecodeOutlier{{
+		Encoder: "enc", Decoder: "dec", DataSize: 100, PixelSize: 400, ContentType: "numeric", ErrorCorrectionLevel: "M",
+		DecodeTimeMs: 1000, MedianMs: 10.5,
+	}}
+	if !reflect.DeepEqual(a.DecodeOutliers, want) {
+		t.Errorf("DecodeOutliers = %+v, want %+v", a.DecodeOutliers, want)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAnalysisMarkdown(&buf, a); err != nil {
+		t.Fatalf("WriteAnalysisMarkdown() failed: %v", err)
+	}
+	for _, want := range []string{
+		"## Decode Time Outliers",
+		"| enc | dec | 100 | 400px | numeric | M | 1000.00ms | 10.50ms |",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Analysis missing %q\n\nOutput:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// warningOutput receives validation warnings from the result loaders.
+var warningOutput io.Writer = os.Stderr
+
+// ValidateResults checks every result in a results file for internal
+// consistency and returns one error per violation, naming the result:
+//   - a success has no errorType
+//   - a capacity skip is a valid rejection (errorType "capacity",
+//     "emptyData", or "unsupported") and not a success
+//   - encode, decode, and phase times are not negative
+//   - pixelSize is positive and dataSize is not negative (0 is the
+//     empty-payload edge case)
+//
+// A truncated or hand-edited file that fails these checks would otherwise
+// skew the statistics silently.
+func ValidateResults(r RawResults) []error {
+	var errs []error
+	for i, res := range r.Results {
+		fail := func(format string, args ...interface{}) {
+			errs = append(errs, fmt.Errorf("result %d (%s): %s", i, resultKey(res), fmt.Sprintf(format, args...)))
+		}
+
+		if res.Success && res.ErrorType != "" {
+			fail("success with errorType %q", res.ErrorType)
+		}
+		if res.IsCapacityExceeded {
+			if res.Success {
+				fail("capacity exceeded but marked success")
+			}
+			if res.ErrorType != "capacity" && res.ErrorType != "emptyData" && res.ErrorType != "unsupported" {
+				fail("capacity exceeded with errorType %q, want a valid rejection", res.ErrorType)
+			}
+		}
+
+		times := []struct {
+			name string
+			ms   float64
+		}{
+			{"encodeTimeMs", res.EncodeTimeMs},
+			{"decodeTimeMs", res.DecodeTimeMs},
+			{"qrConstructTimeMs", res.QRConstructTimeMs},
+			{"imageEncodeTimeMs", res.ImageEncodeTimeMs},
+			{"imageDecodeTimeMs", res.ImageDecodeTimeMs},
+		}
+		for _, t := range times {
+			if t.ms < 0 {
+				fail("negative %s %.3f", t.name, t.ms)
+			}
+		}
+
+		if res.PixelSize <= 0 {
+			fail("pixelSize must be positive, got %d", res.PixelSize)
+		}
+		if res.DataSize < 0 {
+			fail("dataSize must not be negative, got %d", res.DataSize)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,71 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestValidateResults(t *testing.T) {
+	valid := []RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "makiuchi-d/gozxing", DataSize: 100, PixelSize: 300, Success: true, EncodeTimeMs: 1.5, DecodeTimeMs: 2},
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, ErrorType: "decode"},
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 5000, PixelSize: 300, ErrorType: "capacity", IsCapacityExceeded: true},
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 0, PixelSize: 300, ErrorType: "emptyData", IsCapacityExceeded: true},
+	}
+	if errs := ValidateResults(RawResults{Results: valid}); len(errs) != 0 {
+		t.Errorf("ValidateResults() on consistent results = %v, want none", errs)
+	}
+
+	tests := []struct {
+		name    string
+		result  RawTestResult
+		wantErr string
+	}{
+		{"success with error type", RawTestResult{DataSize: 100, PixelSize: 300, Success: true, ErrorType: "decode"}, `success with errorType "decode"`},
+		{"capacity without encode error", RawTestResult{DataSize: 100, PixelSize: 300, ErrorType: "decode", IsCapacityExceeded: true}, `capacity exceeded with errorType "decode"`},
+		{"negative decode time", RawTestResult{DataSize: 100, PixelSize: 300, Success: true, DecodeTimeMs: -1}, "negative decodeTimeMs"},
+		{"negative phase time", RawTestResult{DataSize: 100, PixelSize: 300, Success: true, ImageEncodeTimeMs: -0.5}, "negative imageEncodeTimeMs"},
+		{"zero pixel size", RawTestResult{DataSize: 100, Success: true}, "pixelSize must be positive"},
+		{"negative data size", RawTestResult{DataSize: -1, PixelSize: 300, Success: true}, "dataSize must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := append(append([]RawTestResult{}, valid...), tt.result)
+			errs := ValidateResults(RawResults{Results: results})
+			if len(errs) != 1 {
+				t.Fatalf("ValidateResults() returned %d errors, want 1: %v", len(errs), errs)
+			}
+			if !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("ValidateResults() error = %q, want it to contain %q", errs[0], tt.wantErr)
+			}
+			if !strings.HasPrefix(errs[0].Error(), "result 4 ") {
+				t.Errorf("ValidateResults() error = %q, want it to name result 4", errs[0])
+			}
+		})
+	}
+}
+
+func TestLoadResults_WarnsOnInconsistentResults(t *testing.T) {
+	var warnings bytes.Buffer
+	saved := warningOutput
+	warningOutput = &warnings
+	defer func() { warningOutput = saved }()
+
+	dir := t.TempDir()
+	writeTree(t, dir, "2026-01-01T00:00:00Z", []RawTestResult{
+		{Encoder: "skip2/go-qrcode", Decoder: "tuotoo/qrcode", DataSize: 100, PixelSize: 300, Success: true, ErrorType: "decode"},
+	})
+
+	results, err := LoadResults(dir)
+	if err != nil {
+		t.Fatalf("LoadResults() failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("LoadResults() returned %d results, want the inconsistent result kept", len(results))
+	}
+	if !strings.Contains(warnings.String(), `success with errorType "decode"`) {
+		t.Errorf("Warnings = %q, want the inconsistent result reported", warnings.String())
+	}
+}